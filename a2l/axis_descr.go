@@ -8,7 +8,7 @@ import (
 )
 
 /*
-axisDescr is the Axis description within an adjustable object
+AxisDescr is the Axis description within an adjustable object
 Note:
 With the 'input quantity' parameter a reference is made to a measurement object
 (MEASUREMENT). The MEASUREMENT keyword also specifies the
@@ -42,49 +42,49 @@ It is recommended not to do so, but if the dimension is defined twice and if it
 not equal, then application systems shall always use the dimension of the
 AXIS_PTS object.
 */
-type axisDescr struct {
-	attribute    attributeEnum
-	attributeSet bool
+type AxisDescr struct {
+	Attribute    attributeEnum
+	AttributeSet bool
 	/*inputQuantity references the data record for description of the input quantity (see MEASUREMENT).
 	If there is no input quantity assigned, parameter 'InputQuantity' should be set to "NO_INPUT_QUANTITY"
 	(measurement and calibration systems must be capable to treat this case).
 	Note: If the referenced input quantity is an element of an array or a component of a structure,
 	the identifier to be used for the reference shall be the name built according to rules described at INSTANCE.*/
-	inputQuantity    string
-	inputQuantitySet bool
+	InputQuantity    string
+	InputQuantitySet bool
 	/*conversion references the relevant record of the description of the conversion method (see COMPU_METHOD).
 	If there is no conversion method, as in the case of CURVE_AXIS, the parameter ‘Conversion’
 	should be set to "NO_COMPU_METHOD" (measurement and calibration systems must be able to handle this case).*/
-	conversion    string
-	conversionSet bool
+	Conversion    string
+	ConversionSet bool
 	//maximum number of axis points
-	maxAxisPoints    uint16
-	maxAxisPointsSet bool
+	MaxAxisPoints    uint16
+	MaxAxisPointsSet bool
 	//lowerLimit quantifies the plausible range of axis point values, lower limit
-	lowerLimit    float64
-	lowerLimitSet bool
+	LowerLimit    float64
+	LowerLimitSet bool
 	//upperLimit quantifies the plausible range of axis point values, upper limit
-	upperLimit     float64
-	upperLimitSet  bool
-	annotation     []annotation
-	axisPtsRef     axisPtsRef
-	byteOrder      ByteOrder
-	curveAxisRef   curveAxisRef
-	deposit        deposit
-	extendedLimits extendedLimits
-	fixAxisPar     fixAxisPar
-	fixAxisParDist fixAxisParDist
-	fixAxisParList []fixAxisParList
-	format         format
-	maxGrad        MaxGrad
-	monotony       Monotony
-	physUnit       physUnit
-	readOnly       readOnlyKeyword
-	stepSize       StepSize
+	UpperLimit     float64
+	UpperLimitSet  bool
+	Annotation     []annotation
+	AxisPtsRef     axisPtsRef
+	ByteOrder      ByteOrder
+	CurveAxisRef   curveAxisRef
+	Deposit        deposit
+	ExtendedLimits extendedLimits
+	FixAxisPar     fixAxisPar
+	FixAxisParDist fixAxisParDist
+	FixAxisParList []fixAxisParList
+	Format         format
+	MaxGrad        MaxGrad
+	Monotony       Monotony
+	PhysUnit       physUnit
+	ReadOnly       readOnlyKeyword
+	StepSize       StepSize
 }
 
-func parseAxisDescr(tok *tokenGenerator) (axisDescr, error) {
-	ad := axisDescr{}
+func parseAxisDescr(tok *tokenGenerator) (AxisDescr, error) {
+	ad := AxisDescr{}
 	var err error
 forLoop:
 	for {
@@ -96,52 +96,52 @@ forLoop:
 				log.Err(err).Msg("axisDescr annotation could not be parsed")
 				break forLoop
 			}
-			ad.annotation = append(ad.annotation, buf)
+			ad.Annotation = append(ad.Annotation, buf)
 			log.Info().Msg("axisDescr annotation successfully parsed")
 		case axisPtsRefToken:
-			ad.axisPtsRef, err = parseAxisPtsRef(tok)
+			ad.AxisPtsRef, err = parseAxisPtsRef(tok)
 			if err != nil {
 				log.Err(err).Msg("axisDescr axisPtsRef could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisDescr axisPtsRef successfully parsed")
 		case byteOrderToken:
-			ad.byteOrder, err = parseByteOrder(tok)
+			ad.ByteOrder, err = parseByteOrder(tok)
 			if err != nil {
 				log.Err(err).Msg("axisDescr byteOrder could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisDescr byteOrder successfully parsed")
 		case curveAxisRefToken:
-			ad.curveAxisRef, err = parseCurveAxisRef(tok)
+			ad.CurveAxisRef, err = parseCurveAxisRef(tok)
 			if err != nil {
 				log.Err(err).Msg("axisDescr curveAxisRef could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisDescr curveAxisRef successfully parsed")
 		case depositToken:
-			ad.deposit, err = parseDeposit(tok)
+			ad.Deposit, err = parseDeposit(tok)
 			if err != nil {
 				log.Err(err).Msg("axisDescr deposit could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisDescr deposit successfully parsed")
 		case extendedLimitsToken:
-			ad.extendedLimits, err = parseExtendedLimits(tok)
+			ad.ExtendedLimits, err = parseExtendedLimits(tok)
 			if err != nil {
 				log.Err(err).Msg("axisDescr extendedLimits could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisDescr extendedLimits successfully parsed")
 		case fixAxisParToken:
-			ad.fixAxisPar, err = parseFixAxisPar(tok)
+			ad.FixAxisPar, err = parseFixAxisPar(tok)
 			if err != nil {
 				log.Err(err).Msg("axisDescr fixAxisPar could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisDescr fixAxisPar successfully parsed")
 		case fixAxisParDistToken:
-			ad.fixAxisParDist, err = parseFixAxisParDist(tok)
+			ad.FixAxisParDist, err = parseFixAxisParDist(tok)
 			if err != nil {
 				log.Err(err).Msg("axisDescr fixAxisParDist could not be parsed")
 				break forLoop
@@ -154,45 +154,45 @@ forLoop:
 				log.Err(err).Msg("axisDescr fixAxisParList could not be parsed")
 				break forLoop
 			}
-			ad.fixAxisParList = append(ad.fixAxisParList, buf)
+			ad.FixAxisParList = append(ad.FixAxisParList, buf)
 			log.Info().Msg("axisDescr fixAxisParList successfully parsed")
 		case formatToken:
-			ad.format, err = parseFormat(tok)
+			ad.Format, err = parseFormat(tok)
 			if err != nil {
 				log.Err(err).Msg("axisDescr format could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisDescr format successfully parsed")
 		case maxGradToken:
-			ad.maxGrad, err = parseMaxGrad(tok)
+			ad.MaxGrad, err = parseMaxGrad(tok)
 			if err != nil {
 				log.Err(err).Msg("axisDescr maxGrad could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisDescr maxGrad successfully parsed")
 		case monotonyToken:
-			ad.monotony, err = parseMonotony(tok)
+			ad.Monotony, err = parseMonotony(tok)
 			if err != nil {
 				log.Err(err).Msg("axisDescr monotony could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisDescr monotony successfully parsed")
 		case physUnitToken:
-			ad.physUnit, err = parsePhysUnit(tok)
+			ad.PhysUnit, err = parsePhysUnit(tok)
 			if err != nil {
 				log.Err(err).Msg("axisDescr physUnit could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisDescr physUnit successfully parsed")
 		case readOnlyToken:
-			ad.readOnly, err = parseReadOnly(tok)
+			ad.ReadOnly, err = parseReadOnly(tok)
 			if err != nil {
 				log.Err(err).Msg("axisDescr readOnly could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisDescr readOnly successfully parsed")
 		case stepSizeToken:
-			ad.stepSize, err = parseStepSize(tok)
+			ad.StepSize, err = parseStepSize(tok)
 			if err != nil {
 				log.Err(err).Msg("axisDescr stepSize could not be parsed")
 				break forLoop
@@ -209,51 +209,51 @@ forLoop:
 				err = errors.New("unexpected token " + tok.current())
 				log.Err(err).Msg("axisDescr could not be parsed")
 				break forLoop
-			} else if !ad.attributeSet {
-				ad.attribute, err = parseAttributeEnum(tok)
+			} else if !ad.AttributeSet {
+				ad.Attribute, err = parseAttributeEnum(tok)
 				if err != nil {
 					log.Err(err).Msg("axisDescr attribute could not be parsed")
 					break forLoop
 				}
-				ad.attributeSet = true
+				ad.AttributeSet = true
 				log.Info().Msg("axisDescr attribute successfully parsed")
-			} else if !ad.inputQuantitySet {
-				ad.inputQuantity = tok.current()
-				ad.inputQuantitySet = true
+			} else if !ad.InputQuantitySet {
+				ad.InputQuantity = tok.current()
+				ad.InputQuantitySet = true
 				log.Info().Msg("axisDescr inputQuantity successfully parsed")
-			} else if !ad.conversionSet {
-				ad.conversion = tok.current()
-				ad.conversionSet = true
+			} else if !ad.ConversionSet {
+				ad.Conversion = tok.current()
+				ad.ConversionSet = true
 				log.Info().Msg("axisDescr conversionSet successfully parsed")
-			} else if !ad.maxAxisPointsSet {
+			} else if !ad.MaxAxisPointsSet {
 				var buf uint64
 				buf, err = strconv.ParseUint(tok.current(), 10, 16)
 				if err != nil {
 					log.Err(err).Msg("axisDescr maxAxisPoints could not be parsed")
 					break forLoop
 				}
-				ad.maxAxisPoints = uint16(buf)
-				ad.maxAxisPointsSet = true
+				ad.MaxAxisPoints = uint16(buf)
+				ad.MaxAxisPointsSet = true
 				log.Info().Msg("axisDescr maxAxisPoints successfully parsed")
-			} else if !ad.lowerLimitSet {
+			} else if !ad.LowerLimitSet {
 				var buf float64
 				buf, err = strconv.ParseFloat(tok.current(), 64)
 				if err != nil {
 					log.Err(err).Msg("axisDescr lowerLimit could not be parsed")
 					break forLoop
 				}
-				ad.lowerLimit = buf
-				ad.lowerLimitSet = true
+				ad.LowerLimit = buf
+				ad.LowerLimitSet = true
 				log.Info().Msg("axisDescr lowerLimit successfully parsed")
-			} else if !ad.upperLimitSet {
+			} else if !ad.UpperLimitSet {
 				var buf float64
 				buf, err = strconv.ParseFloat(tok.current(), 64)
 				if err != nil {
 					log.Err(err).Msg("axisDescr upperLimit could not be parsed")
 					break forLoop
 				}
-				ad.upperLimit = buf
-				ad.upperLimitSet = true
+				ad.UpperLimit = buf
+				ad.UpperLimitSet = true
 				log.Info().Msg("axisDescr upperLimit successfully parsed")
 			}
 		}
@@ -11,69 +11,69 @@ import (
 AXIS_PTS and AXIS_DESCR define the same parameters.
 Which parameters are dominate is described at AXIS_DESCR.
 */
-type axisPts struct {
+type AxisPts struct {
 	/*The name has to be unique within all measure and adjustable objects of the MODULE,
 	i.e. there must	not be another AXIS_PTS, MEASUREMENT, CHARACTERISTIC, BLOB or INSTANCE object
 	with an equal identifier in the same MODULE. Furthermore it is not allowed to have a structure
 	component with equal name in the MODULE. (Rules for building the full identifiers of structure
 	components: see at INSTANCE).*/
-	name              string
-	nameSet           bool
-	longIdentifier    string
-	longIdentifierSet bool
+	Name              string
+	NameSet           bool
+	LongIdentifier    string
+	LongIdentifierSet bool
 	//address of the adjustable object in the emulation memory
-	address    string //uint32
-	addressSet bool
+	Address    string //uint32
+	AddressSet bool
 	/*inputQuantity references the data record for description of the input	quantity (see MEASUREMENT).
 	If there is no input quantity assigned, parameter 'InputQuantity' should be set to "NO_INPUT_QUANTITY"
 	(measurement and calibration systems must be capable to treat this case).*/
-	inputQuantity    string
-	inputQuantitySet bool
+	InputQuantity    string
+	InputQuantitySet bool
 	//reference to the relevant data record for description of the record layout (see RECORD_LAYOUT)
-	depositIdent    string
-	depositIdentSet bool
+	DepositIdent    string
+	DepositIdentSet bool
 	/*Maximum difference of physical value recommended for parameter change within one calibration step.
 	If the difference in change exceeds this value, control	algorithms might fail.
 	The value 0 describes that there is	no limit.*/
-	maxDiff    float64
-	maxDiffSet bool
+	MaxDiff    float64
+	MaxDiffSet bool
 	/*Reference to the relevant record of the description of the conversion method (see COMPU_METHOD).
 	If there is no conversion method, as in the case of CURVE_AXIS,
 	the parameter ‘Conversion’ should be set to "NO_COMPU_METHOD"
 	(measurement and calibration systems must be able to handle this case).*/
-	conversion    string
-	conversionSet bool
+	Conversion    string
+	ConversionSet bool
 	//maximum number of axis points
-	maxAxisPoints    uint16
-	maxAxisPointsSet bool
+	MaxAxisPoints    uint16
+	MaxAxisPointsSet bool
 	//plausible range of axis point values, lower limit
-	lowerLimit    float64
-	lowerLimitSet bool
+	LowerLimit    float64
+	LowerLimitSet bool
 	//plausible range of axis point values, upper limit
-	upperLimit          float64
-	upperLimitSet       bool
-	annotation          []annotation
-	byteOrder           ByteOrder
-	calibrationAccess   calibrationAccessEnum
-	deposit             deposit
-	displayIdentifier   DisplayIdentifier
-	ecuAddressExtension ecuAddressExtension
-	extendedLimits      extendedLimits
-	format              format
-	functionList        []FunctionList
-	guardRails          guardRailsKeyword
-	ifData              []IfData
-	monotony            Monotony
-	modelLink           modelLink
-	physUnit            physUnit
-	readOnly            readOnlyKeyword
-	refMemorySegment    refMemorySegment
-	stepSize            StepSize
-	symbolLink          symbolLink
+	UpperLimit          float64
+	UpperLimitSet       bool
+	Annotation          []annotation
+	ByteOrder           ByteOrder
+	CalibrationAccess   CalibrationAccessEnum
+	Deposit             deposit
+	DisplayIdentifier   DisplayIdentifier
+	EcuAddressExtension ecuAddressExtension
+	ExtendedLimits      extendedLimits
+	Format              format
+	FunctionList        []FunctionList
+	GuardRails          guardRailsKeyword
+	IfData              []IfData
+	Monotony            Monotony
+	ModelLink           modelLink
+	PhysUnit            physUnit
+	ReadOnly            readOnlyKeyword
+	RefMemorySegment    refMemorySegment
+	StepSize            StepSize
+	SymbolLink          symbolLink
 }
 
-func parseAxisPts(tok *tokenGenerator) (axisPts, error) {
-	ap := axisPts{}
+func parseAxisPts(tok *tokenGenerator) (AxisPts, error) {
+	ap := AxisPts{}
 	var err error
 forLoop:
 	for {
@@ -85,52 +85,52 @@ forLoop:
 				log.Err(err).Msg("axisPts annotation could not be parsed")
 				break forLoop
 			}
-			ap.annotation = append(ap.annotation, buf)
+			ap.Annotation = append(ap.Annotation, buf)
 			log.Info().Msg("axisPts annotation successfully parsed")
 		case byteOrderToken:
-			ap.byteOrder, err = parseByteOrder(tok)
+			ap.ByteOrder, err = parseByteOrder(tok)
 			if err != nil {
 				log.Err(err).Msg("axisPts byteOrder could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisPts byteOrder successfully parsed")
 		case calibrationAccessToken:
-			ap.calibrationAccess, err = parseCalibrationAccessEnum(tok)
+			ap.CalibrationAccess, err = parseCalibrationAccessEnum(tok)
 			if err != nil {
 				log.Err(err).Msg("axisPts calibrationAccess could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisPts calibrationAccess successfully parsed")
 		case depositToken:
-			ap.deposit, err = parseDeposit(tok)
+			ap.Deposit, err = parseDeposit(tok)
 			if err != nil {
 				log.Err(err).Msg("axisPts deposit could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisPts deposit successfully parsed")
 		case displayIdentifierToken:
-			ap.displayIdentifier, err = parseDisplayIdentifier(tok)
+			ap.DisplayIdentifier, err = parseDisplayIdentifier(tok)
 			if err != nil {
 				log.Err(err).Msg("axisPts displayIdentifier could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisPts displayIdentifier successfully parsed")
 		case ecuAddressExtensionToken:
-			ap.ecuAddressExtension, err = parseECUAddressExtension(tok)
+			ap.EcuAddressExtension, err = parseECUAddressExtension(tok)
 			if err != nil {
 				log.Err(err).Msg("axisPts ecuAddressExtension could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisPts ecuAddressExtension successfully parsed")
 		case extendedLimitsToken:
-			ap.extendedLimits, err = parseExtendedLimits(tok)
+			ap.ExtendedLimits, err = parseExtendedLimits(tok)
 			if err != nil {
 				log.Err(err).Msg("axisPts extendedLimits could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisPts extendedLimits successfully parsed")
 		case formatToken:
-			ap.format, err = parseFormat(tok)
+			ap.Format, err = parseFormat(tok)
 			if err != nil {
 				log.Err(err).Msg("axisPts format could not be parsed")
 				break forLoop
@@ -143,10 +143,10 @@ forLoop:
 				log.Err(err).Msg("axisPts functionList could not be parsed")
 				break forLoop
 			}
-			ap.functionList = append(ap.functionList, buf)
+			ap.FunctionList = append(ap.FunctionList, buf)
 			log.Info().Msg("axisPts functionList successfully parsed")
 		case guardRailsToken:
-			ap.guardRails, err = parseGuardRails(tok)
+			ap.GuardRails, err = parseGuardRails(tok)
 			if err != nil {
 				log.Err(err).Msg("axisPts guardRails could not be parsed")
 				break forLoop
@@ -159,52 +159,52 @@ forLoop:
 				log.Err(err).Msg("axisPts ifData could not be parsed")
 				break forLoop
 			}
-			ap.ifData = append(ap.ifData, buf)
+			ap.IfData = append(ap.IfData, buf)
 			log.Info().Msg("axisPts ifData successfully parsed")
 		case modelLinkToken:
-			ap.modelLink, err = parseModelLink(tok)
+			ap.ModelLink, err = parseModelLink(tok)
 			if err != nil {
 				log.Err(err).Msg("axisPts modelLink could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisPts modelLink successfully parsed")
 		case monotonyToken:
-			ap.monotony, err = parseMonotony(tok)
+			ap.Monotony, err = parseMonotony(tok)
 			if err != nil {
 				log.Err(err).Msg("axisPts monotony could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisPts monotony successfully parsed")
 		case physUnitToken:
-			ap.physUnit, err = parsePhysUnit(tok)
+			ap.PhysUnit, err = parsePhysUnit(tok)
 			if err != nil {
 				log.Err(err).Msg("axisPts physUnit could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisPts physUnit successfully parsed")
 		case readOnlyToken:
-			ap.readOnly, err = parseReadOnly(tok)
+			ap.ReadOnly, err = parseReadOnly(tok)
 			if err != nil {
 				log.Err(err).Msg("axisPts readOnly could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisPts readOnly successfully parsed")
 		case refMemorySegmentToken:
-			ap.refMemorySegment, err = parseRefMemorySegment(tok)
+			ap.RefMemorySegment, err = parseRefMemorySegment(tok)
 			if err != nil {
 				log.Err(err).Msg("axisPts refMemorySegment could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisPts refMemorySegment successfully parsed")
 		case stepSizeToken:
-			ap.stepSize, err = parseStepSize(tok)
+			ap.StepSize, err = parseStepSize(tok)
 			if err != nil {
 				log.Err(err).Msg("axisPts stepSize could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("axisPts stepSize successfully parsed")
 		case symbolLinkToken:
-			ap.symbolLink, err = parseSymbolLink(tok)
+			ap.SymbolLink, err = parseSymbolLink(tok)
 			if err != nil {
 				log.Err(err).Msg("axisPts symbolLink could not be parsed")
 				break forLoop
@@ -221,69 +221,69 @@ forLoop:
 				err = errors.New("unexpected token " + tok.current())
 				log.Err(err).Msg("axisPts could not be parsed")
 				break forLoop
-			} else if !ap.nameSet {
-				ap.name = tok.current()
-				ap.nameSet = true
+			} else if !ap.NameSet {
+				ap.Name = tok.current()
+				ap.NameSet = true
 				log.Info().Msg("axisPts name successfully parsed")
-			} else if !ap.longIdentifierSet {
-				ap.longIdentifier = tok.current()
-				ap.longIdentifierSet = true
+			} else if !ap.LongIdentifierSet {
+				ap.LongIdentifier = tok.current()
+				ap.LongIdentifierSet = true
 				log.Info().Msg("axisPts longIdentifier successfully parsed")
-			} else if !ap.addressSet {
-				ap.address = tok.current()
-				ap.addressSet = true
+			} else if !ap.AddressSet {
+				ap.Address = tok.current()
+				ap.AddressSet = true
 				log.Info().Msg("axisPts address successfully parsed")
-			} else if !ap.inputQuantitySet {
-				ap.inputQuantity = tok.current()
-				ap.inputQuantitySet = true
+			} else if !ap.InputQuantitySet {
+				ap.InputQuantity = tok.current()
+				ap.InputQuantitySet = true
 				log.Info().Msg("axisPts inputQuantity successfully parsed")
-			} else if !ap.depositIdentSet {
-				ap.depositIdent = tok.current()
-				ap.depositIdentSet = true
+			} else if !ap.DepositIdentSet {
+				ap.DepositIdent = tok.current()
+				ap.DepositIdentSet = true
 				log.Info().Msg("axisPts depositIdent successfully parsed")
-			} else if !ap.maxDiffSet {
+			} else if !ap.MaxDiffSet {
 				var buf float64
 				buf, err = strconv.ParseFloat(tok.current(), 64)
 				if err != nil {
 					log.Err(err).Msg("axisPts maxDiff could not be parsed")
 					break forLoop
 				}
-				ap.maxDiff = buf
-				ap.maxDiffSet = true
+				ap.MaxDiff = buf
+				ap.MaxDiffSet = true
 				log.Info().Msg("axisPts maxDiff successfully parsed")
-			} else if !ap.conversionSet {
-				ap.conversion = tok.current()
-				ap.conversionSet = true
+			} else if !ap.ConversionSet {
+				ap.Conversion = tok.current()
+				ap.ConversionSet = true
 				log.Info().Msg("axisPts conversion successfully parsed")
-			} else if !ap.maxAxisPointsSet {
+			} else if !ap.MaxAxisPointsSet {
 				var buf uint64
 				buf, err = strconv.ParseUint(tok.current(), 10, 16)
 				if err != nil {
 					log.Err(err).Msg("axisPts maxAxisPoints could not be parsed")
 					break forLoop
 				}
-				ap.maxAxisPoints = uint16(buf)
-				ap.maxAxisPointsSet = true
+				ap.MaxAxisPoints = uint16(buf)
+				ap.MaxAxisPointsSet = true
 				log.Info().Msg("axisPts maxAxisPoints successfully parsed")
-			} else if !ap.lowerLimitSet {
+			} else if !ap.LowerLimitSet {
 				var buf float64
 				buf, err = strconv.ParseFloat(tok.current(), 64)
 				if err != nil {
 					log.Err(err).Msg("axisPts lowerLimit could not be parsed")
 					break forLoop
 				}
-				ap.lowerLimit = buf
-				ap.lowerLimitSet = true
+				ap.LowerLimit = buf
+				ap.LowerLimitSet = true
 				log.Info().Msg("axisPts lowerLimit successfully parsed")
-			} else if !ap.upperLimitSet {
+			} else if !ap.UpperLimitSet {
 				var buf float64
 				buf, err = strconv.ParseFloat(tok.current(), 64)
 				if err != nil {
 					log.Err(err).Msg("axisPts upperLimit could not be parsed")
 					break forLoop
 				}
-				ap.upperLimit = buf
-				ap.upperLimitSet = true
+				ap.UpperLimit = buf
+				ap.UpperLimitSet = true
 				log.Info().Msg("axisPts upperLimit successfully parsed")
 			}
 		}
@@ -17,8 +17,8 @@ type axisPtsRef struct {
 	Note: If (and only if) the axis description is utilized inside a structure definition and the common axis
 	referenced by this keyword is a component of the same structure, then it is also allowed to refer to
 	the axis by using the THIS keyword followed by a dot and the component name of the axis instead of using a concrete instance name.*/
-	axisPoints    string
-	axisPointsSet bool
+	AxisPoints    string
+	AxisPointsSet bool
 }
 
 func parseAxisPtsRef(tok *tokenGenerator) (axisPtsRef, error) {
@@ -31,9 +31,9 @@ func parseAxisPtsRef(tok *tokenGenerator) (axisPtsRef, error) {
 	} else if isKeyword(tok.current()) {
 		err = errors.New("unexpected token " + tok.current())
 		log.Err(err).Msg("axisPtsRef could not be parsed")
-	} else if !apr.axisPointsSet {
-		apr.axisPoints = tok.current()
-		apr.axisPointsSet = true
+	} else if !apr.AxisPointsSet {
+		apr.AxisPoints = tok.current()
+		apr.AxisPointsSet = true
 		log.Info().Msg("axisPtsRef axisPoints successfully parsed")
 	}
 	return apr, err
@@ -21,7 +21,7 @@ type blob struct {
 	addressType         AddrTypeEnum
 	addressTypeSet      bool
 	annotation          []annotation
-	calibrationAccess   calibrationAccessEnum
+	calibrationAccess   CalibrationAccessEnum
 	displayIdentifier   DisplayIdentifier
 	ecuAddressExtension ecuAddressExtension
 	IfData              []IfData
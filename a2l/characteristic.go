@@ -30,11 +30,11 @@ type Characteristic struct {
 	UpperLimit    float64
 	UpperLimitSet bool
 	Annotation    []annotation
-	AxisDescr     []axisDescr
+	AxisDescr     []AxisDescr
 	BitMask       bitMask
 	//byteOrder can be used to overwrite the standard byte order defined in mod par
 	ByteOrder               ByteOrder
-	CalibrationAccess       calibrationAccessEnum
+	CalibrationAccess       CalibrationAccessEnum
 	ComparisonQuantity      comparisonQuantity
 	DependentCharacteristic []DependentCharacteristic
 	Discrete                discreteKeyword
@@ -74,7 +74,7 @@ forLoop:
 			c.Annotation = append(c.Annotation, buf)
 			log.Info().Msg("characteristic annotation successfully parsed")
 		case beginAxisDescrToken:
-			var buf axisDescr
+			var buf AxisDescr
 			buf, err = parseAxisDescr(tok)
 			if err != nil {
 				log.Err(err).Msg("characteristic axisDescr could not be parsed")
@@ -21,7 +21,7 @@ type CompuMethod struct {
 	CoeffsLinear      CoeffsLinear
 	CompuTabRef       CompuTabRef
 	Formula           []Formula
-	RefUnit           refUnit
+	RefUnit           RefUnit
 	StatusStringRef   StatusStringRef
 }
 
@@ -7,8 +7,8 @@ import (
 )
 
 type curveAxisRef struct {
-	curveAxis    string
-	curveAxisSet bool
+	CurveAxis    string
+	CurveAxisSet bool
 }
 
 func parseCurveAxisRef(tok *tokenGenerator) (curveAxisRef, error) {
@@ -21,9 +21,9 @@ func parseCurveAxisRef(tok *tokenGenerator) (curveAxisRef, error) {
 	} else if isKeyword(tok.current()) {
 		err = errors.New("unexpected token " + tok.current())
 		log.Err(err).Msg("curveAxisRef could not be parsed")
-	} else if !car.curveAxisSet {
-		car.curveAxis = tok.current()
-		car.curveAxisSet = true
+	} else if !car.CurveAxisSet {
+		car.CurveAxis = tok.current()
+		car.CurveAxisSet = true
 		log.Info().Msg("curveAxisRef curveAxis successfully parsed")
 	}
 	return car, err
@@ -6,11 +6,17 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+/*
+DependentCharacteristic defines a characteristic whose value depends on other characteristics.
+the value is stored within the ecu memory and has to be updated by the MC-System whenever one of the referenced characteristics changes.
+Formula computes the physical value from the physical values of the referenced characteristics,
+which are addressed as X1, X2, ... in the order of Characteristic.
+*/
 type DependentCharacteristic struct {
-	formula           string
-	formulaSet        bool
-	characteristic    []string
-	characteristicSet bool
+	Formula           string
+	FormulaSet        bool
+	Characteristic    []string
+	CharacteristicSet bool
 }
 
 func parseDependentCharacteristic(tok *tokenGenerator) (DependentCharacteristic, error) {
@@ -24,19 +30,19 @@ forLoop:
 			log.Err(err).Msg("dependentCharacteristic could not be parsed")
 			break forLoop
 		} else if tok.current() == endDependentCharacteristicToken {
-			dc.characteristicSet = true
+			dc.CharacteristicSet = true
 			log.Info().Msg("dependentCharacteristic characteristic successfully parsed")
 			break forLoop
 		} else if isKeyword(tok.current()) {
 			err = errors.New("unexpected token " + tok.current())
 			log.Err(err).Msg("dependentCharacteristic could not be parsed")
 			break forLoop
-		} else if !dc.formulaSet {
-			dc.formula = tok.current()
-			dc.formulaSet = true
+		} else if !dc.FormulaSet {
+			dc.Formula = tok.current()
+			dc.FormulaSet = true
 			log.Info().Msg("dependentCharacteristic formula successfully parsed")
-		} else if !dc.characteristicSet {
-			dc.characteristic = append(dc.characteristic, tok.current())
+		} else if !dc.CharacteristicSet {
+			dc.Characteristic = append(dc.Characteristic, tok.current())
 		}
 
 	}
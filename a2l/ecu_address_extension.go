@@ -18,8 +18,8 @@ and measurement objects, the keyword ECU_ADDRESS_EXTENSION has been
 introduced
 */
 type ecuAddressExtension struct {
-	extension    int16
-	extensionSet bool
+	Extension    int16
+	ExtensionSet bool
 }
 
 func parseECUAddressExtension(tok *tokenGenerator) (ecuAddressExtension, error) {
@@ -32,14 +32,14 @@ func parseECUAddressExtension(tok *tokenGenerator) (ecuAddressExtension, error)
 	} else if isKeyword(tok.current()) {
 		err = errors.New("unexpected token " + tok.current())
 		log.Err(err).Msg("ecuAddressExtension could not be parsed")
-	} else if !eae.extensionSet {
+	} else if !eae.ExtensionSet {
 		var buf int64
 		buf, err = strconv.ParseInt(tok.current(), 10, 16)
 		if err != nil {
 			log.Err(err).Msg("ecuAddressExtension extension could not be parsed")
 		}
-		eae.extension = int16(buf)
-		eae.extensionSet = true
+		eae.Extension = int16(buf)
+		eae.ExtensionSet = true
 		log.Info().Msg("ecuAddressExtension extension successfully parsed")
 	}
 	return eae, err
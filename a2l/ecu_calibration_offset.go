@@ -17,8 +17,8 @@ vehicle projects served by one common ECU. By using the
 ECU_CALIBRATION_OFFSET, a selection for project base address can be made
 */
 type ecuCalibrationOffset struct {
-	offset    string
-	offsetSet bool
+	Offset    string
+	OffsetSet bool
 }
 
 func parseEcuCalibrationOffset(tok *tokenGenerator) (ecuCalibrationOffset, error) {
@@ -31,9 +31,9 @@ func parseEcuCalibrationOffset(tok *tokenGenerator) (ecuCalibrationOffset, error
 	} else if isKeyword(tok.current()) {
 		err = errors.New("unexpected token " + tok.current())
 		log.Err(err).Msg("ecuCalibrationOffset could not be parsed")
-	} else if !eco.offsetSet {
-		eco.offset = tok.current()
-		eco.offsetSet = true
+	} else if !eco.OffsetSet {
+		eco.Offset = tok.current()
+		eco.OffsetSet = true
 		log.Info().Msg("ecuCalibrationOffset offset successfully parsed")
 	}
 	return eco, err
@@ -41,13 +41,13 @@ const (
 	UBYTE DataTypeEnum = ubyteToken
 	//SBYTE is defined as an unsigned 8 bit integer
 	SBYTE DataTypeEnum = sbyteToken
-	//UWORD seems to be statically defined as a 32 bit unsigned integer
+	//UWORD is defined as a 16 bit unsigned integer
 	UWORD DataTypeEnum = uwordToken
-	//SWORD seems to be statically defined as a 32 bit signed integer
+	//SWORD is defined as a 16 bit signed integer
 	SWORD DataTypeEnum = swordToken
-	//ULONG is defined as a 64 bit unsigned integer
+	//ULONG is defined as a 32 bit unsigned integer
 	ULONG DataTypeEnum = ulongToken
-	//SLONG is defined as a 64 bit signed integer
+	//SLONG is defined as a 32 bit signed integer
 	SLONG DataTypeEnum = slongToken
 	//AUint64 is defined as a 64 bit unsigned integer
 	AUint64 DataTypeEnum = aUint64Token
@@ -104,10 +104,10 @@ func (dte *DataTypeEnum) GetType() interface{} {
 		var t int8
 		return t
 	case UWORD:
-		var t uint32
+		var t uint16
 		return t
 	case SWORD:
-		var t int32
+		var t int16
 		return t
 	case ULONG:
 		var t uint32
@@ -142,9 +142,9 @@ func (dte *DataTypeEnum) GetDatatypeLength() uint16 {
 	case SBYTE:
 		return 8
 	case UWORD:
-		return 32
+		return 16
 	case SWORD:
-		return 32
+		return 16
 	case ULONG:
 		return 32
 	case SLONG:
@@ -211,9 +211,9 @@ func (dse *DataSizeEnum) GetDataSizeLength() uint16 {
 	case BYTE:
 		return 8
 	case WORD:
-		return 32
+		return 16
 	case LONG:
-		return 64
+		return 32
 	default:
 		return 0
 	}
@@ -262,6 +262,8 @@ func parseAddrTypeEnum(tok *tokenGenerator) (AddrTypeEnum, error) {
 		a = PWORD
 	case plongToken:
 		a = PLONG
+	case plonLongToken:
+		a = PLONGLONG
 	case directToken:
 		a = DIRECT
 	default:
@@ -327,8 +329,10 @@ type indexOrderEnum string
 
 const (
 	undefinedIndexorder indexOrderEnum = emptyToken
-	indexIncr           indexOrderEnum = indexIncrToken
-	indexDecr           indexOrderEnum = indexDecrToken
+	//IndexIncr defines that the axis points are stored with increasing index in memory
+	IndexIncr indexOrderEnum = indexIncrToken
+	//IndexDecr defines that the axis points are stored with decreasing index in memory
+	IndexDecr indexOrderEnum = indexDecrToken
 )
 
 func parseIndexOrderEnum(tok *tokenGenerator) (indexOrderEnum, error) {
@@ -336,9 +340,9 @@ func parseIndexOrderEnum(tok *tokenGenerator) (indexOrderEnum, error) {
 	var err error
 	switch tok.current() {
 	case indexIncrToken:
-		i = indexIncr
+		i = IndexIncr
 	case indexDecrToken:
-		i = indexDecr
+		i = IndexDecr
 	default:
 		err = errors.New("incorrect value " + tok.current() + " for enum byteorder")
 	}
@@ -349,28 +353,29 @@ type attributeEnum string
 
 const (
 	undefinedAttribute attributeEnum = emptyToken
-	/*curveAxis type uses a separate CURVE CHARACTERISTIC to rescale the axis.
+	/*CurveAxis type uses a separate CURVE CHARACTERISTIC to rescale the axis.
 	The referenced CURVE is used to	lookup an axis index, and the index value is
 	used by the controller to determine the	operating point in the CURVE or MAP.*/
-	curveAxis attributeEnum = curveAxisToken
-	/*comAxis: Group axis points or description of the axis
+	CurveAxis attributeEnum = curveAxisToken
+	/*ComAxis: Group axis points or description of the axis
 	points for deposit. For this variant of the	axis points the axis point values are
 	separated from the table values of the curve or map in the emulation memory and
 	must be described by a special AXIS_PTS	data record.
 	The reference to this record occurs with the keyword 'AXIS_PTS_REF'.*/
-	comAxis attributeEnum = comAxisToken
-	/*fixAxis is a curve or a map with virtual axis
+	ComAxis attributeEnum = comAxisToken
+	/*FixAxis is a curve or a map with virtual axis
 	points that are not deposited at EPROM.
 	The axis points can be calculated from parameters defined with keywords:
 	FIX_AXIS_PAR, FIX_AXIS_PAR_DIST	and FIX_AXIS_PAR_LIST.
 	The axis points	cannot be modified.*/
-	fixAxis attributeEnum = fixAxisToken
+	FixAxis attributeEnum = fixAxisToken
 	/*Rescale axis. For this variant of the axis
 	points the axis point values are separated from the table values of the curve or map in
 	the emulation memory and must be described by a special AXIS_PTS data
 	record. The reference to this record occurs	with the keyword 'AXIS_PTS_REF'.*/
-	resAxis attributeEnum = resAxisToken
-	stdAxis attributeEnum = stdAxisToken
+	ResAxis attributeEnum = resAxisToken
+	//StdAxis is a standard axis whose axis points are stored within the deposit of the characteristic itself
+	StdAxis attributeEnum = stdAxisToken
 	INTERN  attributeEnum = internToken
 	EXTERN  attributeEnum = externToken
 )
@@ -380,15 +385,15 @@ func parseAttributeEnum(tok *tokenGenerator) (attributeEnum, error) {
 	var err error
 	switch tok.current() {
 	case curveAxisToken:
-		a = curveAxis
+		a = CurveAxis
 	case comAxisToken:
-		a = comAxis
+		a = ComAxis
 	case fixAxisToken:
-		a = fixAxis
+		a = FixAxis
 	case resAxisToken:
-		a = resAxis
+		a = ResAxis
 	case stdAxisToken:
-		a = stdAxis
+		a = StdAxis
 	case internToken:
 		a = INTERN
 	case externToken:
@@ -399,28 +404,29 @@ func parseAttributeEnum(tok *tokenGenerator) (attributeEnum, error) {
 	return a, err
 }
 
-type calibrationAccessEnum string
+// CalibrationAccessEnum defines whether a characteristic may be changed by the calibration system
+type CalibrationAccessEnum string
 
 const (
-	undefinedCalibrationAccess calibrationAccessEnum = emptyToken
-	calibration                calibrationAccessEnum = calibrationToken
-	noCalibration              calibrationAccessEnum = noCalibrationToken
-	notInMcdSystem             calibrationAccessEnum = notInMcdSystemToken
-	offlineCalibration         calibrationAccessEnum = offlineCalibrationToken
+	undefinedCalibrationAccess CalibrationAccessEnum = emptyToken
+	Calibration                CalibrationAccessEnum = calibrationToken
+	NoCalibration              CalibrationAccessEnum = noCalibrationToken
+	NotInMcdSystem             CalibrationAccessEnum = notInMcdSystemToken
+	OfflineCalibration         CalibrationAccessEnum = offlineCalibrationToken
 )
 
-func parseCalibrationAccessEnum(tok *tokenGenerator) (calibrationAccessEnum, error) {
+func parseCalibrationAccessEnum(tok *tokenGenerator) (CalibrationAccessEnum, error) {
 	ca := undefinedCalibrationAccess
 	var err error
 	switch tok.next() {
 	case calibrationToken:
-		ca = calibration
+		ca = Calibration
 	case noCalibrationToken:
-		ca = noCalibration
+		ca = NoCalibration
 	case notInMcdSystemToken:
-		ca = notInMcdSystem
+		ca = NotInMcdSystem
 	case offlineCalibrationToken:
-		ca = offlineCalibration
+		ca = OfflineCalibration
 	default:
 		err = errors.New("incorrect value " + tok.current() + " for enum calibrationAccess")
 	}
@@ -540,16 +546,17 @@ func parseConversionTypeEnum(tok *tokenGenerator) (conversionTypeEnum, error) {
 	return ct, err
 }
 
-type indexModeEnum string
+// IndexModeEnum describes how multi dimensional table values are mapped onto the one dimensional address space
+type IndexModeEnum string
 
 const (
-	undefinedIndexMode indexModeEnum = emptyToken
+	undefinedIndexMode IndexModeEnum = emptyToken
 	/* curves which share a common axis are deposited in columns;
 	each row of memory contains values for all the shared axis curves
 	at a given axis breakpoint.
 	Required in order to represent characteristics which correspond to
 	arrays of structures in ECU program code.*/
-	AlternateCurves indexModeEnum = alternateCurvesToken
+	AlternateCurves IndexModeEnum = alternateCurvesToken
 	/*AlternateWithX defines that values of a map are
 	stored in columns and the columns of table values alternate with the
 	respective X-coordinates. A map of format
@@ -562,7 +569,7 @@ const (
 	The order of axis points and table values can be defined differently
 	by the position statement in the FNC_VALUE
 	In case of a curve the values of x-Axis and values alternate.*/
-	AlternateWithX indexModeEnum = alternateWithXToken
+	AlternateWithX IndexModeEnum = alternateWithXToken
 	/*AlternateWithY defines that values of a map are
 	deposited in rows, the rows of table values alternate with the
 	respective Y-coordinates. A map of format
@@ -574,24 +581,24 @@ const (
 	The order of axis points and table values can be defined differently
 	by the position statement in the FNC_VALUE
 	Only applicable to maps*/
-	AlternateWithY indexModeEnum = alternateWithYToken
+	AlternateWithY IndexModeEnum = alternateWithYToken
 	/*Column Direction defines that values of a map
 	[0 1 2
 	 3 4 5
 	 6 7 8]
 	are stored within the hex file as an array of format
 	[0,3,6,1,4,7,2,5,8]	*/
-	ColumnDir indexModeEnum = columnDirToken
+	ColumnDir IndexModeEnum = columnDirToken
 	/*Row Direction defines that values of a map
 	[0 1 2
 	 3 4 5
 	 6 7 8]
 	are stored within the hex file as an array of format
 	[0,1,2,3,4,5,6,7,8]	*/
-	RowDir indexModeEnum = rowDirToken
+	RowDir IndexModeEnum = rowDirToken
 )
 
-func parseIndexModeEnum(tok *tokenGenerator) (indexModeEnum, error) {
+func parseIndexModeEnum(tok *tokenGenerator) (IndexModeEnum, error) {
 	im := undefinedIndexMode
 	var err error
 	switch tok.current() {
@@ -719,37 +726,38 @@ func parseMemoryTypeEnum(tok *tokenGenerator) (memoryTypeEnum, error) {
 	return mt, err
 }
 
-type monotonyTypeEnum string
+// MonotonyTypeEnum describes the monotony an axis has to follow
+type MonotonyTypeEnum string
 
 const (
-	undefinedMonotonyType monotonyTypeEnum = emptyToken
-	monDecrease           monotonyTypeEnum = monDecreaseToken
-	monIncrease           monotonyTypeEnum = monIncreaseToken
-	strictDecrease        monotonyTypeEnum = strictDecreaseToken
-	strictIncrease        monotonyTypeEnum = strictIncreaseToken
-	monotonous            monotonyTypeEnum = monotonousToken
-	strictMon             monotonyTypeEnum = strictMonToken
-	notMon                monotonyTypeEnum = notMonToken
+	undefinedMonotonyType MonotonyTypeEnum = emptyToken
+	MonDecrease           MonotonyTypeEnum = monDecreaseToken
+	MonIncrease           MonotonyTypeEnum = monIncreaseToken
+	StrictDecrease        MonotonyTypeEnum = strictDecreaseToken
+	StrictIncrease        MonotonyTypeEnum = strictIncreaseToken
+	Monotonous            MonotonyTypeEnum = monotonousToken
+	StrictMon             MonotonyTypeEnum = strictMonToken
+	NotMon                MonotonyTypeEnum = notMonToken
 )
 
-func parseMonotonyTypeEnum(tok *tokenGenerator) (monotonyTypeEnum, error) {
+func parseMonotonyTypeEnum(tok *tokenGenerator) (MonotonyTypeEnum, error) {
 	mt := undefinedMonotonyType
 	var err error
 	switch tok.current() {
 	case monDecreaseToken:
-		mt = monDecrease
+		mt = MonDecrease
 	case monIncreaseToken:
-		mt = monIncrease
+		mt = MonIncrease
 	case strictDecreaseToken:
-		mt = strictDecrease
+		mt = StrictDecrease
 	case strictIncreaseToken:
-		mt = strictIncrease
+		mt = StrictIncrease
 	case monotonousToken:
-		mt = monotonous
+		mt = Monotonous
 	case strictMonToken:
-		mt = strictMon
+		mt = StrictMon
 	case notMonToken:
-		mt = notMon
+		mt = NotMon
 	default:
 		err = errors.New("incorrect value " + tok.current() + " for enum monotonyType")
 	}
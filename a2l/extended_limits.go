@@ -8,10 +8,10 @@ import (
 )
 
 type extendedLimits struct {
-	lowerLimit    float64
-	lowerLimitSet bool
-	upperLimit    float64
-	upperLimitSet bool
+	LowerLimit    float64
+	LowerLimitSet bool
+	UpperLimit    float64
+	UpperLimitSet bool
 }
 
 func parseExtendedLimits(tok *tokenGenerator) (extendedLimits, error) {
@@ -28,25 +28,25 @@ forLoop:
 			err = errors.New("unexpected token " + tok.current())
 			log.Err(err).Msg("extendedLimits could not be parsed")
 			break forLoop
-		} else if !el.lowerLimitSet {
+		} else if !el.LowerLimitSet {
 			var buf float64
 			buf, err = strconv.ParseFloat(tok.current(), 64)
 			if err != nil {
 				log.Err(err).Msg("extendedLimits lowerLimit could not be parsed")
 				break forLoop
 			}
-			el.lowerLimit = buf
-			el.lowerLimitSet = true
+			el.LowerLimit = buf
+			el.LowerLimitSet = true
 			log.Info().Msg("extendedLimits lowerLimit successfully parsed")
-		} else if !el.upperLimitSet {
+		} else if !el.UpperLimitSet {
 			var buf float64
 			buf, err = strconv.ParseFloat(tok.current(), 64)
 			if err != nil {
 				log.Err(err).Msg("extendedLimits upperLimit could not be parsed")
 				break forLoop
 			}
-			el.upperLimit = buf
-			el.upperLimitSet = true
+			el.UpperLimit = buf
+			el.UpperLimitSet = true
 			log.Info().Msg("extendedLimits upperLimit successfully parsed")
 			break forLoop
 		}
@@ -8,12 +8,18 @@ import (
 )
 
 type fixAxisPar struct {
-	offset       int16
-	offsetSet    bool
-	shift        int16
-	shiftSet     bool
-	numberapo    uint16
-	numberapoSet bool
+	Offset    int16
+	OffsetSet bool
+	//OffsetRef is the name of the system constant that defines the offset instead of a number
+	OffsetRef string
+	Shift     int16
+	ShiftSet  bool
+	//ShiftRef is the name of the system constant that defines the shift instead of a number
+	ShiftRef     string
+	Numberapo    uint16
+	NumberapoSet bool
+	//NumberapoRef is the name of the system constant that defines the number of axis points instead of a number
+	NumberapoRef string
 }
 
 func parseFixAxisPar(tok *tokenGenerator) (fixAxisPar, error) {
@@ -30,35 +36,47 @@ forLoop:
 			err = errors.New("unexpected token " + tok.current())
 			log.Err(err).Msg("fixAxisPar could not be parsed")
 			break forLoop
-		} else if !fap.offsetSet {
-			var buf int64
-			buf, err = strconv.ParseInt(tok.current(), 10, 16)
-			if err != nil {
-				log.Err(err).Msg("fixAxisPar offset could not be parsed")
-				break forLoop
+		} else if !fap.OffsetSet {
+			if isSystemConstantRef(tok.current()) {
+				fap.OffsetRef = tok.current()
+			} else {
+				var buf int64
+				buf, err = strconv.ParseInt(tok.current(), 10, 16)
+				if err != nil {
+					log.Err(err).Msg("fixAxisPar offset could not be parsed")
+					break forLoop
+				}
+				fap.Offset = int16(buf)
 			}
-			fap.offset = int16(buf)
-			fap.offsetSet = true
+			fap.OffsetSet = true
 			log.Info().Msg("fixAxisPar offset successfully parsed")
-		} else if !fap.shiftSet {
-			var buf int64
-			buf, err = strconv.ParseInt(tok.current(), 10, 16)
-			if err != nil {
-				log.Err(err).Msg("fixAxisPar shift could not be parsed")
-				break forLoop
+		} else if !fap.ShiftSet {
+			if isSystemConstantRef(tok.current()) {
+				fap.ShiftRef = tok.current()
+			} else {
+				var buf int64
+				buf, err = strconv.ParseInt(tok.current(), 10, 16)
+				if err != nil {
+					log.Err(err).Msg("fixAxisPar shift could not be parsed")
+					break forLoop
+				}
+				fap.Shift = int16(buf)
 			}
-			fap.shift = int16(buf)
-			fap.shiftSet = true
+			fap.ShiftSet = true
 			log.Info().Msg("fixAxisPar shift successfully parsed")
-		} else if !fap.numberapoSet {
-			var buf uint64
-			buf, err = strconv.ParseUint(tok.current(), 10, 16)
-			if err != nil {
-				log.Err(err).Msg("fixAxisPar numberapo could not be parsed")
-				break forLoop
+		} else if !fap.NumberapoSet {
+			if isSystemConstantRef(tok.current()) {
+				fap.NumberapoRef = tok.current()
+			} else {
+				var buf uint64
+				buf, err = strconv.ParseUint(tok.current(), 10, 16)
+				if err != nil {
+					log.Err(err).Msg("fixAxisPar numberapo could not be parsed")
+					break forLoop
+				}
+				fap.Numberapo = uint16(buf)
 			}
-			fap.numberapo = uint16(buf)
-			fap.numberapoSet = true
+			fap.NumberapoSet = true
 			log.Info().Msg("fixAxisPar numberapo successfully parsed")
 			break forLoop
 		}
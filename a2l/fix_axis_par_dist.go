@@ -8,12 +8,18 @@ import (
 )
 
 type fixAxisParDist struct {
-	offset       int16
-	offsetSet    bool
-	distance     int16
-	distanceSet  bool
-	numberapo    uint16
-	numberapoSet bool
+	Offset    int16
+	OffsetSet bool
+	//OffsetRef is the name of the system constant that defines the offset instead of a number
+	OffsetRef   string
+	Distance    int16
+	DistanceSet bool
+	//DistanceRef is the name of the system constant that defines the distance instead of a number
+	DistanceRef  string
+	Numberapo    uint16
+	NumberapoSet bool
+	//NumberapoRef is the name of the system constant that defines the number of axis points instead of a number
+	NumberapoRef string
 }
 
 func parseFixAxisParDist(tok *tokenGenerator) (fixAxisParDist, error) {
@@ -30,35 +36,47 @@ forLoop:
 			err = errors.New("unexpected token " + tok.current())
 			log.Err(err).Msg("fixAxisParDist could not be parsed")
 			break forLoop
-		} else if !fapd.offsetSet {
-			var buf int64
-			buf, err = strconv.ParseInt(tok.current(), 10, 16)
-			if err != nil {
-				log.Err(err).Msg("fixAxisParDist offset could not be parsed")
-				break forLoop
+		} else if !fapd.OffsetSet {
+			if isSystemConstantRef(tok.current()) {
+				fapd.OffsetRef = tok.current()
+			} else {
+				var buf int64
+				buf, err = strconv.ParseInt(tok.current(), 10, 16)
+				if err != nil {
+					log.Err(err).Msg("fixAxisParDist offset could not be parsed")
+					break forLoop
+				}
+				fapd.Offset = int16(buf)
 			}
-			fapd.offset = int16(buf)
-			fapd.offsetSet = true
+			fapd.OffsetSet = true
 			log.Info().Msg("fixAxisParDist offset successfully parsed")
-		} else if !fapd.distanceSet {
-			var buf int64
-			buf, err = strconv.ParseInt(tok.current(), 10, 16)
-			if err != nil {
-				log.Err(err).Msg("fixAxisParDist distance could not be parsed")
-				break forLoop
+		} else if !fapd.DistanceSet {
+			if isSystemConstantRef(tok.current()) {
+				fapd.DistanceRef = tok.current()
+			} else {
+				var buf int64
+				buf, err = strconv.ParseInt(tok.current(), 10, 16)
+				if err != nil {
+					log.Err(err).Msg("fixAxisParDist distance could not be parsed")
+					break forLoop
+				}
+				fapd.Distance = int16(buf)
 			}
-			fapd.distance = int16(buf)
-			fapd.distanceSet = true
+			fapd.DistanceSet = true
 			log.Info().Msg("fixAxisParDist distance successfully parsed")
-		} else if !fapd.numberapoSet {
-			var buf uint64
-			buf, err = strconv.ParseUint(tok.current(), 10, 16)
-			if err != nil {
-				log.Err(err).Msg("fixAxisParDist numberapo could not be parsed")
-				break forLoop
+		} else if !fapd.NumberapoSet {
+			if isSystemConstantRef(tok.current()) {
+				fapd.NumberapoRef = tok.current()
+			} else {
+				var buf uint64
+				buf, err = strconv.ParseUint(tok.current(), 10, 16)
+				if err != nil {
+					log.Err(err).Msg("fixAxisParDist numberapo could not be parsed")
+					break forLoop
+				}
+				fapd.Numberapo = uint16(buf)
 			}
-			fapd.numberapo = uint16(buf)
-			fapd.numberapoSet = true
+			fapd.NumberapoSet = true
 			log.Info().Msg("fixAxisParDist numberapo successfully parsed")
 			break forLoop
 		}
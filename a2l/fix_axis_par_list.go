@@ -8,8 +8,11 @@ import (
 )
 
 type fixAxisParList struct {
-	axisPtsValue    []float64
-	axisPtsValueSet bool
+	AxisPtsValue    []float64
+	AxisPtsValueSet bool
+	//AxisPtsValueRef holds the name of the system constant for each axis point that is defined by a system constant
+	//and an empty string for all axis points that are defined by a number
+	AxisPtsValueRef []string
 }
 
 func parseFixAxisParList(tok *tokenGenerator) (fixAxisParList, error) {
@@ -23,21 +26,27 @@ forLoop:
 			log.Err(err).Msg("fixAxisParList could not be parsed")
 			break forLoop
 		} else if tok.current() == endFixAxisParListToken {
-			fapl.axisPtsValueSet = true
+			fapl.AxisPtsValueSet = true
 			log.Info().Msg("fixAxisParList axisPtsValue successfully parsed")
 			break forLoop
 		} else if isKeyword(tok.current()) {
 			err = errors.New("unexpected token " + tok.current())
 			log.Err(err).Msg("fixAxisParList could not be parsed")
 			break forLoop
-		} else if !fapl.axisPtsValueSet {
+		} else if !fapl.AxisPtsValueSet {
 			var buf float64
-			buf, err = strconv.ParseFloat(tok.current(), 64)
-			if err != nil {
-				log.Err(err).Msg("attribute axisPtsValue could not be parsed")
-				break forLoop
+			var ref string
+			if isSystemConstantRef(tok.current()) {
+				ref = tok.current()
+			} else {
+				buf, err = strconv.ParseFloat(tok.current(), 64)
+				if err != nil {
+					log.Err(err).Msg("attribute axisPtsValue could not be parsed")
+					break forLoop
+				}
 			}
-			fapl.axisPtsValue = append(fapl.axisPtsValue, buf)
+			fapl.AxisPtsValue = append(fapl.AxisPtsValue, buf)
+			fapl.AxisPtsValueRef = append(fapl.AxisPtsValueRef, ref)
 		}
 	}
 	return fapl, err
@@ -0,0 +1,82 @@
+package a2l
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+//create unit tests for the following functions: parseFixAxisPar, parseFixAxisParDist, parseFixAxisParList
+// - parameters defined by numbers
+// - parameters defined by system constants
+// - invalid parameters
+
+func TestParseFixAxisPar_Valid(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	tokenList = []string{emptyToken, "0", "2", "6"}
+	tok := newTokenGenerator()
+	fap, err := parseFixAxisPar(&tok)
+	if err != nil {
+		t.Fatalf("failed parsing with error: %s.", err)
+	}
+	if fap.Offset != 0 || fap.Shift != 2 || fap.Numberapo != 6 || fap.OffsetRef != "" {
+		t.Fatalf("fixAxisPar not parsed correctly: %+v", fap)
+	}
+}
+
+func TestParseFixAxisPar_SystemConstant(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	tokenList = []string{emptyToken, "\"AXIS_OFFSET\"", "1", "NO_POINTS"}
+	tok := newTokenGenerator()
+	fap, err := parseFixAxisPar(&tok)
+	if err != nil {
+		t.Fatalf("failed parsing with error: %s.", err)
+	}
+	if !fap.OffsetSet || fap.OffsetRef != "\"AXIS_OFFSET\"" || fap.Shift != 1 || !fap.NumberapoSet || fap.NumberapoRef != "NO_POINTS" {
+		t.Fatalf("fixAxisPar not parsed correctly: %+v", fap)
+	}
+}
+
+func TestParseFixAxisPar_Invalid(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	tokenList = []string{emptyToken, "0", "-x", "6"}
+	tok := newTokenGenerator()
+	_, err := parseFixAxisPar(&tok)
+	if err == nil {
+		t.Fatalf("failed test with undetected error: %s.", err)
+	}
+}
+
+func TestParseFixAxisParDist_SystemConstant(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	tokenList = []string{emptyToken, "-4", "AXIS_DISTANCE", "5"}
+	tok := newTokenGenerator()
+	fapd, err := parseFixAxisParDist(&tok)
+	if err != nil {
+		t.Fatalf("failed parsing with error: %s.", err)
+	}
+	if fapd.Offset != -4 || fapd.DistanceRef != "AXIS_DISTANCE" || !fapd.DistanceSet || fapd.Numberapo != 5 {
+		t.Fatalf("fixAxisParDist not parsed correctly: %+v", fapd)
+	}
+}
+
+func TestParseFixAxisParList_SystemConstant(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	tokenList = []string{emptyToken, "-1", "\"AXIS_POINT\"", "6.5", endFixAxisParListToken}
+	tok := newTokenGenerator()
+	fapl, err := parseFixAxisParList(&tok)
+	if err != nil {
+		t.Fatalf("failed parsing with error: %s.", err)
+	}
+	if len(fapl.AxisPtsValue) != 3 || fapl.AxisPtsValue[0] != -1 || fapl.AxisPtsValue[2] != 6.5 {
+		t.Fatalf("axis points not parsed correctly: %v", fapl.AxisPtsValue)
+	}
+	if len(fapl.AxisPtsValueRef) != 3 || fapl.AxisPtsValueRef[0] != "" || fapl.AxisPtsValueRef[1] != "\"AXIS_POINT\"" {
+		t.Fatalf("system constant references not parsed correctly: %q", fapl.AxisPtsValueRef)
+	}
+}
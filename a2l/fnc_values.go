@@ -18,7 +18,7 @@ type FncValues struct {
 	/*IndexMode for characteristic maps, curves and value blocks,
 	this field is used to describe how the 2-dimensional table values
 	are mapped onto the 1-dimensional address space*/
-	IndexMode    indexModeEnum
+	IndexMode    IndexModeEnum
 	IndexModeSet bool
 	/*Addresstype defines the addressing of the table values:
 	Enumeration for description of the addressing of table
@@ -6,10 +6,16 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+/*
+FORMULA specifies the conversion of the COMPU_METHOD conversion type FORM.
+fx is the formula f(X1,...,Xn) that converts the ecu internal value into the physical value
+written in the ASAP2 formula syntax, e.g. "X1*2+sysc(offset)".
+FORMULA_INV specifies the inverse formula g(X1) that is needed to convert physical values back into ecu internal values.
+*/
 type Formula struct {
-	fx         []string
-	fxSet      bool
-	formulaInv formulaInv
+	Fx         []string
+	FxSet      bool
+	FormulaInv formulaInv
 }
 
 func parseFormula(tok *tokenGenerator) (Formula, error) {
@@ -19,7 +25,7 @@ forLoop:
 	for {
 		switch tok.next() {
 		case formulaInvToken:
-			f.formulaInv, err = parseFormulaInv(tok)
+			f.FormulaInv, err = parseFormulaInv(tok)
 			if err != nil {
 				log.Err(err).Msg("formula formulaInv could not be parsed")
 				break forLoop
@@ -31,15 +37,15 @@ forLoop:
 				log.Err(err).Msg("formula could not be parsed")
 				break forLoop
 			} else if tok.current() == endFormulaToken {
-				f.fxSet = true
+				f.FxSet = true
 				log.Info().Msg("formula fx successfully parsed")
 				break forLoop
 			} else if isKeyword(tok.current()) {
 				err = errors.New("unexpected token " + tok.current())
 				log.Err(err).Msg("formula could not be parsed")
 				break forLoop
-			} else if !f.fxSet {
-				f.fx = append(f.fx, tok.current())
+			} else if !f.FxSet {
+				f.Fx = append(f.Fx, tok.current())
 			}
 		}
 	}
@@ -7,8 +7,8 @@ import (
 )
 
 type formulaInv struct {
-	gx    string
-	gxSet bool
+	Gx    string
+	GxSet bool
 }
 
 func parseFormulaInv(tok *tokenGenerator) (formulaInv, error) {
@@ -21,9 +21,9 @@ func parseFormulaInv(tok *tokenGenerator) (formulaInv, error) {
 	} else if isKeyword(tok.current()) {
 		err = errors.New("unexpected token " + tok.current())
 		log.Err(err).Msg("formulaInv could not be parsed")
-	} else if !fi.gxSet {
-		fi.gx = tok.current()
-		fi.gxSet = true
+	} else if !fi.GxSet {
+		fi.Gx = tok.current()
+		fi.GxSet = true
 		log.Info().Msg("formulaInv gx successfully parsed")
 	}
 	return fi, err
@@ -7,8 +7,8 @@ import (
 )
 
 type guardRailsKeyword struct {
-	value    bool
-	valueSet bool
+	Value    bool
+	ValueSet bool
 }
 
 func parseGuardRails(tok *tokenGenerator) (guardRailsKeyword, error) {
@@ -17,9 +17,9 @@ func parseGuardRails(tok *tokenGenerator) (guardRailsKeyword, error) {
 	if tok.current() == emptyToken {
 		err = errors.New("unexpected end of file")
 		log.Err(err).Msg("guardRails could not be parsed: unexpected end of file")
-	} else if !gr.valueSet {
-		gr.value = true
-		gr.valueSet = true
+	} else if !gr.ValueSet {
+		gr.Value = true
+		gr.ValueSet = true
 		log.Info().Msg("guardRails value successfully parsed")
 	}
 	return gr, err
@@ -17,7 +17,7 @@ type instance struct {
 	addressSet          bool
 	addressType         AddrTypeEnum
 	annotation          []annotation
-	calibrationAccess   calibrationAccessEnum
+	calibrationAccess   CalibrationAccessEnum
 	displayIdentifier   DisplayIdentifier
 	ecuAddressExtension ecuAddressExtension
 	ifData              []IfData
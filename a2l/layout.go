@@ -7,7 +7,7 @@ import (
 )
 
 type layout struct {
-	indexMode    indexModeEnum
+	indexMode    IndexModeEnum
 	indexModeSet bool
 }
 
@@ -7,9 +7,10 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// MaxGrad limits the absolute gradient of the function values along an axis
 type MaxGrad struct {
-	maxGradient    float64
-	maxGradientSet bool
+	MaxGradient    float64
+	MaxGradientSet bool
 }
 
 func parseMaxGrad(tok *tokenGenerator) (MaxGrad, error) {
@@ -22,14 +23,14 @@ func parseMaxGrad(tok *tokenGenerator) (MaxGrad, error) {
 	} else if isKeyword(tok.current()) {
 		err = errors.New("unexpected token " + tok.current())
 		log.Err(err).Msg("maxGrad could not be parsed")
-	} else if !mg.maxGradientSet {
+	} else if !mg.MaxGradientSet {
 		var buf float64
 		buf, err = strconv.ParseFloat(tok.current(), 64)
 		if err != nil {
 			log.Err(err).Msg("maxGrad maxGradient could not be parsed")
 		}
-		mg.maxGradient = buf
-		mg.maxGradientSet = true
+		mg.MaxGradient = buf
+		mg.MaxGradientSet = true
 		log.Info().Msg("maxGrad maxGradient successfully parsed")
 	}
 	return mg, err
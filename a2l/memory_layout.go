@@ -10,12 +10,12 @@ import (
 type memoryLayout struct {
 	prgType    prgTypeEnum
 	prgTypeSet bool
-	address    string
-	addressSet bool
-	size       string
-	sizeSet    bool
-	offset     []int32
-	offsetSet  bool
+	Address    string
+	AddressSet bool
+	Size       string
+	SizeSet    bool
+	Offset     []int32
+	OffsetSet  bool
 	ifData     []IfData
 }
 
@@ -53,24 +53,24 @@ forLoop:
 				}
 				ml.prgTypeSet = true
 				log.Info().Msg("memoryLayout prgType successfully parsed")
-			} else if !ml.addressSet {
-				ml.address = tok.current()
-				ml.addressSet = true
-			} else if !ml.sizeSet {
-				ml.size = tok.current()
-				ml.sizeSet = true
-			} else if !ml.offsetSet {
+			} else if !ml.AddressSet {
+				ml.Address = tok.current()
+				ml.AddressSet = true
+			} else if !ml.SizeSet {
+				ml.Size = tok.current()
+				ml.SizeSet = true
+			} else if !ml.OffsetSet {
 				var buf int64
-				buf, err = strconv.ParseInt(tok.current(), 10, 32)
+				buf, err = strconv.ParseInt(tok.current(), 0, 32)
 				if err != nil {
-					log.Err(err).Msg("shiftOp4 position could not be parsed")
+					log.Err(err).Msg("memoryLayout offset could not be parsed")
 					break forLoop
 				}
-				ml.offset = append(ml.offset, int32(buf))
-				if len(ml.offset) == 5 {
-					ml.offsetSet = true
+				ml.Offset = append(ml.Offset, int32(buf))
+				if len(ml.Offset) == 5 {
+					ml.OffsetSet = true
 				}
-				log.Info().Msg("shiftOp4 position successfully parsed")
+				log.Info().Msg("memoryLayout offset successfully parsed")
 			}
 		}
 	}
@@ -7,8 +7,8 @@ import (
 )
 
 type memorySegment struct {
-	name              string
-	nameSet           bool
+	Name              string
+	NameSet           bool
 	longIdentifier    string
 	longIdentifierSet bool
 	prgType           prgTypeEnum
@@ -17,12 +17,12 @@ type memorySegment struct {
 	memoryTypeSet     bool
 	attribute         attributeEnum
 	attributeSet      bool
-	address           string
-	addressSet        bool
-	size              string
-	sizeSet           bool
-	offset            [5]string
-	offsetSet         bool
+	Address           string
+	AddressSet        bool
+	Size              string
+	SizeSet           bool
+	Offset            [5]string
+	OffsetSet         bool
 	ifData            []IfData
 }
 
@@ -52,9 +52,9 @@ forLoop:
 				err = errors.New("unexpected token " + tok.current())
 				log.Err(err).Msg("memorySegment could not be parsed")
 				break forLoop
-			} else if !ms.nameSet {
-				ms.name = tok.current()
-				ms.nameSet = true
+			} else if !ms.NameSet {
+				ms.Name = tok.current()
+				ms.NameSet = true
 				log.Info().Msg("memorySegment name successfully parsed")
 			} else if !ms.longIdentifierSet {
 				ms.longIdentifier = tok.current()
@@ -84,17 +84,25 @@ forLoop:
 				}
 				ms.attributeSet = true
 				log.Info().Msg("memorySegment address successfully parsed")
-			} else if !ms.addressSet {
-				ms.address = tok.current()
-				ms.addressSet = true
-			} else if !ms.sizeSet {
-				ms.size = tok.current()
-				ms.sizeSet = true
-			} else if !ms.offsetSet {
-				for i := 0; i < 5; i++ {
-					ms.offset[i] = tok.current()
+			} else if !ms.AddressSet {
+				ms.Address = tok.current()
+				ms.AddressSet = true
+			} else if !ms.SizeSet {
+				ms.Size = tok.current()
+				ms.SizeSet = true
+			} else if !ms.OffsetSet {
+				ms.Offset[0] = tok.current()
+				for i := 1; i < 5; i++ {
+					tok.next()
+					if tok.current() == emptyToken || isKeyword(tok.current()) {
+						err = errors.New("unexpected token " + tok.current())
+						log.Err(err).Msg("memorySegment offset could not be parsed")
+						break forLoop
+					}
+					ms.Offset[i] = tok.current()
 				}
-				ms.offsetSet = true
+				ms.OffsetSet = true
+				log.Info().Msg("memorySegment offset successfully parsed")
 			}
 		}
 	}
@@ -0,0 +1,54 @@
+package a2l
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+//create unit tests for the following functions: parseMemorySegment
+// - valid memorySegment including its mirror offsets
+// - empty memorySegment
+// - incomplete offsets
+
+func TestParseMemorySegment_Valid(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	// valid memorySegment
+	tokenList = []string{emptyToken, "Data", "\"calibration data\"", "DATA", "FLASH", "INTERN", "0x800000", "0x1000", "0x10000", "-1", "-1", "-1", "-1", endMemorySegmentToken}
+	tok := newTokenGenerator()
+	ms, err := parseMemorySegment(&tok)
+	if err != nil {
+		t.Fatalf("failed parsing with error: %s.", err)
+	}
+	if ms.Name != "Data" || ms.Address != "0x800000" || ms.Size != "0x1000" {
+		t.Fatalf("memorySegment not parsed correctly: %s %s %s", ms.Name, ms.Address, ms.Size)
+	}
+	if !ms.OffsetSet || ms.Offset != [5]string{"0x10000", "-1", "-1", "-1", "-1"} {
+		t.Fatalf("offsets not parsed correctly: %v", ms.Offset)
+	}
+}
+
+func TestParseMemorySegment_Empty(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	// empty memorySegment
+	tokenList = []string{emptyToken, emptyToken}
+	tok := newTokenGenerator()
+	_, err := parseMemorySegment(&tok)
+	if err == nil {
+		t.Fatalf("failed test with undetected error: %s.", err)
+	}
+}
+
+func TestParseMemorySegment_IncompleteOffsets(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	// only two of five offsets
+	tokenList = []string{emptyToken, "Data", "\"calibration data\"", "DATA", "FLASH", "INTERN", "0x800000", "0x1000", "0x10000", "-1", endMemorySegmentToken}
+	tok := newTokenGenerator()
+	_, err := parseMemorySegment(&tok)
+	if err == nil {
+		t.Fatalf("failed test with undetected error: %s.", err)
+	}
+}
@@ -15,10 +15,10 @@ type modPar struct {
 	customer             customer
 	customerNo           customerNo
 	ecu                  ecu
-	ecuCalibrationOffset ecuCalibrationOffset
+	EcuCalibrationOffset ecuCalibrationOffset
 	epk                  epk
-	memoryLayout         []memoryLayout
-	memorySegment        []memorySegment
+	MemoryLayouts        []memoryLayout
+	MemorySegments       []memorySegment
 	noOfInterfaces       noOfInterfaces
 	phoneNo              phoneNo
 	supplier             supplier
@@ -81,7 +81,7 @@ forLoop:
 			}
 			log.Info().Msg("modPar ecu successfully parsed")
 		case ecuCalibrationOffsetToken:
-			mp.ecuCalibrationOffset, err = parseEcuCalibrationOffset(tok)
+			mp.EcuCalibrationOffset, err = parseEcuCalibrationOffset(tok)
 			if err != nil {
 				log.Err(err).Msg("modPar ecuCalibrationOffset could not be parsed")
 				break forLoop
@@ -101,7 +101,7 @@ forLoop:
 				log.Err(err).Msg("modPar memoryLayout could not be parsed")
 				break forLoop
 			}
-			mp.memoryLayout = append(mp.memoryLayout, buf)
+			mp.MemoryLayouts = append(mp.MemoryLayouts, buf)
 			log.Info().Msg("modPar memoryLayout successfully parsed")
 		case beginMemorySegmentToken:
 			var buf memorySegment
@@ -110,7 +110,7 @@ forLoop:
 				log.Err(err).Msg("modPar memorySegment could not be parsed")
 				break forLoop
 			}
-			mp.memorySegment = append(mp.memorySegment, buf)
+			mp.MemorySegments = append(mp.MemorySegments, buf)
 			log.Info().Msg("modPar memorySegment successfully parsed")
 		case noOfInterfacesToken:
 			mp.noOfInterfaces, err = parseNoOfInterfaces(tok)
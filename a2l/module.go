@@ -15,7 +15,7 @@ type Module struct {
 	longIdentifierSet      bool
 	a2ml                   a2ml
 	errors                 []error
-	AxisPts                map[string]axisPts
+	AxisPts                map[string]AxisPts
 	blobs                  map[string]blob
 	Characteristics        map[string]Characteristic
 	CompuMethods           map[string]CompuMethod
@@ -37,7 +37,7 @@ type Module struct {
 	typeDefCharacteristics map[string]typeDefCharacteristic
 	typeDefMeasurements    map[string]typeDefMeasurement
 	typeDefStructures      map[string]typeDefStructure
-	Units                  map[string]unit
+	Units                  map[string]Unit
 	userRights             map[string]userRights
 	variantCoding          variantCoding
 }
@@ -45,7 +45,7 @@ type Module struct {
 func parseModule(tok *tokenGenerator) (Module, error) {
 	//Bulk init of an average number of objects contained in a modern a2l-file.
 	myModule := Module{}
-	myModule.AxisPts = make(map[string]axisPts, 1000)
+	myModule.AxisPts = make(map[string]AxisPts, 1000)
 	myModule.blobs = make(map[string]blob, 5)
 	myModule.Characteristics = make(map[string]Characteristic, 10000)
 	myModule.CompuMethods = make(map[string]CompuMethod, 1000)
@@ -64,10 +64,10 @@ func parseModule(tok *tokenGenerator) (Module, error) {
 	myModule.typeDefCharacteristics = make(map[string]typeDefCharacteristic, 10)
 	myModule.typeDefMeasurements = make(map[string]typeDefMeasurement, 10)
 	myModule.typeDefStructures = make(map[string]typeDefStructure, 10)
-	myModule.Units = make(map[string]unit, 1000)
+	myModule.Units = make(map[string]Unit, 1000)
 	myModule.userRights = make(map[string]userRights, 1000)
 	var err error
-	var bufAxisPts axisPts
+	var bufAxisPts AxisPts
 	var bufBlob blob
 	var bufCharacteristic Characteristic
 	var bufCompuMethod CompuMethod
@@ -86,7 +86,7 @@ func parseModule(tok *tokenGenerator) (Module, error) {
 	var bufTypeDefCharacteristic typeDefCharacteristic
 	var bufTypeDefMeasurement typeDefMeasurement
 	var bufTypeDefStructure typeDefStructure
-	var bufUnit unit
+	var bufUnit Unit
 	var bufUserRights userRights
 
 forLoop:
@@ -105,7 +105,7 @@ forLoop:
 				log.Err(err).Msg("module axisPts could not be parsed")
 				break forLoop
 			}
-			myModule.AxisPts[bufAxisPts.name] = bufAxisPts
+			myModule.AxisPts[bufAxisPts.Name] = bufAxisPts
 			log.Info().Msg("module axisPts successfully parsed")
 		case beginBlobToken:
 			bufBlob, err = parseBlob(tok)
@@ -278,7 +278,7 @@ forLoop:
 				log.Err(err).Msg("module unit could not be parsed")
 				break forLoop
 			}
-			myModule.Units[bufUnit.name] = bufUnit
+			myModule.Units[bufUnit.Name] = bufUnit
 			log.Info().Msg("module unit successfully parsed")
 		case beginUserRightsToken:
 			bufUserRights, err = parseUserRights(tok)
@@ -328,7 +328,7 @@ func parseModuleMultithreaded(tok *tokenGenerator) (Module, error) {
 	//Bulk init of an average number of objects contained in a modern a2l-file.
 	log.Info().Msg("creating maps for module subtypes")
 	myModule := Module{}
-	myModule.AxisPts = make(map[string]axisPts, 1000)
+	myModule.AxisPts = make(map[string]AxisPts, 1000)
 	myModule.blobs = make(map[string]blob, 5)
 	myModule.Characteristics = make(map[string]Characteristic, 10000)
 	myModule.CompuMethods = make(map[string]CompuMethod, 1000)
@@ -347,7 +347,7 @@ func parseModuleMultithreaded(tok *tokenGenerator) (Module, error) {
 	myModule.typeDefCharacteristics = make(map[string]typeDefCharacteristic, 10)
 	myModule.typeDefMeasurements = make(map[string]typeDefMeasurement, 10)
 	myModule.typeDefStructures = make(map[string]typeDefStructure, 10)
-	myModule.Units = make(map[string]unit, 1000)
+	myModule.Units = make(map[string]Unit, 1000)
 	myModule.userRights = make(map[string]userRights, 1000)
 	var err error
 
@@ -372,7 +372,7 @@ forLoop:
 	log.Info().Msg("creating channels")
 	cError := make(chan error, numProc)
 	cA2ml := make(chan a2ml, 1)
-	cAxisPts := make(chan axisPts, 100)
+	cAxisPts := make(chan AxisPts, 100)
 	cBlob := make(chan blob, 5)
 	cCharacteristic := make(chan Characteristic, 1000)
 	cCompuMethod := make(chan CompuMethod, 100)
@@ -394,7 +394,7 @@ forLoop:
 	cTypeDefCharacteristic := make(chan typeDefCharacteristic, 10)
 	cTypeDefMeasurement := make(chan typeDefMeasurement, 10)
 	cTypeDefStructure := make(chan typeDefStructure, 10)
-	cUnit := make(chan unit, 100)
+	cUnit := make(chan Unit, 100)
 	cUserRights := make(chan userRights, 10)
 	cVariantCoding := make(chan variantCoding, 1)
 
@@ -452,7 +452,7 @@ forLoop:
 // collectChannelsMultithreaded uses anonymous function to collect the data sent by the goroutines running the moduleMainLoop.
 // usually the Select Collector is to be prefered as it is mostly faster and always easier on memory
 // as the additional goroutines spun up in collectChannelsMultithreaded seem to block the GC a lot
-func collectChannelsMultithreaded(myModule *Module, cA2ml chan a2ml, cAxisPts chan axisPts, cBlob chan blob, cCharacteristic chan Characteristic,
+func collectChannelsMultithreaded(myModule *Module, cA2ml chan a2ml, cAxisPts chan AxisPts, cBlob chan blob, cCharacteristic chan Characteristic,
 	cCompuMethod chan CompuMethod, cCompuTab chan CompuTab, cCompuVtab chan CompuVTab,
 	cCompuVtabRange chan CompuVTabRange, cFrame chan frame, cFunction chan function,
 	cGroup chan group, cIfData chan IfData, cMeasurement chan Measurement,
@@ -460,7 +460,7 @@ func collectChannelsMultithreaded(myModule *Module, cA2ml chan a2ml, cAxisPts ch
 	cInstance chan instance, cTransformer chan transformer, cTypeDefAxis chan typeDefAxis,
 	cTypeDefBlob chan typeDefBlob, cTypeDefCharacteristic chan typeDefCharacteristic,
 	cTypeDefMeasurement chan typeDefMeasurement, cTypeDefStructure chan typeDefStructure,
-	cUnit chan unit, cUserRights chan userRights, cVariantCoding chan variantCoding, cError chan error) {
+	cUnit chan Unit, cUserRights chan userRights, cVariantCoding chan variantCoding, cError chan error) {
 
 	log.Info().Msg("spinning up collector routines")
 	wgCollectors := new(sync.WaitGroup)
@@ -482,7 +482,7 @@ func collectChannelsMultithreaded(myModule *Module, cA2ml chan a2ml, cAxisPts ch
 	go func(wg *sync.WaitGroup) {
 		defer wg.Done()
 		for elem := range cAxisPts {
-			myModule.AxisPts[elem.name] = elem
+			myModule.AxisPts[elem.Name] = elem
 		}
 		log.Info().Msg("collected axisPts")
 	}(wgCollectors)
@@ -636,7 +636,7 @@ func collectChannelsMultithreaded(myModule *Module, cA2ml chan a2ml, cAxisPts ch
 	go func(wg *sync.WaitGroup) {
 		defer wg.Done()
 		for elem := range cUnit {
-			myModule.Units[elem.name] = elem
+			myModule.Units[elem.Name] = elem
 		}
 		log.Info().Msg("collected units")
 	}(wgCollectors)
@@ -663,7 +663,7 @@ func collectChannelsMultithreaded(myModule *Module, cA2ml chan a2ml, cAxisPts ch
 // and wgParser.Wait() is over.
 // channels have to be closed in order for the collector to recognize when it is done
 // because no more data can be sent and all channels are empty
-func closeChannelsAfterParsing(wg *sync.WaitGroup, cA2ml chan a2ml, cAxisPts chan axisPts, cBlob chan blob, cCharacteristic chan Characteristic,
+func closeChannelsAfterParsing(wg *sync.WaitGroup, cA2ml chan a2ml, cAxisPts chan AxisPts, cBlob chan blob, cCharacteristic chan Characteristic,
 	cCompuMethod chan CompuMethod, cCompuTab chan CompuTab, cCompuVtab chan CompuVTab,
 	cCompuVtabRange chan CompuVTabRange, cFrame chan frame, cFunction chan function,
 	cGroup chan group, cIfData chan IfData, cMeasurement chan Measurement,
@@ -671,7 +671,7 @@ func closeChannelsAfterParsing(wg *sync.WaitGroup, cA2ml chan a2ml, cAxisPts cha
 	cInstance chan instance, cTransformer chan transformer, cTypeDefAxis chan typeDefAxis,
 	cTypeDefBlob chan typeDefBlob, cTypeDefCharacteristic chan typeDefCharacteristic,
 	cTypeDefMeasurement chan typeDefMeasurement, cTypeDefStructure chan typeDefStructure,
-	cUnit chan unit, cUserRights chan userRights, cVariantCoding chan variantCoding, cError chan error) {
+	cUnit chan Unit, cUserRights chan userRights, cVariantCoding chan variantCoding, cError chan error) {
 	log.Info().Msg("waiting for the parsers to finish")
 	wg.Wait()
 	close(cError)
@@ -706,7 +706,7 @@ func closeChannelsAfterParsing(wg *sync.WaitGroup, cA2ml chan a2ml, cAxisPts cha
 
 // parseModuleMainLoop is used by the parseModuleMultithreaded function to run the module parser in individual goroutines
 func parseModuleMainLoop(wg *sync.WaitGroup, minIndex int, maxIndex int,
-	cA2ml chan a2ml, cAxisPts chan axisPts, cBlob chan blob, cCharacteristic chan Characteristic,
+	cA2ml chan a2ml, cAxisPts chan AxisPts, cBlob chan blob, cCharacteristic chan Characteristic,
 	cCompuMethod chan CompuMethod, cCompuTab chan CompuTab, cCompuVtab chan CompuVTab,
 	cCompuVtabRange chan CompuVTabRange, cFrame chan frame, cFunction chan function,
 	cGroup chan group, cIfData chan IfData, cMeasurement chan Measurement,
@@ -714,14 +714,14 @@ func parseModuleMainLoop(wg *sync.WaitGroup, minIndex int, maxIndex int,
 	cInstance chan instance, cTransformer chan transformer, cTypeDefAxis chan typeDefAxis,
 	cTypeDefBlob chan typeDefBlob, cTypeDefCharacteristic chan typeDefCharacteristic,
 	cTypeDefMeasurement chan typeDefMeasurement, cTypeDefStructure chan typeDefStructure,
-	cUnit chan unit, cUserRights chan userRights, cVariantCoding chan variantCoding, cError chan error) {
+	cUnit chan Unit, cUserRights chan userRights, cVariantCoding chan variantCoding, cError chan error) {
 
 	defer wg.Done()
 
 	tg := tokenGenerator{}
 	tg.index = minIndex
 	var err error
-	var bufAxisPts axisPts
+	var bufAxisPts AxisPts
 	var bufBlob blob
 	var bufCharacteristic Characteristic
 	var bufCompuMethod CompuMethod
@@ -740,7 +740,7 @@ func parseModuleMainLoop(wg *sync.WaitGroup, minIndex int, maxIndex int,
 	var bufTypeDefCharacteristic typeDefCharacteristic
 	var bufTypeDefMeasurement typeDefMeasurement
 	var bufTypeDefStructure typeDefStructure
-	var bufUnit unit
+	var bufUnit Unit
 	var bufUserRights userRights
 
 forLoop:
@@ -6,9 +6,10 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// Monotony defines the monotony of the axis points of an axis
 type Monotony struct {
-	monotony    monotonyTypeEnum
-	monotonySet bool
+	Monotony    MonotonyTypeEnum
+	MonotonySet bool
 }
 
 func parseMonotony(tok *tokenGenerator) (Monotony, error) {
@@ -21,12 +22,12 @@ func parseMonotony(tok *tokenGenerator) (Monotony, error) {
 	} else if isKeyword(tok.current()) {
 		err = errors.New("unexpected token " + tok.current())
 		log.Err(err).Msg("monotony could not be parsed")
-	} else if !m.monotonySet {
-		m.monotony, err = parseMonotonyTypeEnum(tok)
+	} else if !m.MonotonySet {
+		m.Monotony, err = parseMonotonyTypeEnum(tok)
 		if err != nil {
 			log.Err(err).Msg("monotony monotony could not be parsed")
 		}
-		m.monotonySet = true
+		m.MonotonySet = true
 		log.Info().Msg("monotony monotony successfully parsed")
 	}
 	return m, err
@@ -0,0 +1,54 @@
+package a2l
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+//create unit tests for the following functions: parseNoRescaleX
+// - valid noRescaleX including its datatype
+// - empty noRescaleX
+// - invalid position
+
+func TestParseNoRescaleX_Valid(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	// valid noRescaleX
+	tokenList = []string{emptyToken, "1", "UBYTE", endRecordLayoutToken}
+	tok := newTokenGenerator()
+	nrx, err := parseNoRescaleX(&tok)
+	if err != nil {
+		t.Fatalf("failed parsing with error: %s.", err)
+	}
+	if !nrx.PositionSet || nrx.Position != 1 {
+		t.Fatalf("position not parsed correctly: %d", nrx.Position)
+	}
+	if !nrx.DatatypeSet || nrx.Datatype != UBYTE {
+		t.Fatalf("datatype not parsed correctly: %s", nrx.Datatype)
+	}
+}
+
+func TestParseNoRescaleX_Empty(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	// empty noRescaleX
+	tokenList = []string{emptyToken, emptyToken}
+	tok := newTokenGenerator()
+	_, err := parseNoRescaleX(&tok)
+	if err == nil {
+		t.Fatalf("failed test with undetected error: %s.", err)
+	}
+}
+
+func TestParseNoRescaleX_Invalid(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	// invalid position
+	tokenList = []string{emptyToken, "1.5", "UBYTE"}
+	tok := newTokenGenerator()
+	_, err := parseNoRescaleX(&tok)
+	if err == nil {
+		t.Fatalf("failed test with undetected error: %s.", err)
+	}
+}
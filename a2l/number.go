@@ -8,8 +8,8 @@ import (
 )
 
 type Number struct {
-	number    uint16
-	numberSet bool
+	Number    uint16
+	NumberSet bool
 }
 
 func parseNumber(tok *tokenGenerator) (Number, error) {
@@ -22,14 +22,14 @@ func parseNumber(tok *tokenGenerator) (Number, error) {
 	} else if isKeyword(tok.current()) {
 		err = errors.New("unexpected token " + tok.current())
 		log.Err(err).Msg("number could not be parsed")
-	} else if !n.numberSet {
+	} else if !n.NumberSet {
 		var buf uint64
 		buf, err = strconv.ParseUint(tok.current(), 10, 16)
 		if err != nil {
 			log.Err(err).Msg("number number could not be parsed")
 		}
-		n.number = uint16(buf)
-		n.numberSet = true
+		n.Number = uint16(buf)
+		n.NumberSet = true
 		log.Info().Msg("number number successfully parsed")
 	}
 	return n, err
@@ -3,16 +3,16 @@ package a2l
 import "github.com/rs/zerolog/log"
 
 type readOnlyKeyword struct {
-	value    bool
-	valueSet bool
+	Value    bool
+	ValueSet bool
 }
 
 func parseReadOnly(tok *tokenGenerator) (readOnlyKeyword, error) {
 	ro := readOnlyKeyword{}
 	var err error
-	if !ro.valueSet {
-		ro.value = true
-		ro.valueSet = true
+	if !ro.ValueSet {
+		ro.Value = true
+		ro.ValueSet = true
 		log.Info().Msg("readOnly value successfully parsed")
 	}
 	return ro, err
@@ -7,8 +7,8 @@ import (
 )
 
 type refMemorySegment struct {
-	name    string
-	nameSet bool
+	Name    string
+	NameSet bool
 }
 
 func parseRefMemorySegment(tok *tokenGenerator) (refMemorySegment, error) {
@@ -21,9 +21,9 @@ func parseRefMemorySegment(tok *tokenGenerator) (refMemorySegment, error) {
 	} else if isKeyword(tok.current()) {
 		err = errors.New("unexpected token " + tok.current())
 		log.Err(err).Msg("refMemorySegment could not be parsed")
-	} else if !rms.nameSet {
-		rms.name = tok.current()
-		rms.nameSet = true
+	} else if !rms.NameSet {
+		rms.Name = tok.current()
+		rms.NameSet = true
 		log.Info().Msg("refMemorySegment name successfully parsed")
 	}
 	return rms, err
@@ -6,13 +6,13 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-type refUnit struct {
-	unit    string
-	unitSet bool
+type RefUnit struct {
+	Unit    string
+	UnitSet bool
 }
 
-func parseRefUnit(tok *tokenGenerator) (refUnit, error) {
-	ru := refUnit{}
+func parseRefUnit(tok *tokenGenerator) (RefUnit, error) {
+	ru := RefUnit{}
 	var err error
 	tok.next()
 	if tok.current() == emptyToken {
@@ -21,9 +21,9 @@ func parseRefUnit(tok *tokenGenerator) (refUnit, error) {
 	} else if isKeyword(tok.current()) {
 		err = errors.New("unexpected token " + tok.current())
 		log.Err(err).Msg("refUnit could not be parsed")
-	} else if !ru.unitSet {
-		ru.unit = tok.current()
-		ru.unitSet = true
+	} else if !ru.UnitSet {
+		ru.Unit = tok.current()
+		ru.UnitSet = true
 		log.Info().Msg("refUnit unit successfully parsed")
 	}
 	return ru, err
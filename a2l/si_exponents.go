@@ -7,25 +7,25 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-type siExponents struct {
-	length               int16
-	lengthSet            bool
-	mass                 int16
-	massSet              bool
-	time                 int16
-	timeSet              bool
-	electricCurrent      int16
-	electricCurrentSet   bool
-	temperature          int16
-	temperatureSet       bool
-	amountOfSubstance    int16
-	amountOfSubstanceSet bool
-	luminousIntensity    int16
-	luminousIntensitySet bool
+type SiExponents struct {
+	Length               int16
+	LengthSet            bool
+	Mass                 int16
+	MassSet              bool
+	Time                 int16
+	TimeSet              bool
+	ElectricCurrent      int16
+	ElectricCurrentSet   bool
+	Temperature          int16
+	TemperatureSet       bool
+	AmountOfSubstance    int16
+	AmountOfSubstanceSet bool
+	LuminousIntensity    int16
+	LuminousIntensitySet bool
 }
 
-func parseSiExponents(tok *tokenGenerator) (siExponents, error) {
-	se := siExponents{}
+func parseSiExponents(tok *tokenGenerator) (SiExponents, error) {
+	se := SiExponents{}
 	var err error
 forLoop:
 	for {
@@ -38,75 +38,75 @@ forLoop:
 			err = errors.New("unexpected token " + tok.current())
 			log.Err(err).Msg("siExponents could not be parsed")
 			break forLoop
-		} else if !se.lengthSet {
+		} else if !se.LengthSet {
 			var buf int64
 			buf, err = strconv.ParseInt(tok.current(), 10, 16)
 			if err != nil {
 				log.Err(err).Msg("siExponents length could not be parsed")
 				break forLoop
 			}
-			se.length = int16(buf)
-			se.lengthSet = true
+			se.Length = int16(buf)
+			se.LengthSet = true
 			log.Info().Msg("siExponents length successfully parsed")
-		} else if !se.massSet {
+		} else if !se.MassSet {
 			var buf int64
 			buf, err = strconv.ParseInt(tok.current(), 10, 16)
 			if err != nil {
 				log.Err(err).Msg("siExponents mass could not be parsed")
 				break forLoop
 			}
-			se.mass = int16(buf)
-			se.massSet = true
+			se.Mass = int16(buf)
+			se.MassSet = true
 			log.Info().Msg("siExponents mass successfully parsed")
-		} else if !se.timeSet {
+		} else if !se.TimeSet {
 			var buf int64
 			buf, err = strconv.ParseInt(tok.current(), 10, 16)
 			if err != nil {
 				log.Err(err).Msg("siExponents time could not be parsed")
 				break forLoop
 			}
-			se.time = int16(buf)
-			se.timeSet = true
+			se.Time = int16(buf)
+			se.TimeSet = true
 			log.Info().Msg("siExponents time successfully parsed")
-		} else if !se.electricCurrentSet {
+		} else if !se.ElectricCurrentSet {
 			var buf int64
 			buf, err = strconv.ParseInt(tok.current(), 10, 16)
 			if err != nil {
 				log.Err(err).Msg("siExponents electricCurrent could not be parsed")
 				break forLoop
 			}
-			se.electricCurrent = int16(buf)
-			se.electricCurrentSet = true
+			se.ElectricCurrent = int16(buf)
+			se.ElectricCurrentSet = true
 			log.Info().Msg("siExponents electricCurrent successfully parsed")
-		} else if !se.temperatureSet {
+		} else if !se.TemperatureSet {
 			var buf int64
 			buf, err = strconv.ParseInt(tok.current(), 10, 16)
 			if err != nil {
 				log.Err(err).Msg("siExponents temperature could not be parsed")
 				break forLoop
 			}
-			se.temperature = int16(buf)
-			se.temperatureSet = true
+			se.Temperature = int16(buf)
+			se.TemperatureSet = true
 			log.Info().Msg("siExponents temperature successfully parsed")
-		} else if !se.amountOfSubstanceSet {
+		} else if !se.AmountOfSubstanceSet {
 			var buf int64
 			buf, err = strconv.ParseInt(tok.current(), 10, 16)
 			if err != nil {
 				log.Err(err).Msg("siExponents amountOfSubstance could not be parsed")
 				break forLoop
 			}
-			se.amountOfSubstance = int16(buf)
-			se.amountOfSubstanceSet = true
+			se.AmountOfSubstance = int16(buf)
+			se.AmountOfSubstanceSet = true
 			log.Info().Msg("siExponents amountOfSubstance successfully parsed")
-		} else if !se.luminousIntensitySet {
+		} else if !se.LuminousIntensitySet {
 			var buf int64
 			buf, err = strconv.ParseInt(tok.current(), 10, 16)
 			if err != nil {
 				log.Err(err).Msg("siExponents luminousIntensity could not be parsed")
 				break forLoop
 			}
-			se.luminousIntensity = int16(buf)
-			se.luminousIntensitySet = true
+			se.LuminousIntensity = int16(buf)
+			se.LuminousIntensitySet = true
 			log.Info().Msg("siExponents luminousIntensity successfully parsed")
 			break forLoop
 		}
@@ -2,17 +2,23 @@ package a2l
 
 import "github.com/rs/zerolog/log"
 
+/*staticAddressOffsetsKeyword states that
+for calibration objects with a dynamic number of axis points
+the memory is reserved for the max. number of axis points like with STATIC_RECORD_LAYOUT,
+but the record layout elements keep a static offset to the end of their reserved memory area.
+When removing axis points the unused memory is located at the beginning of each reserved area
+instead of its end.*/
 type staticAddressOffsetsKeyword struct {
-	value    bool
-	valueSet bool
+	Value    bool
+	ValueSet bool
 }
 
 func parseStaticAddressOffsets(tok *tokenGenerator) (staticAddressOffsetsKeyword, error) {
 	sao := staticAddressOffsetsKeyword{}
 	var err error
-	if !sao.valueSet {
-		sao.value = true
-		sao.valueSet = true
+	if !sao.ValueSet {
+		sao.Value = true
+		sao.ValueSet = true
 		log.Info().Msg("StaticAddressOffsets value successfully parsed")
 	}
 	return sao, err
@@ -14,16 +14,16 @@ the calibration objects referencing this record layout do compact / extend data
 removing resp. inserting axis points and the addresses of the record layout elements
 depend on the actual number of axis points.*/
 type staticRecordLayoutKeyword struct {
-	value    bool
-	valueSet bool
+	Value    bool
+	ValueSet bool
 }
 
 func parseStaticRecordLayout(tok *tokenGenerator) (staticRecordLayoutKeyword, error) {
 	srl := staticRecordLayoutKeyword{}
 	var err error
-	if !srl.valueSet {
-		srl.value = true
-		srl.valueSet = true
+	if !srl.ValueSet {
+		srl.Value = true
+		srl.ValueSet = true
 		log.Info().Msg("staticRecordLayout value successfully parsed")
 	}
 	return srl, err
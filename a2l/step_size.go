@@ -7,9 +7,10 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// StepSize is the smallest possible change of the physical value in the calibration system
 type StepSize struct {
-	stepSize    float64
-	stepSizeSet bool
+	StepSize    float64
+	StepSizeSet bool
 }
 
 func parseStepSize(tok *tokenGenerator) (StepSize, error) {
@@ -22,14 +23,14 @@ func parseStepSize(tok *tokenGenerator) (StepSize, error) {
 	} else if isKeyword(tok.current()) {
 		err = errors.New("unexpected token " + tok.current())
 		log.Err(err).Msg("stepSize could not be parsed")
-	} else if !ss.stepSizeSet {
+	} else if !ss.StepSizeSet {
 		var buf float64
 		buf, err = strconv.ParseFloat(tok.current(), 64)
 		if err != nil {
 			log.Err(err).Msg("stepSize stepSize could not be parsed")
 		}
-		ss.stepSize = buf
-		ss.stepSizeSet = true
+		ss.StepSize = buf
+		ss.StepSizeSet = true
 		log.Info().Msg("stepSize stepSize successfully parsed")
 	}
 	return ss, err
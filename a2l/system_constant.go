@@ -40,3 +40,13 @@ forLoop:
 	}
 	return sc, err
 }
+
+// isSystemConstantRef reports whether a token that is expected to be a number refers to a system constant instead.
+// numbers start with a digit, a sign or a decimal point, system constants are referenced by their (quoted) name.
+func isSystemConstantRef(token string) bool {
+	if token == "" {
+		return false
+	}
+	c := token[0]
+	return c == '"' || c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -24,7 +24,7 @@ type typeDefCharacteristic struct {
 	lowerLimitSet     bool
 	upperLimit        float64
 	upperLimitSet     bool
-	axisDescr         []axisDescr
+	axisDescr         []AxisDescr
 	bitMask           bitMask
 	byteOrder         ByteOrder
 	discrete          discreteKeyword
@@ -44,7 +44,7 @@ forLoop:
 	for {
 		switch tok.next() {
 		case beginAxisDescrToken:
-			var buf axisDescr
+			var buf AxisDescr
 			buf, err = parseAxisDescr(tok)
 			if err != nil {
 				log.Err(err).Msg("typeDefCharacteristic axisDescr could not be parsed")
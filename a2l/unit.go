@@ -6,42 +6,48 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-type unit struct {
-	name              string
-	nameSet           bool
-	longIdentifier    string
-	longIdentifierSet bool
-	display           string
-	displaySet        bool
+/*
+Unit defines a physical unit.
+EXTENDED_SI units are defined by the exponents of the seven SI base units,
+DERIVED units refer to another unit via REF_UNIT and convert to it with UNIT_CONVERSION:
+value in derived unit = gradient * value in referenced unit + offset
+*/
+type Unit struct {
+	Name              string
+	NameSet           bool
+	LongIdentifier    string
+	LongIdentifierSet bool
+	Display           string
+	DisplaySet        bool
 	Type              TypeEnum
 	TypeSet           bool
-	refUnit           refUnit
-	siExponents       siExponents
-	unitConversion    unitConversion
+	RefUnit           RefUnit
+	SiExponents       SiExponents
+	UnitConversion    UnitConversion
 }
 
-func parseUnit(tok *tokenGenerator) (unit, error) {
-	u := unit{}
+func parseUnit(tok *tokenGenerator) (Unit, error) {
+	u := Unit{}
 	var err error
 forLoop:
 	for {
 		switch tok.next() {
 		case refUnitToken:
-			u.refUnit, err = parseRefUnit(tok)
+			u.RefUnit, err = parseRefUnit(tok)
 			if err != nil {
 				log.Err(err).Msg("unit refUnit could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("unit refUnit successfully parsed")
 		case siExponentsToken:
-			u.siExponents, err = parseSiExponents(tok)
+			u.SiExponents, err = parseSiExponents(tok)
 			if err != nil {
 				log.Err(err).Msg("unit siExponents could not be parsed")
 				break forLoop
 			}
 			log.Info().Msg("unit siExponents successfully parsed")
 		case unitConversionToken:
-			u.unitConversion, err = parseUnitConversion(tok)
+			u.UnitConversion, err = parseUnitConversion(tok)
 			if err != nil {
 				log.Err(err).Msg("unit unitConversion could not be parsed")
 				break forLoop
@@ -58,17 +64,17 @@ forLoop:
 				err = errors.New("unexpected token " + tok.current())
 				log.Err(err).Msg("unit could not be parsed")
 				break forLoop
-			} else if !u.nameSet {
-				u.name = tok.current()
-				u.nameSet = true
+			} else if !u.NameSet {
+				u.Name = tok.current()
+				u.NameSet = true
 				log.Info().Msg("unit name successfully parsed")
-			} else if !u.longIdentifierSet {
-				u.longIdentifier = tok.current()
-				u.nameSet = true
-				log.Info().Msg("unit name successfully parsed")
-			} else if !u.displaySet {
-				u.display = tok.current()
-				u.displaySet = true
+			} else if !u.LongIdentifierSet {
+				u.LongIdentifier = tok.current()
+				u.LongIdentifierSet = true
+				log.Info().Msg("unit longIdentifier successfully parsed")
+			} else if !u.DisplaySet {
+				u.Display = tok.current()
+				u.DisplaySet = true
 				log.Info().Msg("unit display successfully parsed")
 			} else if !u.TypeSet {
 				u.Type, err = parseTypeEnum(tok)
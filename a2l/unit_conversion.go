@@ -7,15 +7,15 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-type unitConversion struct {
-	gradient    float64
-	gradientSet bool
-	offset      float64
-	offsetSet   bool
+type UnitConversion struct {
+	Gradient    float64
+	GradientSet bool
+	Offset      float64
+	OffsetSet   bool
 }
 
-func parseUnitConversion(tok *tokenGenerator) (unitConversion, error) {
-	uc := unitConversion{}
+func parseUnitConversion(tok *tokenGenerator) (UnitConversion, error) {
+	uc := UnitConversion{}
 	var err error
 forLoop:
 	for {
@@ -28,25 +28,25 @@ forLoop:
 			err = errors.New("unexpected token " + tok.current())
 			log.Err(err).Msg("unitConversion could not be parsed")
 			break forLoop
-		} else if !uc.gradientSet {
+		} else if !uc.GradientSet {
 			var buf float64
 			buf, err = strconv.ParseFloat(tok.current(), 64)
 			if err != nil {
 				log.Err(err).Msg("unitConversion gradient could not be parsed")
 				break forLoop
 			}
-			uc.gradient = buf
-			uc.gradientSet = true
+			uc.Gradient = buf
+			uc.GradientSet = true
 			log.Info().Msg("unitConversion gradient successfully parsed")
-		} else if !uc.offsetSet {
+		} else if !uc.OffsetSet {
 			var buf float64
 			buf, err = strconv.ParseFloat(tok.current(), 64)
 			if err != nil {
 				log.Err(err).Msg("unitConversion offset could not be parsed")
 				break forLoop
 			}
-			uc.offset = buf
-			uc.offsetSet = true
+			uc.Offset = buf
+			uc.OffsetSet = true
 			log.Info().Msg("unitConversion offset successfully parsed")
 			break forLoop
 		}
@@ -0,0 +1,58 @@
+package a2l
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+//create unit tests for the following functions: parseUnit
+// - extended SI unit
+// - derived unit with reference unit and conversion
+// - empty unit
+
+func TestParseUnit_ExtendedSi(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	tokenList = []string{emptyToken, "newton", "\"extended SI unit for force\"", "\"[N]\"", extendedSiToken, siExponentsToken, "1", "1", "-2", "0", "0", "0", "0", endUnitToken}
+	tok := newTokenGenerator()
+	u, err := parseUnit(&tok)
+	if err != nil {
+		t.Fatalf("failed parsing with error: %s.", err)
+	}
+	if u.Name != "newton" || u.LongIdentifier != "\"extended SI unit for force\"" || u.Display != "\"[N]\"" || u.Type != ExtendedSi {
+		t.Fatalf("unit not parsed correctly: %+v", u)
+	}
+	se := u.SiExponents
+	if se.Length != 1 || se.Mass != 1 || se.Time != -2 || !se.LuminousIntensitySet {
+		t.Fatalf("siExponents not parsed correctly: %+v", se)
+	}
+}
+
+func TestParseUnit_Derived(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	tokenList = []string{emptyToken, "kms_per_hour", "\"derived unit for velocity\"", "\"[km/h]\"", derivedToken, refUnitToken, "metres_per_second", unitConversionToken, "3.6", "0.0", endUnitToken}
+	tok := newTokenGenerator()
+	u, err := parseUnit(&tok)
+	if err != nil {
+		t.Fatalf("failed parsing with error: %s.", err)
+	}
+	if u.Type != Derived || u.RefUnit.Unit != "metres_per_second" || !u.RefUnit.UnitSet {
+		t.Fatalf("unit not parsed correctly: %+v", u)
+	}
+	if u.UnitConversion.Gradient != 3.6 || u.UnitConversion.Offset != 0 || !u.UnitConversion.OffsetSet {
+		t.Fatalf("unitConversion not parsed correctly: %+v", u.UnitConversion)
+	}
+}
+
+func TestParseUnit_Empty(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	tokenList = []string{emptyToken, emptyToken}
+	tok := newTokenGenerator()
+	_, err := parseUnit(&tok)
+	if err == nil {
+		t.Fatalf("failed test with undetected error: %s.", err)
+	}
+}
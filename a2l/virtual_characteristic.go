@@ -6,11 +6,16 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+/*
+VirtualCharacteristic defines a characteristic that has no memory within the ecu.
+its value is only visible within the MC-System and is computed with Formula
+from the physical values of the referenced characteristics, which are addressed as X1, X2, ... in the order of Characteristic.
+*/
 type VirtualCharacteristic struct {
-	formula           string
-	formulaSet        bool
-	characteristic    []string
-	characteristicSet bool
+	Formula           string
+	FormulaSet        bool
+	Characteristic    []string
+	CharacteristicSet bool
 }
 
 func parseVirtualCharacteristic(tok *tokenGenerator) (VirtualCharacteristic, error) {
@@ -24,19 +29,19 @@ forLoop:
 			log.Err(err).Msg("virtualCharacteristic could not be parsed")
 			break forLoop
 		} else if tok.current() == endVirtualCharacteristicToken {
-			vc.characteristicSet = true
+			vc.CharacteristicSet = true
 			log.Info().Msg("virtualCharacteristic characteristic successfully parsed")
 			break forLoop
 		} else if isKeyword(tok.current()) {
 			err = errors.New("unexpected token " + tok.current())
 			log.Err(err).Msg("virtualCharacteristic could not be parsed")
 			break forLoop
-		} else if !vc.formulaSet {
-			vc.formula = tok.current()
-			vc.formulaSet = true
+		} else if !vc.FormulaSet {
+			vc.Formula = tok.current()
+			vc.FormulaSet = true
 			log.Info().Msg("virtualCharacteristic formula successfully parsed")
-		} else if !vc.characteristicSet {
-			vc.characteristic = append(vc.characteristic, tok.current())
+		} else if !vc.CharacteristicSet {
+			vc.Characteristic = append(vc.Characteristic, tok.current())
 		}
 	}
 	return vc, err
@@ -0,0 +1,227 @@
+package calibrationReader
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
+)
+
+// memoryRegion is a memory segment or memory layout of MOD_PAR together with the offsets of its mirrors.
+type memoryRegion struct {
+	address uint32
+	size    uint32
+	offsets []int64
+}
+
+// AddAddressSpace parses the given hex file and uses it as image for all objects with the given ECU_ADDRESS_EXTENSION.
+// objects whose address extension has no image of its own are read from Hex.
+func (cd *CalibrationData) AddAddressSpace(extension int16, hexFilePath string) error {
+	var errChan = make(chan error, 1)
+	var hexChan = make(chan map[uint32]byte, 1)
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	readHex(wg, hexChan, errChan, hexFilePath)
+	close(errChan)
+	if err := <-errChan; err != nil {
+		log.Err(err).Msg("could not add address space " + strconv.Itoa(int(extension)))
+		return err
+	}
+	if cd.AddressSpaces == nil {
+		cd.AddressSpaces = make(map[int16]map[uint32]byte)
+	}
+	cd.AddressSpaces[extension] = <-hexChan
+	return nil
+}
+
+// inAddressSpace returns a shallow copy of the calibration data that reads from the image of the given address extension.
+// the copy keeps track of the primary image, so objects referenced from within it are read from their own address space.
+func (cd *CalibrationData) inAddressSpace(extension int16) *CalibrationData {
+	image, exists := cd.AddressSpaces[extension]
+	if !exists {
+		if cd.primaryHex == nil {
+			//already reading from the primary image
+			return cd
+		}
+		image = cd.primaryHex
+	}
+	view := *cd
+	if view.primaryHex == nil {
+		view.primaryHex = cd.Hex
+	}
+	view.Hex = image
+	return &view
+}
+
+// ResolveAddress maps the address of an a2l object together with its ECU_ADDRESS_EXTENSION
+// to the address within the image of that extension at which the object is stored.
+// if ApplyCalibrationOffset is set the ECU_CALIBRATION_OFFSET of MOD_PAR is added to select the data set of the configured variant.
+// addresses within a mirror of a MEMORY_SEGMENT or MEMORY_LAYOUT (e.g. the ram copy of a flash segment)
+// are mapped onto the segment itself. an error is returned if the resolved address is not part of the image.
+func (cd *CalibrationData) ResolveAddress(address string, extension int16) (uint32, error) {
+	_, resolved, _, err := cd.locate(address, extension)
+	return resolved, err
+}
+
+// locate resolves the address of an a2l object as described for ResolveAddress
+// and returns the calibration data that reads from the address space of the object along with the resolved address
+// and the exclusive end of the memory region the object has to fit into.
+func (cd *CalibrationData) locate(address string, extension int16) (*CalibrationData, uint32, uint64, error) {
+	addr, err := cd.convertStringToUint32Address(address)
+	if err != nil {
+		log.Err(err).Msg("could not resolve address")
+		return nil, 0, 0, err
+	}
+	abs := int64(addr)
+	if cd.ApplyCalibrationOffset {
+		offset, err := cd.getCalibrationOffset()
+		if err != nil {
+			log.Err(err).Msg("could not resolve address " + address)
+			return nil, 0, 0, err
+		}
+		abs += offset
+	}
+	view := cd.inAddressSpace(extension)
+	resolved, limit := view.mapToImage(abs)
+	if !view.isInImage(resolved) {
+		err = errors.New("object at address " + address + " resolved to " + fmt.Sprintf("0x%X", resolved) + " is outside of the hex file")
+		log.Err(err).Msg("could not resolve address")
+		return nil, 0, 0, err
+	}
+	return view, uint32(resolved), limit, nil
+}
+
+// checkExtent makes sure that an object occupying the addresses from start to end (exclusive) is entirely part of the image
+// and does not exceed the memory region it has been located in.
+func (cd *CalibrationData) checkExtent(name string, start uint32, end uint32, limit uint64) error {
+	if uint64(end) > limit {
+		err := errors.New("object " + name + " at " + fmt.Sprintf("0x%X", start) + " with " + fmt.Sprint(end-start) + " bytes exceeds its memory region ending at " + fmt.Sprintf("0x%X", limit))
+		log.Err(err).Msg("invalid object extent")
+		return err
+	}
+	for a := start; a < end; a++ {
+		if !cd.isInImage(int64(a)) {
+			err := errors.New("object " + name + " at " + fmt.Sprintf("0x%X", start) + " with " + fmt.Sprint(end-start) + " bytes is not completely part of the hex file")
+			log.Err(err).Msg("invalid object extent")
+			return err
+		}
+	}
+	return nil
+}
+
+// getCalibrationOffset returns the ECU_CALIBRATION_OFFSET of MOD_PAR or 0 if it is not defined.
+func (cd *CalibrationData) getCalibrationOffset() (int64, error) {
+	eco := cd.A2l.Project.Modules[cd.ModuleIndex].ModPar.EcuCalibrationOffset
+	if !eco.OffsetSet {
+		return 0, nil
+	}
+	offset, err := strconv.ParseInt(strings.TrimSpace(eco.Offset), 0, 64)
+	if err != nil {
+		log.Err(err).Msg("ecuCalibrationOffset '" + eco.Offset + "' could not be parsed")
+		return 0, err
+	}
+	return offset, nil
+}
+
+// resolvePointerTarget maps the address a pointer within a deposit points to onto the image.
+// PBYTE and PWORD are near pointers to which the ECU_CALIBRATION_OFFSET is added to compute the absolute address,
+// PLONG and PLONGLONG are absolute addresses.
+func (cd *CalibrationData) resolvePointerTarget(ptr uint32, at a2l.AddrTypeEnum) (uint32, error) {
+	abs := int64(ptr)
+	if at == a2l.PBYTE || at == a2l.PWORD {
+		offset, err := cd.getCalibrationOffset()
+		if err != nil {
+			log.Err(err).Msg("could not resolve near pointer")
+			return 0, err
+		}
+		abs += offset
+	}
+	target, _ := cd.mapToImage(abs)
+	if !cd.isInImage(target) {
+		err := errors.New("pointer target " + fmt.Sprintf("0x%X", abs) + " is outside of the hex file")
+		log.Err(err).Msg("could not resolve pointer target")
+		return 0, err
+	}
+	return uint32(target), nil
+}
+
+// mapToImage returns the address within the image at which the byte with the given ecu address is stored
+// together with the exclusive end of the memory region it belongs to.
+// addresses within a mirror of a memory segment or memory layout are mapped onto the segment,
+// all other addresses are returned unchanged.
+func (cd *CalibrationData) mapToImage(addr int64) (int64, uint64) {
+	for _, r := range cd.getMemoryRegions() {
+		start, end := int64(r.address), int64(r.address)+int64(r.size)
+		if addr >= start && addr < end {
+			return addr, uint64(end)
+		}
+		for _, o := range r.offsets {
+			if addr >= start+o && addr < end+o {
+				return addr - o, uint64(end)
+			}
+		}
+	}
+	return addr, math.MaxUint32 + 1
+}
+
+// isInImage reports whether the byte at the given address is part of the image.
+func (cd *CalibrationData) isInImage(addr int64) bool {
+	if addr < 0 || addr > math.MaxUint32 {
+		return false
+	}
+	_, exists := cd.Hex[uint32(addr)]
+	return exists
+}
+
+// getMemoryRegions collects the memory segments and memory layouts of MOD_PAR that define at least one mirror.
+// offsets of -1 mark unused mirrors, segments whose address or size cannot be parsed are ignored.
+func (cd *CalibrationData) getMemoryRegions() []memoryRegion {
+	mp := &cd.A2l.Project.Modules[cd.ModuleIndex].ModPar
+	regions := make([]memoryRegion, 0, len(mp.MemorySegments)+len(mp.MemoryLayouts))
+	for _, ms := range mp.MemorySegments {
+		offsets := make([]int64, 0, len(ms.Offset))
+		for _, s := range ms.Offset {
+			o, err := strconv.ParseInt(strings.TrimSpace(s), 0, 64)
+			if err == nil && o != -1 && o != 0 {
+				offsets = append(offsets, o)
+			}
+		}
+		if r, ok := newMemoryRegion(ms.Address, ms.Size, offsets); ok {
+			regions = append(regions, r)
+		}
+	}
+	for _, ml := range mp.MemoryLayouts {
+		offsets := make([]int64, 0, len(ml.Offset))
+		for _, o := range ml.Offset {
+			if o != -1 && o != 0 {
+				offsets = append(offsets, int64(o))
+			}
+		}
+		if r, ok := newMemoryRegion(ml.Address, ml.Size, offsets); ok {
+			regions = append(regions, r)
+		}
+	}
+	return regions
+}
+
+// newMemoryRegion parses address and size of a memory segment or memory layout.
+// false is returned if one of them is invalid or the region is not mirrored at all.
+func newMemoryRegion(address string, size string, offsets []int64) (memoryRegion, bool) {
+	if len(offsets) == 0 {
+		return memoryRegion{}, false
+	}
+	a, err := strconv.ParseUint(strings.TrimSpace(address), 0, 32)
+	if err != nil {
+		return memoryRegion{}, false
+	}
+	s, err := strconv.ParseUint(strings.TrimSpace(size), 0, 32)
+	if err != nil {
+		return memoryRegion{}, false
+	}
+	return memoryRegion{address: uint32(a), size: uint32(s), offsets: offsets}, true
+}
@@ -0,0 +1,80 @@
+package calibrationReader
+
+import (
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestAddressResolution(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	rl := a2l.RecordLayout{Name: "RL.TEST.ADDRESS", NameSet: true}
+	rl.FncValues = a2l.FncValues{Position: 1, PositionSet: true, Datatype: a2l.UBYTE, DatatypeSet: true}
+	m.RecordLayouts[rl.Name] = rl
+	c := m.Characteristics["ASAM.C.SCALAR.UBYTE.IDENTICAL"]
+	c.Name = "TEST.SCALAR.ADDRESS"
+	c.Deposit = rl.Name
+	c.Conversion = "NO_COMPU_METHOD"
+	read := func(address string, extension int16) float64 {
+		c.Address = address
+		c.EcuAddressExtension.Extension = extension
+		m.Characteristics[c.Name] = c
+		cv, err := cd.GetCharacteristicValues(c.Name)
+		if err != nil {
+			t.Fatalf("could not get value at %s: %s", address, err)
+		}
+		return cv.RawValues.(float64)
+	}
+
+	//addresses that are part of the hex file are used as they are
+	if a, err := cd.ResolveAddress("0x810000", 0); err != nil || a != 0x810000 {
+		t.Errorf("expected unchanged address, got 0x%X, %v", a, err)
+	}
+	//objects outside of the hex file are reported instead of falling back to another address
+	if _, err := cd.ResolveAddress("0xEFF000", 0); err == nil {
+		t.Errorf("expected error for address outside of the hex file")
+	}
+	//the ECU_CALIBRATION_OFFSET 0x1000 of the demo selects the variant data set even if the base data set is part of the hex file
+	cd.Hex[0xEFF000] = 0x11
+	cd.Hex[0xF00000] = 0x2A
+	if v := read("0xEFF000", 0); v != 17 {
+		t.Errorf("expected value of base data set, got %v", v)
+	}
+	cd.ApplyCalibrationOffset = true
+	if v := read("0xEFF000", 0); v != 42 {
+		t.Errorf("expected value at calibration offset, got %v", v)
+	}
+	cd.ApplyCalibrationOffset = false
+	//the ram mirror at 0xF20000 of a flash segment at 0xF10000 is read from the flash segment
+	ms := m.ModPar.MemorySegments[0]
+	ms.Address, ms.Size = "0xF10000", "0x100"
+	ms.Offset = [5]string{"-1", "0x10000", "-1", "-1", "-1"}
+	m.ModPar.MemorySegments = append(m.ModPar.MemorySegments, ms)
+	cd.Hex[0xF10010] = 0x17
+	if v := read("0xF20010", 0); v != 23 {
+		t.Errorf("expected value of mirrored segment, got %v", v)
+	}
+	//objects must not cross the end of the memory region they are located in
+	rlWord := a2l.RecordLayout{Name: "RL.TEST.ADDRESS.UWORD", NameSet: true}
+	rlWord.FncValues = a2l.FncValues{Position: 1, PositionSet: true, Datatype: a2l.UWORD, DatatypeSet: true}
+	m.RecordLayouts[rlWord.Name] = rlWord
+	cw := c
+	cw.Name, cw.Deposit, cw.Address = "TEST.SCALAR.ADDRESS.UWORD", rlWord.Name, "0xF200FF"
+	m.Characteristics[cw.Name] = cw
+	cd.Hex[0xF100FF], cd.Hex[0xF10100] = 0x01, 0x02
+	if _, err := cd.GetCharacteristicValues(cw.Name); err == nil {
+		t.Errorf("expected error for object crossing the end of a mirrored segment")
+	}
+	//objects with an address extension are read from their own address space if there is one
+	cd.AddressSpaces = map[int16]map[uint32]byte{1: {0xF00000: 0x05}}
+	if v := read("0xF00000", 1); v != 5 {
+		t.Errorf("expected value of address space 1, got %v", v)
+	}
+	if v := read("0xF00000", 2); v != 42 {
+		t.Errorf("expected value of primary address space, got %v", v)
+	}
+}
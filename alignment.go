@@ -0,0 +1,176 @@
+package calibrationReader
+
+import (
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+)
+
+// getAlignment returns the alignment border in bytes for values of the given datatype.
+// the alignment defined within the record layout takes precedence over the alignment defined in MOD_COMMON.
+// if neither defines an alignment the size of the datatype is used, i.e.
+// ALIGNMENT_BYTE 1, ALIGNMENT_WORD 2, ALIGNMENT_LONG 4, ALIGNMENT_INT64 8,
+// ALIGNMENT_FLOAT16_IEEE 2, ALIGNMENT_FLOAT32_IEEE 4 and ALIGNMENT_FLOAT64_IEEE 8.
+func (cd *CalibrationData) getAlignment(dte a2l.DataTypeEnum, rl *a2l.RecordLayout) uint32 {
+	mc := &cd.A2l.Project.Modules[cd.ModuleIndex].ModCommon
+	var rlSet, mcSet bool
+	var rlBorder, mcBorder uint16
+	switch dte {
+	case a2l.UBYTE, a2l.SBYTE:
+		rlSet, rlBorder = rl.AlignmentByte.AlignmentBorderSet, rl.AlignmentByte.AlignmentBorder
+		mcSet, mcBorder = mc.AlignmentByte.AlignmentBorderSet, mc.AlignmentByte.AlignmentBorder
+	case a2l.UWORD, a2l.SWORD:
+		rlSet, rlBorder = rl.AlignmentWord.AlignmentBorderSet, rl.AlignmentWord.AlignmentBorder
+		mcSet, mcBorder = mc.AlignmentWord.AlignmentBorderSet, mc.AlignmentWord.AlignmentBorder
+	case a2l.ULONG, a2l.SLONG:
+		rlSet, rlBorder = rl.AlignmentLong.AlignmentBorderSet, rl.AlignmentLong.AlignmentBorder
+		mcSet, mcBorder = mc.AlignmentLong.AlignmentBorderSet, mc.AlignmentLong.AlignmentBorder
+	case a2l.AUint64, a2l.AInt64:
+		rlSet, rlBorder = rl.AlignmentInt64.AlignmentBorderSet, rl.AlignmentInt64.AlignmentBorder
+		mcSet, mcBorder = mc.AlignmentInt64.AlignmentBorderSet, mc.AlignmentInt64.AlignmentBorder
+	case a2l.Float16Ieee:
+		rlSet, rlBorder = rl.AlignmentFloat16Ieee.AlignmentBorderSet, rl.AlignmentFloat16Ieee.AlignmentBorder
+		mcSet, mcBorder = mc.AlignmentFloat16Ieee.AlignmentBorderSet, mc.AlignmentFloat16Ieee.AlignmentBorder
+	case a2l.Float32Ieee:
+		rlSet, rlBorder = rl.AlignmentFloat32Ieee.AlignmentBorderSet, rl.AlignmentFloat32Ieee.AlignmentBorder
+		mcSet, mcBorder = mc.AlignmentFloat32Ieee.AlignmentBorderSet, mc.AlignmentFloat32Ieee.AlignmentBorder
+	case a2l.Float64Ieee:
+		rlSet, rlBorder = rl.AlignmentFloat64Ieee.AlignmentBorderSet, rl.AlignmentFloat64Ieee.AlignmentBorder
+		mcSet, mcBorder = mc.AlignmentFloat64Ieee.AlignmentBorderSet, mc.AlignmentFloat64Ieee.AlignmentBorder
+	default:
+		return 1
+	}
+	switch {
+	case rlSet && rlBorder > 0:
+		return uint32(rlBorder)
+	case mcSet && mcBorder > 0:
+		return uint32(mcBorder)
+	default:
+		return uint32(dte.GetDatatypeLength() / 8)
+	}
+}
+
+// alignAddress returns the first address that is greater or equal to the given address and dividable by the alignment border.
+func alignAddress(address uint32, border uint32) uint32 {
+	if border <= 1 {
+		return address
+	}
+	return address + (border-address%border)%border
+}
+
+// getFieldPadding returns the number of bytes that are inserted before the record layout field at the given address
+// to align its first element.
+func (cd *CalibrationData) getFieldPadding(address uint32, field string, rl *a2l.RecordLayout) uint32 {
+	dte, exists := getFieldDatatype(field, rl)
+	if !exists {
+		return 0
+	}
+	return alignAddress(address, cd.getAlignment(dte, rl)) - address
+}
+
+// getFieldDatatype returns the datatype of the first element of a record layout field as it is stored in memory.
+// for indirect addressing this is the datatype of the pointer.
+// fields that do not occupy memory within the deposit (RIP_ADDR and SRC_ADDR) have no datatype.
+func getFieldDatatype(field string, rl *a2l.RecordLayout) (a2l.DataTypeEnum, bool) {
+	var dte a2l.DataTypeEnum
+	var set bool
+	var at a2l.AddrTypeEnum
+	switch field {
+	case "AxisPtsX":
+		dte, set, at = rl.AxisPtsX.Datatype, rl.AxisPtsX.DatatypeSet, rl.AxisPtsX.Addressing
+	case "AxisPtsY":
+		dte, set, at = rl.AxisPtsY.Datatype, rl.AxisPtsY.DatatypeSet, rl.AxisPtsY.Addressing
+	case "AxisPtsZ":
+		dte, set, at = rl.AxisPtsZ.Datatype, rl.AxisPtsZ.DatatypeSet, rl.AxisPtsZ.Addressing
+	case "AxisPts4":
+		dte, set, at = rl.AxisPts4.Datatype, rl.AxisPts4.DatatypeSet, rl.AxisPts4.Addressing
+	case "AxisPts5":
+		dte, set, at = rl.AxisPts5.Datatype, rl.AxisPts5.DatatypeSet, rl.AxisPts5.Addressing
+	case "AxisRescaleX":
+		dte, set, at = rl.AxisRescaleX.Datatype, rl.AxisRescaleX.DatatypeSet, rl.AxisRescaleX.Adressing
+	case "DistOpX":
+		dte, set = rl.DistOpX.Datatype, rl.DistOpX.DatatypeSet
+	case "DistOpY":
+		dte, set = rl.DistOpY.Datatype, rl.DistOpY.DatatypeSet
+	case "DistOpZ":
+		dte, set = rl.DistOpZ.Datatype, rl.DistOpZ.DatatypeSet
+	case "DistOp4":
+		dte, set = rl.DistOp4.Datatype, rl.DistOp4.DatatypeSet
+	case "DistOp5":
+		dte, set = rl.DistOp5.Datatype, rl.DistOp5.DatatypeSet
+	case "FncValues":
+		dte, set, at = rl.FncValues.Datatype, rl.FncValues.DatatypeSet, rl.FncValues.Addresstype
+	case "Identification":
+		dte, set = rl.Identification.Datatype, rl.Identification.DatatypeSet
+	case "NoAxisPtsX":
+		dte, set = rl.NoAxisPtsX.Datatype, rl.NoAxisPtsX.DatatypeSet
+	case "NoAxisPtsY":
+		dte, set = rl.NoAxisPtsY.Datatype, rl.NoAxisPtsY.DatatypeSet
+	case "NoAxisPtsZ":
+		dte, set = rl.NoAxisPtsZ.Datatype, rl.NoAxisPtsZ.DatatypeSet
+	case "NoAxisPts4":
+		dte, set = rl.NoAxisPts4.Datatype, rl.NoAxisPts4.DatatypeSet
+	case "NoAxisPts5":
+		dte, set = rl.NoAxisPts5.Datatype, rl.NoAxisPts5.DatatypeSet
+	case "NoRescaleX":
+		dte, set = rl.NoRescaleX.Datatype, rl.NoRescaleX.DatatypeSet
+	case "OffsetX":
+		dte, set = rl.OffsetX.Datatype, rl.OffsetX.DatatypeSet
+	case "OffsetY":
+		dte, set = rl.OffsetY.Datatype, rl.OffsetY.DatatypeSet
+	case "OffsetZ":
+		dte, set = rl.OffsetZ.Datatype, rl.OffsetZ.DatatypeSet
+	case "Offset4":
+		dte, set = rl.Offset4.Datatype, rl.Offset4.DatatypeSet
+	case "Offset5":
+		dte, set = rl.Offset5.Datatype, rl.Offset5.DatatypeSet
+	case "Reserved":
+		dte, set = getReservedDatatype(rl)
+	case "ShiftOpX":
+		dte, set = rl.ShiftOpX.Datatype, rl.ShiftOpX.DatatypeSet
+	case "ShiftOpY":
+		dte, set = rl.ShiftOpY.Datatype, rl.ShiftOpY.DatatypeSet
+	case "ShiftOpZ":
+		dte, set = rl.ShiftOpZ.Datatype, rl.ShiftOpZ.DatatypeSet
+	case "ShiftOp4":
+		dte, set = rl.ShiftOp4.Datatype, rl.ShiftOp4.DatatypeSet
+	case "ShiftOp5":
+		dte, set = rl.ShiftOp5.Datatype, rl.ShiftOp5.DatatypeSet
+	default:
+		return dte, false
+	}
+	if pt, isPointer := getPointerDatatype(at); isPointer {
+		return pt, true
+	}
+	return dte, set
+}
+
+// getReservedDatatype returns the unsigned datatype with the size of the reserved data size.
+func getReservedDatatype(rl *a2l.RecordLayout) (a2l.DataTypeEnum, bool) {
+	if !rl.Reserved.DataSizeSet {
+		return a2l.UBYTE, false
+	}
+	switch rl.Reserved.DataSize {
+	case a2l.WORD:
+		return a2l.UWORD, true
+	case a2l.LONG:
+		return a2l.ULONG, true
+	default:
+		return a2l.UBYTE, true
+	}
+}
+
+// getPointerDatatype returns the datatype of the pointer used by the indirect address types PBYTE, PWORD, PLONG and PLONGLONG.
+// false is returned for all other address types.
+func getPointerDatatype(at a2l.AddrTypeEnum) (a2l.DataTypeEnum, bool) {
+	switch at {
+	case a2l.PBYTE:
+		return a2l.UBYTE, true
+	case a2l.PWORD:
+		return a2l.UWORD, true
+	case a2l.PLONG:
+		return a2l.ULONG, true
+	case a2l.PLONGLONG:
+		return a2l.AUint64, true
+	default:
+		return a2l.UBYTE, false
+	}
+}
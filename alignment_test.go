@@ -0,0 +1,83 @@
+package calibrationReader
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestAlignment(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	tests := []struct {
+		address uint32
+		border  uint32
+		aligned uint32
+	}{
+		{address: 0x1000, border: 4, aligned: 0x1000},
+		{address: 0x1001, border: 4, aligned: 0x1004},
+		{address: 0x1003, border: 4, aligned: 0x1004},
+		{address: 0x1001, border: 2, aligned: 0x1002},
+		{address: 0x1005, border: 8, aligned: 0x1008},
+		{address: 0x1005, border: 1, aligned: 0x1005},
+		{address: 0x1005, border: 0, aligned: 0x1005},
+	}
+	for _, tt := range tests {
+		if a := alignAddress(tt.address, tt.border); a != tt.aligned {
+			t.Errorf("aligning 0x%X to %d: expected 0x%X, got 0x%X", tt.address, tt.border, tt.aligned, a)
+		}
+	}
+
+	//MOD_COMMON of the demo defines ALIGNMENT_LONG 4 and ALIGNMENT_INT64 4
+	rl := a2l.RecordLayout{Name: "RL.TEST.ALIGNMENT", NameSet: true}
+	if a := cd.getAlignment(a2l.SLONG, &rl); a != 4 {
+		t.Errorf("expected alignment of MOD_COMMON, got %d", a)
+	}
+	if a := cd.getAlignment(a2l.AInt64, &rl); a != 4 {
+		t.Errorf("expected alignment of MOD_COMMON, got %d", a)
+	}
+	rl.AlignmentLong.AlignmentBorder = 2
+	rl.AlignmentLong.AlignmentBorderSet = true
+	if a := cd.getAlignment(a2l.SLONG, &rl); a != 2 {
+		t.Errorf("expected alignment of record layout, got %d", a)
+	}
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	mc := m.ModCommon
+	m.ModCommon.AlignmentFloat64Ieee.AlignmentBorderSet = false
+	if a := cd.getAlignment(a2l.Float64Ieee, &rl); a != 8 {
+		t.Errorf("expected default alignment, got %d", a)
+	}
+	m.ModCommon = mc
+
+	//curve with an UBYTE number of axis points followed by ULONG axis points and UWORD values
+	rl = a2l.RecordLayout{Name: "RL.TEST.ALIGNMENT", NameSet: true}
+	rl.NoAxisPtsX = a2l.NoAxisPtsX{Position: 1, PositionSet: true, Datatype: a2l.UBYTE, DatatypeSet: true}
+	rl.AxisPtsX = a2l.AxisPtsX{Position: 2, PositionSet: true, Datatype: a2l.ULONG, DatatypeSet: true}
+	rl.FncValues = a2l.FncValues{Position: 3, PositionSet: true, Datatype: a2l.UWORD, DatatypeSet: true}
+	m.RecordLayouts[rl.Name] = rl
+	c := m.Characteristics["ASAM.C.CURVE.STD_AXIS"]
+	c.Name = "TEST.CURVE.ALIGNMENT"
+	c.Address = "0xF00001"
+	c.Deposit = rl.Name
+	c.Conversion = "NO_COMPU_METHOD"
+	c.AxisDescr = append([]a2l.AxisDescr{}, c.AxisDescr...)
+	c.AxisDescr[0].Conversion = "NO_COMPU_METHOD"
+	m.Characteristics[c.Name] = c
+	data := []byte{2, 0xFF, 0xFF, 1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 4, 0}
+	for i, b := range data {
+		cd.Hex[0xF00001+uint32(i)] = b
+	}
+	cv, err := cd.GetCharacteristicValues(c.Name)
+	if err != nil {
+		t.Fatalf("could not get values: %s", err)
+	}
+	if fmt.Sprint(cv.AxisXRawValues) != "[1 2]" || fmt.Sprint(cv.RawValues) != "[3 4]" {
+		t.Errorf("unexpected axis %v or values %v", cv.AxisXRawValues, cv.RawValues)
+	}
+	if cv.Padding["NoAxisPtsX"] != 0 || cv.Padding["AxisPtsX"] != 2 || cv.Padding["FncValues"] != 0 {
+		t.Errorf("unexpected padding %v", cv.Padding)
+	}
+}
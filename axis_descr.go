@@ -0,0 +1,200 @@
+package calibrationReader
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
+)
+
+// resolveAxes determines the axis points of all axes of a characteristic that are not stored within its own deposit.
+// STD_AXIS points are read together with the function values from the record layout of the characteristic.
+// COM_AXIS and RES_AXIS points are read from the referenced AXIS_PTS object,
+// which also defines the number of axis points as described at a2l.AxisDescr.
+// RES_AXIS points are computed from the rescale pairs of the AXIS_PTS object if it defines AXIS_RESCALE_X.
+// CURVE_AXIS points are the values of the referenced curve
+// and FIX_AXIS points are computed from FIX_AXIS_PAR, FIX_AXIS_PAR_DIST or FIX_AXIS_PAR_LIST.
+// resolving holds the names of the characteristics whose values are currently computed in order to detect cyclic CURVE_AXIS_REF.
+func (cv *CharacteristicValues) resolveAxes(cd *CalibrationData, resolving []string) error {
+	var err error
+	for i, ad := range cv.characteristic.AxisDescr {
+		if i >= 5 {
+			err = errors.New("more than five axis descriptions defined in characteristic " + cv.characteristic.Name)
+			log.Err(err).Msg("could not resolve axes")
+			return err
+		}
+		raw, phy := cv.getAxisValuesRef(i)
+		switch ad.Attribute {
+		case a2l.StdAxis:
+			continue
+		case a2l.ComAxis, a2l.ResAxis:
+			if !ad.AxisPtsRef.AxisPointsSet {
+				err = errors.New("no AXIS_PTS_REF defined for axis " + strconv.Itoa(i) + " of characteristic " + cv.characteristic.Name)
+				log.Err(err).Msg("could not resolve axes")
+				return err
+			}
+			av, err := cd.readAxisPtsValues(ad.AxisPtsRef.AxisPoints)
+			if err != nil {
+				log.Err(err).Msg("could not resolve axis " + strconv.Itoa(i) + " of characteristic " + cv.characteristic.Name)
+				return err
+			}
+			*raw = av.RawValues
+			if ad.Attribute == a2l.ResAxis && len(av.RescalePairs) > 0 {
+				*raw, err = computeRescaleAxisPoints(av.RescalePairs, cv.getNoRescaleAxisPts(i, len(av.RescalePairs)))
+				if err != nil {
+					log.Err(err).Msg("could not resolve axis " + strconv.Itoa(i) + " of characteristic " + cv.characteristic.Name)
+					return err
+				}
+			}
+		case a2l.CurveAxis:
+			if !ad.CurveAxisRef.CurveAxisSet {
+				err = errors.New("no CURVE_AXIS_REF defined for axis " + strconv.Itoa(i) + " of characteristic " + cv.characteristic.Name)
+				log.Err(err).Msg("could not resolve axes")
+				return err
+			}
+			curve, err := cd.getCharacteristicValues(ad.CurveAxisRef.CurveAxis, resolving)
+			if err != nil {
+				log.Err(err).Msg("could not resolve axis " + strconv.Itoa(i) + " of characteristic " + cv.characteristic.Name)
+				return err
+			}
+			if curve.Type != a2l.Curve {
+				err = errors.New("curve axis " + curve.Name + " referenced by characteristic " + cv.characteristic.Name + " is not a curve")
+				log.Err(err).Msg("could not resolve axes")
+				return err
+			}
+			//a CURVE_AXIS has no conversion of its own. The referenced curve already converts its values.
+			*raw = curve.fncValues
+			*phy = curve.PhyValues
+		case a2l.FixAxis:
+			*raw, err = cd.getFixAxisPoints(&ad)
+			if err != nil {
+				log.Err(err).Msg("could not resolve axis " + strconv.Itoa(i) + " of characteristic " + cv.characteristic.Name)
+				return err
+			}
+		default:
+			err = errors.New("unexpected attribute " + string(ad.Attribute) + " for axis " + strconv.Itoa(i) + " of characteristic " + cv.characteristic.Name)
+			log.Err(err).Msg("could not resolve axes")
+			return err
+		}
+	}
+	return nil
+}
+
+// getAxisValuesRef returns references to the raw and physical values of the axis with the given index (0 = x ... 4 = 5)
+func (cv *CharacteristicValues) getAxisValuesRef(axisIndex int) (*[]float64, *interface{}) {
+	switch axisIndex {
+	case 0:
+		return &cv.AxisXRawValues, &cv.AxisXPhyValues
+	case 1:
+		return &cv.AxisYRawValues, &cv.AxisYPhyValues
+	case 2:
+		return &cv.AxisZRawValues, &cv.AxisZPhyValues
+	case 3:
+		return &cv.Axis4RawValues, &cv.Axis4PhyValues
+	default:
+		return &cv.Axis5RawValues, &cv.Axis5PhyValues
+	}
+}
+
+// getFixAxisPoints computes the virtual axis points of a FIX_AXIS.
+// FIX_AXIS_PAR:		X_i = Offset + i * 2^Shift
+// FIX_AXIS_PAR_DIST:	X_i = Offset + i * Distance
+// FIX_AXIS_PAR_LIST:	X_i as listed
+// the keywords are mutually exclusive. In case more than one is defined they are evaluated in the order above.
+// parameters that are defined by a system constant are replaced by its value.
+func (cd *CalibrationData) getFixAxisPoints(ad *a2l.AxisDescr) ([]float64, error) {
+	var val []float64
+	switch {
+	case ad.FixAxisPar.OffsetSet && ad.FixAxisPar.ShiftSet && ad.FixAxisPar.NumberapoSet:
+		offset, shift, numberapo, err := cd.resolveFixAxisParameters(
+			float64(ad.FixAxisPar.Offset), ad.FixAxisPar.OffsetRef,
+			float64(ad.FixAxisPar.Shift), ad.FixAxisPar.ShiftRef,
+			float64(ad.FixAxisPar.Numberapo), ad.FixAxisPar.NumberapoRef)
+		if err != nil {
+			log.Err(err).Msg("could not compute fix axis points")
+			return nil, err
+		}
+		dist := math.Pow(2, shift)
+		for i := 0; i < numberapo; i++ {
+			val = append(val, offset+float64(i)*dist)
+		}
+	case ad.FixAxisParDist.OffsetSet && ad.FixAxisParDist.DistanceSet && ad.FixAxisParDist.NumberapoSet:
+		offset, dist, numberapo, err := cd.resolveFixAxisParameters(
+			float64(ad.FixAxisParDist.Offset), ad.FixAxisParDist.OffsetRef,
+			float64(ad.FixAxisParDist.Distance), ad.FixAxisParDist.DistanceRef,
+			float64(ad.FixAxisParDist.Numberapo), ad.FixAxisParDist.NumberapoRef)
+		if err != nil {
+			log.Err(err).Msg("could not compute fix axis points")
+			return nil, err
+		}
+		for i := 0; i < numberapo; i++ {
+			val = append(val, offset+float64(i)*dist)
+		}
+	case len(ad.FixAxisParList) > 0:
+		for _, l := range ad.FixAxisParList {
+			for i, v := range l.AxisPtsValue {
+				var ref string
+				if i < len(l.AxisPtsValueRef) {
+					ref = l.AxisPtsValueRef[i]
+				}
+				p, err := cd.resolveFixAxisParameter(v, ref)
+				if err != nil {
+					log.Err(err).Msg("could not compute fix axis points")
+					return nil, err
+				}
+				val = append(val, p)
+			}
+		}
+	default:
+		err := errors.New("neither FIX_AXIS_PAR, FIX_AXIS_PAR_DIST nor FIX_AXIS_PAR_LIST defined for fix axis")
+		log.Err(err).Msg("could not compute fix axis points")
+		return nil, err
+	}
+	if len(val) == 0 {
+		err := errors.New("fix axis has no axis points")
+		log.Err(err).Msg("could not compute fix axis points")
+		return nil, err
+	}
+	return val, nil
+}
+
+// getAxisDatatype returns the datatype the points of the axis with the given index (0 = x ... 4 = 5) are stored with.
+// the axis points of COM_AXIS and RES_AXIS are stored within the deposit of the referenced AXIS_PTS object.
+// computed axes (FIX_AXIS, rescaled axes without stored points) are treated as floating point values.
+func (cv *CharacteristicValues) getAxisDatatype(cd *CalibrationData, axisIndex int) a2l.DataTypeEnum {
+	if axisIndex < len(cv.characteristic.AxisDescr) {
+		ad := &cv.characteristic.AxisDescr[axisIndex]
+		if (ad.Attribute == a2l.ComAxis || ad.Attribute == a2l.ResAxis) && ad.AxisPtsRef.AxisPointsSet {
+			m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+			if ap, exists := m.AxisPts[ad.AxisPtsRef.AxisPoints]; exists {
+				if rl, exists := m.RecordLayouts[ap.DepositIdent]; exists && rl.AxisPtsX.DatatypeSet {
+					return rl.AxisPtsX.Datatype
+				}
+			}
+			return a2l.Float64Ieee
+		}
+	}
+	if cv.recordLayout == nil {
+		return a2l.Float64Ieee
+	}
+	var dte a2l.DataTypeEnum
+	var set bool
+	switch axisIndex {
+	case 0:
+		dte, set = cv.recordLayout.AxisPtsX.Datatype, cv.recordLayout.AxisPtsX.DatatypeSet
+	case 1:
+		dte, set = cv.recordLayout.AxisPtsY.Datatype, cv.recordLayout.AxisPtsY.DatatypeSet
+	case 2:
+		dte, set = cv.recordLayout.AxisPtsZ.Datatype, cv.recordLayout.AxisPtsZ.DatatypeSet
+	case 3:
+		dte, set = cv.recordLayout.AxisPts4.Datatype, cv.recordLayout.AxisPts4.DatatypeSet
+	case 4:
+		dte, set = cv.recordLayout.AxisPts5.Datatype, cv.recordLayout.AxisPts5.DatatypeSet
+	}
+	if !set {
+		return a2l.Float64Ieee
+	}
+	return dte
+}
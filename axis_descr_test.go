@@ -0,0 +1,80 @@
+package calibrationReader
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestAxisResolution(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	av, err := cd.GetAxisPtsValues("ASAM.C.AXIS_PTS.UBYTE_8")
+	if err != nil {
+		t.Fatalf("could not get axis points: %s", err)
+	}
+	expectedComAxis := []float64{0, 1, 2, 3, 4, 5, 13, 15}
+	if fmt.Sprint(av.RawValues) != fmt.Sprint(expectedComAxis) {
+		t.Errorf("axis points: expected %v, got %v", expectedComAxis, av.RawValues)
+	}
+
+	tests := []struct {
+		name     string
+		expected []float64
+	}{
+		{name: "ASAM.C.CURVE.COM_AXIS", expected: expectedComAxis},
+		{name: "ASAM.C.CURVE.FIX_AXIS.PAR", expected: []float64{0, 4, 8, 12, 16, 20}},
+		{name: "ASAM.C.CURVE.FIX_AXIS.PAR_LIST", expected: []float64{-1, 4, 6, 8, 9, 10}},
+		{name: "ASAM.C.CURVE.CURVE_AXIS", expected: []float64{0, 1, 2, 3, 4, 5, 6, 7}},
+	}
+	for _, tt := range tests {
+		cv, err := cd.GetCharacteristicValues(tt.name)
+		if err != nil {
+			t.Fatalf("could not get values of %s: %s", tt.name, err)
+		}
+		if fmt.Sprint(cv.AxisXPhyValues) != fmt.Sprint(tt.expected) {
+			t.Errorf("%s: expected axis %v, got %v", tt.name, tt.expected, cv.AxisXPhyValues)
+		}
+		if len(cv.Dimensions) != 1 || cv.Dimensions[0] != len(tt.expected) {
+			t.Errorf("%s: expected dimension %d, got %v", tt.name, len(tt.expected), cv.Dimensions)
+		}
+	}
+
+	cv, err := cd.GetCharacteristicValues("ASAM.C.MAP.COM_AXIS.FIX_AXIS")
+	if err != nil {
+		t.Fatalf("could not get values of map: %s", err)
+	}
+	if fmt.Sprint(cv.AxisYPhyValues) != fmt.Sprint([]float64{1, 2, 3}) {
+		t.Errorf("map fix axis: expected [1 2 3], got %v", cv.AxisYPhyValues)
+	}
+
+	//cyclic CURVE_AXIS_REF are reported instead of recursing endlessly
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	setCurveAxisRef := func(name string, ref string) {
+		c := m.Characteristics[name]
+		ad := append([]a2l.AxisDescr(nil), c.AxisDescr...)
+		ad[0].Attribute = a2l.CurveAxis
+		ad[0].CurveAxisRef.CurveAxis = ref
+		ad[0].CurveAxisRef.CurveAxisSet = true
+		c.AxisDescr = ad
+		m.Characteristics[name] = c
+	}
+	setCurveAxisRef("ASAM.C.CURVE.CURVE_AXIS", "ASAM.C.CURVE.CURVE_AXIS")
+	if _, err = cd.GetCharacteristicValues("ASAM.C.CURVE.CURVE_AXIS"); err == nil || !strings.Contains(err.Error(), "cyclic") {
+		t.Errorf("expected self reference of curve axis to be detected, got %v", err)
+	}
+	setCurveAxisRef("ASAM.C.CURVE.CURVE_AXIS", "ASAM.C.CURVE_AXIS")
+	setCurveAxisRef("ASAM.C.CURVE_AXIS", "ASAM.C.CURVE.CURVE_AXIS")
+	for _, name := range []string{"ASAM.C.CURVE.CURVE_AXIS", "ASAM.C.CURVE_AXIS"} {
+		if _, err = cd.GetCharacteristicValues(name); err == nil || !strings.Contains(err.Error(), "cyclic") {
+			t.Errorf("%s: expected cyclic curve axis reference to be detected, got %v", name, err)
+		}
+		if _, err = cd.GetRecordLayoutMap(name); err == nil {
+			t.Errorf("%s: expected cyclic curve axis reference to be detected by record layout map", name)
+		}
+	}
+}
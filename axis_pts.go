@@ -13,195 +13,177 @@ import (
 
 // getAxisPointsX retrieves Axis Points according to their layout specified within the record layout and their values as calibrated in the hex file
 func (cv *CharacteristicValues) getAxisPointsX(cd *CalibrationData, rl *a2l.RecordLayout, curPos *uint32) ([]float64, error) {
-	var val []float64
-	var bufByte []byte
-	var bufFloat float64
-	var err error
-	var i int64
 	var noAxisPts int64
 	if rl.FixNoAxisPtsX.NumberOfAxisPointsSet {
 		noAxisPts = int64(rl.FixNoAxisPtsX.NumberOfAxisPoints)
-	} else if !rl.FixNoAxisPtsX.NumberOfAxisPointsSet {
+	} else {
 		if cv.noAxisPtsXValue <= 0 {
-			err = errors.New("number of axisPts is smaller or equal to zero")
+			err := errors.New("number of axisPts is smaller or equal to zero")
 			log.Err(err).Msg("could not retrieve NoAxisPointsX value")
 			return nil, err
 		}
 		noAxisPts = cv.noAxisPtsXValue
-	} else {
-		err = errors.New("number of axis points could not be determined")
-		log.Err(err).Msg("could not convert axisPointsX values")
+	}
+	val, addrs, err := cd.readReservedAxisPoints(rl, curPos, rl.AxisPtsX.Datatype, rl.AxisPtsX.Addressing, noAxisPts, cv.getMaxAxisPts(0), cv.getByteOrder(cd, 0))
+	if err != nil {
+		log.Err(err).Msg("could not retrieve axisPointsX values")
 		return nil, err
 	}
-	for i = 0; i < noAxisPts; i++ {
-		bufByte, err = cd.getValue(curPos, rl.AxisPtsX.Datatype, rl)
-		if err != nil {
-			log.Err(err).Msg("could not retrieve axisPointsX value")
-			return nil, err
-		}
-		bufFloat, err = cd.convertByteSliceToDatatype(bufByte, rl.AxisPtsX.Datatype)
-		if err != nil {
-			log.Err(err).Msg("could not retrieve axisPointsX value")
-			return nil, err
-		}
-		val = append(val, bufFloat)
-		*curPos += uint32(rl.AxisPtsX.Datatype.GetDatatypeLength())
+	if rl.AxisPtsX.IndexIncr == a2l.IndexDecr {
+		reverseSlice(val)
+		reverseSlice(addrs)
 	}
-	return val, err
+	cv.axisAddresses[0] = addrs
+	return val, nil
 }
 
 // getAxisPointsY retrieves Axis Points according to their layout specified within the record layout and their values as calibrated in the hex file
 func (cv *CharacteristicValues) getAxisPointsY(cd *CalibrationData, rl *a2l.RecordLayout, curPos *uint32) ([]float64, error) {
-	var val []float64
-	var bufByte []byte
-	var bufFloat float64
-	var err error
-	var i int64
 	var noAxisPts int64
 	if rl.FixNoAxisPtsY.NumberOfAxisPointsSet {
 		noAxisPts = int64(rl.FixNoAxisPtsY.NumberOfAxisPoints)
-	} else if !rl.FixNoAxisPtsY.NumberOfAxisPointsSet {
+	} else {
 		if cv.noAxisPtsYValue <= 0 {
-			err = errors.New("number of axisPts is smaller or equal to zero")
+			err := errors.New("number of axisPts is smaller or equal to zero")
 			log.Err(err).Msg("could not retrieve NoAxisPointsY value")
 			return nil, err
 		}
 		noAxisPts = cv.noAxisPtsYValue
-	} else {
-		err = errors.New("number of axis points could not be determined")
-		log.Err(err).Msg("could not convert axisPointsY values")
+	}
+	val, addrs, err := cd.readReservedAxisPoints(rl, curPos, rl.AxisPtsY.Datatype, rl.AxisPtsY.Addressing, noAxisPts, cv.getMaxAxisPts(1), cv.getByteOrder(cd, 1))
+	if err != nil {
+		log.Err(err).Msg("could not retrieve axisPointsY values")
 		return nil, err
 	}
-	for i = 0; i < noAxisPts; i++ {
-		bufByte, err = cd.getValue(curPos, rl.AxisPtsY.Datatype, rl)
-		if err != nil {
-			log.Err(err).Msg("could not retrieve axisPointsY value")
-			return nil, err
-		}
-		bufFloat, err = cd.convertByteSliceToDatatype(bufByte, rl.AxisPtsY.Datatype)
-		if err != nil {
-			log.Err(err).Msg("could not retrieve axisPointsY value")
-			return nil, err
-		}
-		val = append(val, bufFloat)
-		*curPos += uint32(rl.AxisPtsY.Datatype.GetDatatypeLength())
+	if rl.AxisPtsY.IndexIncr == a2l.IndexDecr {
+		reverseSlice(val)
+		reverseSlice(addrs)
 	}
-	return val, err
+	cv.axisAddresses[1] = addrs
+	return val, nil
 }
 
 // getAxisPointsZ retrieves Axis Points according to their layout specified within the record layout and their values as calibrated in the hex file
 func (cv *CharacteristicValues) getAxisPointsZ(cd *CalibrationData, rl *a2l.RecordLayout, curPos *uint32) ([]float64, error) {
-	var val []float64
-	var bufByte []byte
-	var bufFloat float64
-	var err error
-	var i int64
 	var noAxisPts int64
 	if rl.FixNoAxisPtsZ.NumberOfAxisPointsSet {
 		noAxisPts = int64(rl.FixNoAxisPtsZ.NumberOfAxisPoints)
-	} else if !rl.FixNoAxisPtsZ.NumberOfAxisPointsSet {
+	} else {
 		if cv.noAxisPtsZValue <= 0 {
-			err = errors.New("number of axisPts is smaller or equal to zero")
+			err := errors.New("number of axisPts is smaller or equal to zero")
 			log.Err(err).Msg("could not retrieve NoAxisPointsZ value")
 			return nil, err
 		}
 		noAxisPts = cv.noAxisPtsZValue
-	} else {
-		err = errors.New("number of axis points could not be determined")
-		log.Err(err).Msg("could not convert axisPointsZ values")
+	}
+	val, addrs, err := cd.readReservedAxisPoints(rl, curPos, rl.AxisPtsZ.Datatype, rl.AxisPtsZ.Addressing, noAxisPts, cv.getMaxAxisPts(2), cv.getByteOrder(cd, 2))
+	if err != nil {
+		log.Err(err).Msg("could not retrieve axisPointsZ values")
 		return nil, err
 	}
-	for i = 0; i < noAxisPts; i++ {
-		bufByte, err = cd.getValue(curPos, rl.AxisPtsZ.Datatype, rl)
-		if err != nil {
-			log.Err(err).Msg("could not retrieve axisPointsZ value")
-			return nil, err
-		}
-		bufFloat, err = cd.convertByteSliceToDatatype(bufByte, rl.AxisPtsZ.Datatype)
-		if err != nil {
-			log.Err(err).Msg("could not retrieve axisPointsZ value")
-			return nil, err
-		}
-		val = append(val, bufFloat)
-		*curPos += uint32(rl.AxisPtsZ.Datatype.GetDatatypeLength())
+	if rl.AxisPtsZ.IndexIncr == a2l.IndexDecr {
+		reverseSlice(val)
+		reverseSlice(addrs)
 	}
-	return val, err
+	cv.axisAddresses[2] = addrs
+	return val, nil
 }
 
 // getAxisPoints4 retrieves Axis Points according to their layout specified within the record layout and their values as calibrated in the hex file
 func (cv *CharacteristicValues) getAxisPoints4(cd *CalibrationData, rl *a2l.RecordLayout, curPos *uint32) ([]float64, error) {
-	var val []float64
-	var bufByte []byte
-	var bufFloat float64
-	var err error
-	var i int64
 	var noAxisPts int64
 	if rl.FixNoAxisPts4.NumberOfAxisPointsSet {
 		noAxisPts = int64(rl.FixNoAxisPts4.NumberOfAxisPoints)
-	} else if !rl.FixNoAxisPts4.NumberOfAxisPointsSet {
+	} else {
 		if cv.noAxisPts4Value <= 0 {
-			err = errors.New("number of axisPts is smaller or equal to zero")
+			err := errors.New("number of axisPts is smaller or equal to zero")
 			log.Err(err).Msg("could not retrieve NoAxisPoints4 value")
 			return nil, err
 		}
 		noAxisPts = cv.noAxisPts4Value
-	} else {
-		err = errors.New("number of axis points could not be determined")
-		log.Err(err).Msg("could not convert axisPoints4 values")
+	}
+	val, addrs, err := cd.readReservedAxisPoints(rl, curPos, rl.AxisPts4.Datatype, rl.AxisPts4.Addressing, noAxisPts, cv.getMaxAxisPts(3), cv.getByteOrder(cd, 3))
+	if err != nil {
+		log.Err(err).Msg("could not retrieve axisPoints4 values")
 		return nil, err
 	}
-	for i = 0; i < noAxisPts; i++ {
-		bufByte, err = cd.getValue(curPos, rl.AxisPts4.Datatype, rl)
-		if err != nil {
-			log.Err(err).Msg("could not retrieve axisPoints4 value")
-			return nil, err
-		}
-		bufFloat, err = cd.convertByteSliceToDatatype(bufByte, rl.AxisPts4.Datatype)
-		if err != nil {
-			log.Err(err).Msg("could not retrieve axisPoints4 value")
-			return nil, err
-		}
-		val = append(val, bufFloat)
-		*curPos += uint32(rl.AxisPts4.Datatype.GetDatatypeLength())
+	if rl.AxisPts4.IndexIncr == a2l.IndexDecr {
+		reverseSlice(val)
+		reverseSlice(addrs)
 	}
-	return val, err
+	cv.axisAddresses[3] = addrs
+	return val, nil
 }
 
 // getAxisPoints5 retrieves Axis Points according to their layout specified within the record layout and their values as calibrated in the hex file
 func (cv *CharacteristicValues) getAxisPoints5(cd *CalibrationData, rl *a2l.RecordLayout, curPos *uint32) ([]float64, error) {
-	var val []float64
-	var bufByte []byte
-	var bufFloat float64
-	var err error
-	var i int64
 	var noAxisPts int64
 	if rl.FixNoAxisPts5.NumberOfAxisPointsSet {
 		noAxisPts = int64(rl.FixNoAxisPts5.NumberOfAxisPoints)
-	} else if !rl.FixNoAxisPts5.NumberOfAxisPointsSet {
+	} else {
 		if cv.noAxisPts5Value <= 0 {
-			err = errors.New("number of axisPts is smaller or equal to zero")
+			err := errors.New("number of axisPts is smaller or equal to zero")
 			log.Err(err).Msg("could not retrieve NoAxisPoints5 value")
 			return nil, err
 		}
 		noAxisPts = cv.noAxisPts5Value
-	} else {
-		err = errors.New("number of axis points could not be determined")
-		log.Err(err).Msg("could not convert axisPoints5 values")
+	}
+	val, addrs, err := cd.readReservedAxisPoints(rl, curPos, rl.AxisPts5.Datatype, rl.AxisPts5.Addressing, noAxisPts, cv.getMaxAxisPts(4), cv.getByteOrder(cd, 4))
+	if err != nil {
+		log.Err(err).Msg("could not retrieve axisPoints5 values")
 		return nil, err
 	}
+	if rl.AxisPts5.IndexIncr == a2l.IndexDecr {
+		reverseSlice(val)
+		reverseSlice(addrs)
+	}
+	cv.axisAddresses[4] = addrs
+	return val, nil
+}
+
+// readAxisPoints reads noAxisPts consecutive axis points of the given datatype and byte order starting at curPos.
+// for the indirect address types PBYTE, PWORD, PLONG and PLONGLONG curPos holds a pointer to the first axis point instead.
+// the address of each axis point is returned as well, so the axis points can be written back to the image they have been read from.
+func (cd *CalibrationData) readAxisPoints(rl *a2l.RecordLayout, curPos *uint32, dte a2l.DataTypeEnum, at a2l.AddrTypeEnum, noAxisPts int64, bo a2l.ByteOrderEnum) ([]float64, []uint32, error) {
+	switch at {
+	case a2l.PBYTE, a2l.PWORD, a2l.PLONG, a2l.PLONGLONG:
+		target, err := cd.getPointerTarget(curPos, at, rl, bo)
+		if err != nil {
+			log.Err(err).Msg("could not dereference axis points")
+			return nil, nil, err
+		}
+		return cd.readAxisPoints(rl, &target, dte, a2l.DIRECT, noAxisPts, bo)
+	case a2l.DIRECT, "":
+	default:
+		err := errors.New("invalid address type " + string(at) + " for axis points")
+		log.Err(err).Msg("could not retrieve axis points")
+		return nil, nil, err
+	}
+	val := make([]float64, 0, noAxisPts)
+	addrs := make([]uint32, 0, noAxisPts)
+	var i int64
 	for i = 0; i < noAxisPts; i++ {
-		bufByte, err = cd.getValue(curPos, rl.AxisPts5.Datatype, rl)
+		bufByte, err := cd.getValue(curPos, dte, rl)
 		if err != nil {
-			log.Err(err).Msg("could not retrieve axisPoints5 value")
-			return nil, err
+			log.Err(err).Msg("could not retrieve axis point value")
+			return nil, nil, err
 		}
-		bufFloat, err = cd.convertByteSliceToDatatype(bufByte, rl.AxisPts5.Datatype)
+		bufFloat, err := decodeDatatype(bufByte, dte, bo)
 		if err != nil {
-			log.Err(err).Msg("could not retrieve axisPoints5 value")
-			return nil, err
+			log.Err(err).Msg("could not convert axis point value")
+			return nil, nil, err
 		}
 		val = append(val, bufFloat)
-		*curPos += uint32(rl.AxisPts5.Datatype.GetDatatypeLength())
+		addrs = append(addrs, *curPos)
+		*curPos += uint32(dte.GetDatatypeLength() / 8)
+	}
+	return val, addrs, nil
+}
+
+// reverseSlice reverses the order of the given values in place.
+// axis points that are stored with decreasing index in memory are reversed so that they are always returned in ascending index order.
+func reverseSlice[T any](vals []T) {
+	for i, j := 0, len(vals)-1; i < j; i, j = i+1, j-1 {
+		vals[i], vals[j] = vals[j], vals[i]
 	}
-	return val, err
 }
@@ -0,0 +1,168 @@
+package calibrationReader
+
+import (
+	"errors"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
+)
+
+// AxisPtsValues contains the axis points of an AXIS_PTS object as read from the hex file.
+// AXIS_PTS objects are shared between several characteristics with COM_AXIS or RES_AXIS axes
+// and are stored in their own deposit separated from the function values of the characteristics.
+type AxisPtsValues struct {
+	axisPts      *a2l.AxisPts
+	recordLayout *a2l.RecordLayout
+	//Name is the identifier of the AXIS_PTS object
+	Name string
+	//RawValues contains the decimal axis points in ascending index order
+	RawValues []float64
+	//PhyValues contains the axis points converted with the compu method of the AXIS_PTS object.
+	//either []float64 or []string for verbal conversions
+	PhyValues interface{}
+	//RescalePairs contains the rescale axis point value pairs of an AXIS_PTS object with AXIS_RESCALE_X in ascending index order
+	RescalePairs []RescalePair
+	//Padding contains the number of bytes inserted before each field of the record layout to align it
+	Padding             map[string]uint32
+	identificationValue interface{}
+	noAxisPtsXValue     int64
+	noRescaleXValue     int64
+}
+
+// GetAxisPtsValues reads the AXIS_PTS object with the given identifier from the hex file
+// and converts the axis points with the compu method defined within the AXIS_PTS object.
+func (cd *CalibrationData) GetAxisPtsValues(name string) (AxisPtsValues, error) {
+	av, err := cd.readAxisPtsValues(name)
+	if err != nil {
+		log.Err(err).Msg("could not get axis points values")
+		return AxisPtsValues{}, err
+	}
+	av.PhyValues, err = cd.convertValuesToPhysical(av.RawValues, av.axisPts.Conversion, av.recordLayout.AxisPtsX.Datatype)
+	if err != nil {
+		log.Err(err).Msg("could not convert axis points of '" + name + "'")
+		return *av, err
+	}
+	return *av, nil
+}
+
+// readAxisPtsValues reads the raw axis points of an AXIS_PTS object.
+// the fields of its record layout are read in the order of their position.
+func (cd *CalibrationData) readAxisPtsValues(name string) (*AxisPtsValues, error) {
+	module := cd.A2l.Project.Modules[cd.ModuleIndex]
+	ap, exists := module.AxisPts[name]
+	if !exists {
+		err := errors.New("axis points " + name + " not found")
+		log.Err(err).Msg("could not read axis points values")
+		return nil, err
+	}
+	rl, exists := module.RecordLayouts[ap.DepositIdent]
+	if !exists {
+		err := errors.New("no record layout found for deposit identifier " + ap.DepositIdent + " of axis points " + name)
+		log.Err(err).Msg("record layout not found")
+		return nil, err
+	}
+	av := &AxisPtsValues{axisPts: &ap, recordLayout: &rl, Name: name}
+
+	relPos, err := rl.GetRecordLayoutRelativePositions()
+	if err != nil {
+		log.Err(err).Msg("could not retrieve positions for record layout '" + ap.DepositIdent + "'")
+		return nil, err
+	}
+	rl.RelativePositions = relPos
+	positions := sortedPositions(relPos)
+
+	cd, curPos, limit, err := cd.locate(ap.Address, ap.EcuAddressExtension.Extension)
+	if err != nil {
+		log.Err(err).Msg("could not resolve address of axis points '" + name + "'")
+		return nil, err
+	}
+	begin := curPos
+
+	av.Padding = make(map[string]uint32, len(positions))
+	for _, p := range positions {
+		field := rl.RelativePositions[uint16(p)]
+		av.Padding[field] = cd.getFieldPadding(curPos, field, &rl)
+		switch field {
+		case "AxisPtsX":
+			av.RawValues, err = av.getAxisPointsX(cd, &rl, &curPos)
+			if err != nil {
+				log.Err(err).Msg("could not get values for axis points '" + name + "'")
+				return nil, err
+			}
+		case "AxisRescaleX":
+			av.RescalePairs, err = cd.getAxisRescaleX(&rl, &curPos, getNoRescaleXArg(&rl, av.noRescaleXValue), av.getByteOrder(cd))
+			if err != nil {
+				log.Err(err).Msg("could not get rescale pairs of axis points '" + name + "'")
+				return nil, err
+			}
+		case "Identification":
+			av.identificationValue, err = cd.getIdentification(&rl, &curPos)
+			if err != nil {
+				log.Err(err).Msg("could not get value for identification of axis points '" + name + "'")
+				return nil, err
+			}
+		case "NoAxisPtsX":
+			av.noAxisPtsXValue, err = cd.getNoAxisPtsX(&rl, &curPos, av.getByteOrder(cd))
+			if err != nil {
+				log.Err(err).Msg("could not get value for noAxisPtsX of axis points '" + name + "'")
+				return nil, err
+			}
+		case "NoRescaleX":
+			av.noRescaleXValue, err = cd.getNoRescaleX(&rl, &curPos, av.getByteOrder(cd))
+			if err != nil {
+				log.Err(err).Msg("could not get value for noRescaleX of axis points '" + name + "'")
+				return nil, err
+			}
+		case "Reserved":
+			err = cd.getReserved(&rl, &curPos)
+			if err != nil {
+				log.Err(err).Msg("could not get offset from reserved datasize of axis points '" + name + "'")
+				return nil, err
+			}
+		case "RipAddrX", "SrcAddrX":
+			//see getValuesFromHex, there is nothing to read
+		default:
+			err = errors.New("undefined case in record layout position")
+			log.Err(err).Msg("unexpected case '" + field + "' in axis points '" + name + "'")
+			return nil, err
+		}
+	}
+	err = cd.checkExtent(name, begin, curPos, limit)
+	if err != nil {
+		log.Err(err).Msg("could not read axis points '" + name + "'")
+		return nil, err
+	}
+	if len(av.RawValues) == 0 && len(av.RescalePairs) > 0 {
+		//a pure rescale axis has no axis points of its own. its axis points are those of the rescale pairs.
+		for _, p := range av.RescalePairs {
+			av.RawValues = append(av.RawValues, p.Axis)
+		}
+	}
+	return av, nil
+}
+
+// getAxisPointsX retrieves the axis points of an AXIS_PTS object.
+// the number of axis points is taken from NO_AXIS_PTS_X, FIX_NO_AXIS_PTS_X or the maximum number of axis points in that order.
+func (av *AxisPtsValues) getAxisPointsX(cd *CalibrationData, rl *a2l.RecordLayout, curPos *uint32) ([]float64, error) {
+	var noAxisPts int64
+	if av.noAxisPtsXValue > 0 {
+		noAxisPts = av.noAxisPtsXValue
+	} else if rl.FixNoAxisPtsX.NumberOfAxisPointsSet {
+		noAxisPts = int64(rl.FixNoAxisPtsX.NumberOfAxisPoints)
+	} else if av.axisPts.MaxAxisPointsSet {
+		noAxisPts = int64(av.axisPts.MaxAxisPoints)
+	} else {
+		err := errors.New("number of axis points could not be determined")
+		log.Err(err).Msg("could not retrieve axis points of '" + av.Name + "'")
+		return nil, err
+	}
+	val, _, err := cd.readReservedAxisPoints(rl, curPos, rl.AxisPtsX.Datatype, rl.AxisPtsX.Addressing, noAxisPts, int64(av.axisPts.MaxAxisPoints), av.getByteOrder(cd))
+	if err != nil {
+		log.Err(err).Msg("could not retrieve axis points of '" + av.Name + "'")
+		return nil, err
+	}
+	if rl.AxisPtsX.IndexIncr == a2l.IndexDecr {
+		reverseSlice(val)
+	}
+	return val, nil
+}
@@ -0,0 +1,118 @@
+package calibrationReader
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
+)
+
+// RescalePair maps an axis point onto the virtual axis that is used to access the function values of a rescale axis.
+// see a2l.AxisRescaleX for a description of the rescale mapping.
+type RescalePair struct {
+	Axis    float64
+	Virtual float64
+}
+
+// getAxisRescaleX retrieves the rescale axis point value pairs according to their layout specified within the record layout
+// and their values as calibrated in the hex file.
+// the number of pairs is given by NO_RESCALE_X and must not exceed the maximum number of rescale pairs.
+// noRescaleX is -1 if the record layout does not define NO_RESCALE_X, in which case the maximum number of rescale pairs is read.
+// each pair is stored as axis point followed by its virtual axis point.
+// the pairs are returned in ascending index order.
+func (cd *CalibrationData) getAxisRescaleX(rl *a2l.RecordLayout, curPos *uint32, noRescaleX int64, bo a2l.ByteOrderEnum) ([]RescalePair, error) {
+	if !rl.AxisRescaleX.DatatypeSet {
+		err := errors.New("axisRescaleX datatype not set")
+		log.Err(err).Msg("could not retrieve axisRescaleX values")
+		return nil, err
+	}
+	noPairs := noRescaleX
+	if noPairs < 0 {
+		noPairs = int64(rl.AxisRescaleX.MaxNumberOfRescalePairs)
+	}
+	if noPairs <= 0 {
+		err := errors.New("number of rescale pairs " + strconv.FormatInt(noPairs, 10) + " is too small")
+		log.Err(err).Msg("could not retrieve axisRescaleX values")
+		return nil, err
+	}
+	if rl.AxisRescaleX.MaxNumberOfRescalePairsSet && noPairs > int64(rl.AxisRescaleX.MaxNumberOfRescalePairs) {
+		err := errors.New("number of rescale pairs " + strconv.FormatInt(noPairs, 10) + " exceeds maximum of " + strconv.Itoa(int(rl.AxisRescaleX.MaxNumberOfRescalePairs)))
+		log.Err(err).Msg("could not retrieve axisRescaleX values")
+		return nil, err
+	}
+	val, _, err := cd.readReservedAxisPoints(rl, curPos, rl.AxisRescaleX.Datatype, rl.AxisRescaleX.Adressing, 2*noPairs, 2*int64(rl.AxisRescaleX.MaxNumberOfRescalePairs), bo)
+	if err != nil {
+		log.Err(err).Msg("could not retrieve axisRescaleX values")
+		return nil, err
+	}
+	pairs := make([]RescalePair, 0, noPairs)
+	for i := 0; i+1 < len(val); i += 2 {
+		pairs = append(pairs, RescalePair{Axis: val[i], Virtual: val[i+1]})
+	}
+	if rl.AxisRescaleX.IndexIncr == a2l.IndexDecr {
+		for i, j := 0, len(pairs)-1; i < j; i, j = i+1, j-1 {
+			pairs[i], pairs[j] = pairs[j], pairs[i]
+		}
+	}
+	return pairs, nil
+}
+
+// getNoRescaleXArg returns the number of rescale pairs read from NO_RESCALE_X
+// or -1 if the record layout does not define NO_RESCALE_X.
+func getNoRescaleXArg(rl *a2l.RecordLayout, noRescaleX int64) int64 {
+	if !rl.NoRescaleX.PositionSet {
+		return -1
+	}
+	return noRescaleX
+}
+
+// computeRescaleAxisPoints derives noAxisPts axis points from the rescale pairs.
+// the virtual axis points are distributed equidistantly with the distance
+// D = (virtual_last - virtual_first + 1) / (noAxisPts - 1)
+// and mapped linearly onto the axis between the two rescale pairs enclosing them.
+// the last axis point is always the axis point of the last rescale pair.
+func computeRescaleAxisPoints(pairs []RescalePair, noAxisPts int) ([]float64, error) {
+	if len(pairs) < 2 {
+		err := errors.New("at least two rescale pairs are necessary to compute a rescale axis")
+		log.Err(err).Msg("could not compute rescale axis points")
+		return nil, err
+	}
+	if noAxisPts < 2 {
+		err := errors.New("a rescale axis needs at least two axis points")
+		log.Err(err).Msg("could not compute rescale axis points")
+		return nil, err
+	}
+	for i := 1; i < len(pairs); i++ {
+		if pairs[i].Virtual <= pairs[i-1].Virtual || pairs[i].Axis < pairs[i-1].Axis {
+			err := errors.New("rescale pairs are not in ascending order")
+			log.Err(err).Msg("could not compute rescale axis points")
+			return nil, err
+		}
+	}
+	first := pairs[0].Virtual
+	last := pairs[len(pairs)-1].Virtual
+	d := (last - first + 1) / float64(noAxisPts-1)
+	val := make([]float64, 0, noAxisPts)
+	seg := 0
+	for k := 0; k < noAxisPts-1; k++ {
+		v := first + float64(k)*d
+		for seg < len(pairs)-2 && v >= pairs[seg+1].Virtual {
+			seg++
+		}
+		p, q := pairs[seg], pairs[seg+1]
+		val = append(val, p.Axis+(v-p.Virtual)*(q.Axis-p.Axis)/(q.Virtual-p.Virtual))
+	}
+	val = append(val, pairs[len(pairs)-1].Axis)
+	return val, nil
+}
+
+// getNoRescaleAxisPts returns the number of axis points of the rescale axis with the given index.
+// it is defined by the maximum number of axis points of the axis description.
+// if that is missing the axis has one axis point per rescale pair.
+func (cv *CharacteristicValues) getNoRescaleAxisPts(axisIndex int, noPairs int) int {
+	if n := cv.getMaxAxisPts(axisIndex); n > 0 {
+		return int(n)
+	}
+	return noPairs
+}
@@ -0,0 +1,63 @@
+package calibrationReader
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestRescaleAxis(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	av, err := cd.GetAxisPtsValues("ASAM.C.AXIS_PTS.RESCALE")
+	if err != nil {
+		t.Fatalf("could not get rescale axis points: %s", err)
+	}
+	if fmt.Sprint(av.RescalePairs) != "[{17 32} {20 64} {32 128} {176 208} {210 255}]" {
+		t.Errorf("unexpected rescale pairs %v", av.RescalePairs)
+	}
+	cv, err := cd.GetCharacteristicValues("ASAM.C.CURVE.RES_AXIS")
+	if err != nil {
+		t.Fatalf("could not get values of rescale curve: %s", err)
+	}
+	if len(cv.AxisXRawValues) != 9 || cv.AxisXRawValues[0] != 17 || cv.AxisXRawValues[8] != 210 {
+		t.Errorf("unexpected rescale axis %v", cv.AxisXRawValues)
+	}
+	if fmt.Sprint(cv.RawValues) != "[3 4 5 6 7 9 10 11 12]" {
+		t.Errorf("unexpected values %v", cv.RawValues)
+	}
+
+	//example of a2l.AxisRescaleX. the standard approximates X8 with 116/64 as slope, exactly it is 116/63
+	pairs := []RescalePair{{Axis: 0, Virtual: 0}, {Axis: 100, Virtual: 192}, {Axis: 216, Virtual: 255}}
+	val, err := computeRescaleAxisPoints(pairs, 9)
+	if err != nil {
+		t.Fatalf("could not compute rescale axis: %s", err)
+	}
+	expected := []float64{0, 16.666, 33.333, 50, 66.666, 83.333, 100, 158.92, 216}
+	for i := range expected {
+		if math.Abs(val[i]-expected[i]) > 0.01 {
+			t.Errorf("axis point %d: expected %f, got %f", i, expected[i], val[i])
+		}
+	}
+	if _, err = computeRescaleAxisPoints(pairs[:1], 9); err == nil {
+		t.Errorf("a single rescale pair must not define a rescale axis")
+	}
+
+	//without NO_RESCALE_X the maximum number of rescale pairs is read
+	rl := cd.A2l.Project.Modules[cd.ModuleIndex].RecordLayouts["RL.AXIS_PTS.RES_AXIS"]
+	rl.NoRescaleX.PositionSet = false
+	curPos := uint32(0x8103D2)
+	rp, err := cd.getAxisRescaleX(&rl, &curPos, getNoRescaleXArg(&rl, 0), a2l.LittleEndian)
+	if err != nil || len(rp) != int(rl.AxisRescaleX.MaxNumberOfRescalePairs) {
+		t.Errorf("expected %d rescale pairs, got %v, %v", rl.AxisRescaleX.MaxNumberOfRescalePairs, rp, err)
+	}
+	//a NO_RESCALE_X of zero read from the deposit is rejected
+	cd.Hex[0x8103D0] = 0
+	if av, err = cd.GetAxisPtsValues("ASAM.C.AXIS_PTS.RESCALE"); err == nil {
+		t.Errorf("expected error for zero rescale pairs, got %v", av.RescalePairs)
+	}
+}
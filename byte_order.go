@@ -0,0 +1,58 @@
+package calibrationReader
+
+import (
+	"errors"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
+)
+
+// resolveByteOrder returns the first byte order that is set within the given hierarchy of BYTE_ORDER keywords.
+// the keywords are expected in descending precedence, e.g. object before axis description.
+// if none of them is set the byte order of MOD_COMMON is used and big endian if that is missing as well.
+func (cd *CalibrationData) resolveByteOrder(orders ...a2l.ByteOrder) a2l.ByteOrderEnum {
+	for _, o := range orders {
+		if o.ByteOrderSet {
+			return o.ByteOrder
+		}
+	}
+	modCom := &cd.A2l.Project.Modules[cd.ModuleIndex].ModCommon
+	if modCom.ByteOrder.ByteOrderSet {
+		return modCom.ByteOrder.ByteOrder
+	}
+	return a2l.BigEndian
+}
+
+// getByteOrder returns the byte order of the values belonging to the axis with the given index (0 = x ... 4 = 5)
+// or of the function values and all other fields not belonging to an axis for a negative index.
+// the byte order of the characteristic takes precedence over the byte order of the axis description.
+func (cv *CharacteristicValues) getByteOrder(cd *CalibrationData, axisIndex int) a2l.ByteOrderEnum {
+	if axisIndex >= 0 && axisIndex < len(cv.characteristic.AxisDescr) {
+		return cd.resolveByteOrder(cv.characteristic.ByteOrder, cv.characteristic.AxisDescr[axisIndex].ByteOrder)
+	}
+	return cd.resolveByteOrder(cv.characteristic.ByteOrder)
+}
+
+// getByteOrder returns the byte order of the values of an AXIS_PTS object.
+func (av *AxisPtsValues) getByteOrder(cd *CalibrationData) a2l.ByteOrderEnum {
+	return cd.resolveByteOrder(av.axisPts.ByteOrder)
+}
+
+// GetByteOrder returns the byte order the values of the characteristic, axis points or measurement with the given identifier are stored with.
+// the BYTE_ORDER of the object takes precedence over the one defined in MOD_COMMON.
+// axis points of characteristics may deviate as the BYTE_ORDER of their axis description is considered as well.
+func (cd *CalibrationData) GetByteOrder(ident string) (a2l.ByteOrderEnum, error) {
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	if c, exists := m.Characteristics[ident]; exists {
+		return cd.resolveByteOrder(c.ByteOrder), nil
+	}
+	if ap, exists := m.AxisPts[ident]; exists {
+		return cd.resolveByteOrder(ap.ByteOrder), nil
+	}
+	if me, exists := m.Measurements[ident]; exists {
+		return cd.resolveByteOrder(me.ByteOrder), nil
+	}
+	err := errors.New("no characteristic, axis points or measurement with name " + ident)
+	log.Err(err).Msg("could not determine byte order")
+	return "", err
+}
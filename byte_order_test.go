@@ -0,0 +1,111 @@
+package calibrationReader
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestByteOrder(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	tests := []struct {
+		dte   a2l.DataTypeEnum
+		bo    a2l.ByteOrderEnum
+		bytes []byte
+		val   float64
+	}{
+		{dte: a2l.UWORD, bo: a2l.MsbFirst, bytes: []byte{0x11, 0x22}, val: 0x1122},
+		{dte: a2l.UWORD, bo: a2l.MsbLast, bytes: []byte{0x22, 0x11}, val: 0x1122},
+		{dte: a2l.UWORD, bo: a2l.MsbFirstMswLast, bytes: []byte{0x11, 0x22}, val: 0x1122},
+		{dte: a2l.UWORD, bo: a2l.MsbLastMswFirst, bytes: []byte{0x22, 0x11}, val: 0x1122},
+		{dte: a2l.ULONG, bo: a2l.BigEndian, bytes: []byte{0x11, 0x22, 0x33, 0x44}, val: 0x11223344},
+		{dte: a2l.ULONG, bo: a2l.LittleEndian, bytes: []byte{0x44, 0x33, 0x22, 0x11}, val: 0x11223344},
+		{dte: a2l.ULONG, bo: a2l.MsbFirstMswLast, bytes: []byte{0x33, 0x44, 0x11, 0x22}, val: 0x11223344},
+		{dte: a2l.ULONG, bo: a2l.MsbLastMswFirst, bytes: []byte{0x22, 0x11, 0x44, 0x33}, val: 0x11223344},
+		{dte: a2l.SLONG, bo: a2l.MsbFirstMswLast, bytes: []byte{0xFF, 0xFE, 0xFF, 0xFF}, val: -2},
+		{dte: a2l.SLONG, bo: a2l.MsbLastMswFirst, bytes: []byte{0xFF, 0xFF, 0xFE, 0xFF}, val: -2},
+		{dte: a2l.AUint64, bo: a2l.MsbFirstMswLast, bytes: []byte{0x00, 0x08, 0x00, 0x07, 0x00, 0x06, 0x00, 0x05}, val: 0x0005000600070008},
+		{dte: a2l.AUint64, bo: a2l.MsbLastMswFirst, bytes: []byte{0x05, 0x00, 0x06, 0x00, 0x07, 0x00, 0x08, 0x00}, val: 0x0005000600070008},
+		{dte: a2l.AInt64, bo: a2l.MsbFirstMswLast, bytes: []byte{0xFF, 0xFD, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, val: -3},
+		{dte: a2l.AInt64, bo: a2l.MsbLastMswFirst, bytes: []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFD, 0xFF}, val: -3},
+		//1.5 is 0x3FC00000 as FLOAT32_IEEE and 0x3FF8000000000000 as FLOAT64_IEEE
+		{dte: a2l.Float32Ieee, bo: a2l.MsbFirstMswLast, bytes: []byte{0x00, 0x00, 0x3F, 0xC0}, val: 1.5},
+		{dte: a2l.Float32Ieee, bo: a2l.MsbLastMswFirst, bytes: []byte{0xC0, 0x3F, 0x00, 0x00}, val: 1.5},
+		{dte: a2l.Float64Ieee, bo: a2l.MsbFirstMswLast, bytes: []byte{0, 0, 0, 0, 0, 0, 0x3F, 0xF8}, val: 1.5},
+		{dte: a2l.Float64Ieee, bo: a2l.MsbLastMswFirst, bytes: []byte{0xF8, 0x3F, 0, 0, 0, 0, 0, 0}, val: 1.5},
+		{dte: a2l.Float64Ieee, bo: a2l.LittleEndian, bytes: []byte{0, 0, 0, 0, 0, 0, 0xF8, 0x3F}, val: 1.5},
+	}
+	for _, tt := range tests {
+		val, err := decodeDatatype(tt.bytes, tt.dte, tt.bo)
+		if err != nil {
+			t.Errorf("%s %s: could not decode: %s", tt.dte.String(), tt.bo.String(), err)
+		} else if val != tt.val {
+			t.Errorf("%s %s: expected %v, got %v", tt.dte.String(), tt.bo.String(), tt.val, val)
+		}
+		b, err := encodeDatatype(tt.val, tt.dte, tt.bo)
+		if err != nil {
+			t.Errorf("%s %s: could not encode: %s", tt.dte.String(), tt.bo.String(), err)
+		} else if fmt.Sprintf("% x", b) != fmt.Sprintf("% x", tt.bytes) {
+			t.Errorf("%s %s: expected bytes % x, got % x", tt.dte.String(), tt.bo.String(), tt.bytes, b)
+		}
+	}
+	if _, err := encodeDatatype(70000, a2l.UWORD, a2l.MsbFirst); err == nil {
+		t.Errorf("value exceeding the range of UWORD must not be encoded")
+	}
+}
+
+func TestByteOrderHierarchy(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	if bo, _ := cd.GetByteOrder("ASAM.C.CURVE.STD_AXIS"); bo != a2l.MsbLast {
+		t.Errorf("expected byte order of MOD_COMMON, got %s", bo.String())
+	}
+	rl := a2l.RecordLayout{Name: "RL.TEST.BYTE_ORDER", NameSet: true}
+	rl.NoAxisPtsX = a2l.NoAxisPtsX{Position: 1, PositionSet: true, Datatype: a2l.UWORD, DatatypeSet: true}
+	rl.AxisPtsX = a2l.AxisPtsX{Position: 2, PositionSet: true, Datatype: a2l.UWORD, DatatypeSet: true}
+	rl.FncValues = a2l.FncValues{Position: 3, PositionSet: true, Datatype: a2l.UWORD, DatatypeSet: true}
+	m.RecordLayouts[rl.Name] = rl
+	//MOD_COMMON is MSB_LAST. the axis is stored MSB_FIRST
+	data := []byte{0x00, 0x02, 0x01, 0x00, 0x02, 0x00, 0x03, 0x00, 0x04, 0x00}
+	for i, b := range data {
+		cd.Hex[0xF00000+uint32(i)] = b
+	}
+	c := m.Characteristics["ASAM.C.CURVE.STD_AXIS"]
+	c.Name = "TEST.CURVE.BYTE_ORDER"
+	c.Address = "0xF00000"
+	c.Deposit = rl.Name
+	c.Conversion = "NO_COMPU_METHOD"
+	c.AxisDescr = append([]a2l.AxisDescr{}, c.AxisDescr...)
+	c.AxisDescr[0].Conversion = "NO_COMPU_METHOD"
+	c.AxisDescr[0].ByteOrder = a2l.ByteOrder{ByteOrder: a2l.MsbFirst, ByteOrderSet: true}
+	m.Characteristics[c.Name] = c
+	cv, err := cd.GetCharacteristicValues(c.Name)
+	if err != nil {
+		t.Fatalf("could not get values: %s", err)
+	}
+	if fmt.Sprint(cv.AxisXRawValues) != "[256 512]" || fmt.Sprint(cv.RawValues) != "[3 4]" {
+		t.Errorf("unexpected axis %v or values %v", cv.AxisXRawValues, cv.RawValues)
+	}
+	//the byte order of the characteristic takes precedence over the one of the axis description
+	c.ByteOrder = a2l.ByteOrder{ByteOrder: a2l.MsbFirst, ByteOrderSet: true}
+	c.AxisDescr[0].ByteOrder = a2l.ByteOrder{ByteOrder: a2l.MsbLast, ByteOrderSet: true}
+	data = []byte{0x00, 0x02, 0x00, 0x01, 0x00, 0x02, 0x00, 0x03, 0x00, 0x04}
+	for i, b := range data {
+		cd.Hex[0xF00000+uint32(i)] = b
+	}
+	m.Characteristics[c.Name] = c
+	cv, err = cd.GetCharacteristicValues(c.Name)
+	if err != nil {
+		t.Fatalf("could not get values: %s", err)
+	}
+	if fmt.Sprint(cv.AxisXRawValues) != "[1 2]" || fmt.Sprint(cv.RawValues) != "[3 4]" {
+		t.Errorf("unexpected axis %v or values %v", cv.AxisXRawValues, cv.RawValues)
+	}
+	if bo, _ := cd.GetByteOrder(c.Name); bo != a2l.MsbFirst {
+		t.Errorf("expected byte order of characteristic, got %s", bo.String())
+	}
+}
@@ -1,9 +1,11 @@
-/*reads characteristics information from a2l and fills it with the data from a hex file. At least that is the plan.
-Currently it can parse a2l-files as well as the corresponding IntelHex32 or Motorola S19 files. And it is quite fast at that.
+/*reads characteristics information from a2l and fills it with the data from a hex file.
+It parses a2l-files as well as the corresponding IntelHex32 or Motorola S19 files. And it is quite fast at that.
 At the moment a real world A2L(80MB) with its corresponding Hex File(10MB) will be parsed in less than a second.
-But it still lacks the last bit of work which is implementing the methods for
-axis_pts, axis_descr, record_layout and fnc_values in order to understand the memory layout and position of a specific characteristic.
-This is somewhat of a convoluted mess in the a2l standard due to its historic growth and will be implemented when I have a little more spare time.
+GetCharacteristicValues and GetAxisPtsValues evaluate the record layouts, axis descriptions and function values
+to locate a calibration object within the hex file and return its decimal and physical values together with its axis points.
+The values can be validated against their limits (Validate), converted into other units (ConvertUnit)
+and changed with SetCharacteristicPhysical or SetCharacteristicRaw, which write to the memory image that can be saved
+with the writers of the ihex32 and srec19 packages.
 The only dependency outside the go standard library is currently zerolog.*/
 
 package calibrationReader
@@ -12,6 +14,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -38,6 +41,14 @@ type CalibrationData struct {
 	//it is being simplified as a map that can be accessed by an address
 	//represented by an integer and returns a byte as value.
 	Hex map[uint32]byte
+	//AddressSpaces contains the images of further address spaces of the ecu keyed by their ECU_ADDRESS_EXTENSION,
+	//e.g. for multi micro controller devices. Objects whose extension has no image of its own are read from Hex.
+	AddressSpaces map[int16]map[uint32]byte
+	//ApplyCalibrationOffset selects the variant data set given by the ECU_CALIBRATION_OFFSET of MOD_PAR.
+	//if set the offset is added to the addresses of all characteristics and axis points, otherwise only to near pointers.
+	ApplyCalibrationOffset bool
+	//primaryHex is the image Hex has been replaced with while reading an object from another address space.
+	primaryHex map[uint32]byte
 }
 
 // ReadCalibration takes filepaths to the a2l file and the hex file,
@@ -192,305 +203,27 @@ func (cd *CalibrationData) getRecordLayout(c *a2l.Characteristic) (*a2l.RecordLa
 }
 
 // getNextAlignedAddress takes an adress of a record layout field and its datatype as well as a reference to the record layout itself.
-// it computes whether the address given is aligned as defined by the alignemnts within the record layout for the given datatype.
-// if the record layout does not provide an alignement the alignment from MOD_COMMON is used.
-// if MOD_COMMON does not provide an alignment, then the default values (magic numbers below) are used.
+// it returns the next address that is aligned as defined by the alignments within the record layout for the given datatype.
+// if the record layout does not provide an alignment the alignment from MOD_COMMON is used.
+// if MOD_COMMON does not provide an alignment, then the size of the datatype is used (see getAlignment).
 func (cd *CalibrationData) getNextAlignedAddress(address uint32, dte a2l.DataTypeEnum, rl *a2l.RecordLayout) uint32 {
-	switch dte {
-	case a2l.UBYTE:
-		if rl.AlignmentByte.AlignmentBorderSet {
-			modulo := address % uint32(rl.AlignmentByte.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-		a := cd.A2l.Project.Modules[cd.ModuleIndex].ModCommon.AlignmentByte
-		if a.AlignmentBorderSet {
-			modulo := address % uint32(a.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		} else {
-			modulo := address % uint32(1)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-	case a2l.SBYTE:
-		if rl.AlignmentByte.AlignmentBorderSet {
-			modulo := address % uint32(rl.AlignmentByte.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-		a := cd.A2l.Project.Modules[cd.ModuleIndex].ModCommon.AlignmentByte
-		if a.AlignmentBorderSet {
-			modulo := address % uint32(a.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		} else {
-			modulo := address % uint32(1)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-	case a2l.UWORD:
-		if rl.AlignmentWord.AlignmentBorderSet {
-			modulo := address % uint32(rl.AlignmentWord.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-		a := cd.A2l.Project.Modules[cd.ModuleIndex].ModCommon.AlignmentWord
-		if a.AlignmentBorderSet {
-			modulo := address % uint32(a.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		} else {
-			modulo := address % uint32(2)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-	case a2l.SWORD:
-		if rl.AlignmentWord.AlignmentBorderSet {
-			modulo := address % uint32(rl.AlignmentWord.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-		a := cd.A2l.Project.Modules[cd.ModuleIndex].ModCommon.AlignmentWord
-		if a.AlignmentBorderSet {
-			modulo := address % uint32(a.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		} else {
-			modulo := address % uint32(2)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-	case a2l.ULONG:
-		if rl.AlignmentLong.AlignmentBorderSet {
-			modulo := address % uint32(rl.AlignmentLong.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-		a := cd.A2l.Project.Modules[cd.ModuleIndex].ModCommon.AlignmentLong
-		if a.AlignmentBorderSet {
-			modulo := address % uint32(a.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		} else {
-			modulo := address % uint32(4)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-	case a2l.SLONG:
-		if rl.AlignmentLong.AlignmentBorderSet {
-			modulo := address % uint32(rl.AlignmentLong.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-		a := cd.A2l.Project.Modules[cd.ModuleIndex].ModCommon.AlignmentLong
-		if a.AlignmentBorderSet {
-			modulo := address % uint32(a.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		} else {
-			modulo := address % uint32(4)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-	case a2l.AUint64:
-		if rl.AlignmentInt64.AlignmentBorderSet {
-			modulo := address % uint32(rl.AlignmentInt64.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-		a := cd.A2l.Project.Modules[cd.ModuleIndex].ModCommon.AlignmentInt64
-		if a.AlignmentBorderSet {
-			modulo := address % uint32(a.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		} else {
-			modulo := address % uint32(8)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-	case a2l.AInt64:
-		if rl.AlignmentInt64.AlignmentBorderSet {
-			modulo := address % uint32(rl.AlignmentInt64.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-		a := cd.A2l.Project.Modules[cd.ModuleIndex].ModCommon.AlignmentInt64
-		if a.AlignmentBorderSet {
-			modulo := address % uint32(a.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		} else {
-			modulo := address % uint32(8)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-	case a2l.Float16Ieee:
-		if rl.AlignmentFloat16Ieee.AlignmentBorderSet {
-			modulo := address % uint32(rl.AlignmentFloat16Ieee.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-		a := cd.A2l.Project.Modules[cd.ModuleIndex].ModCommon.AlignmentFloat16Ieee
-		if a.AlignmentBorderSet {
-			modulo := address % uint32(a.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		} else {
-			modulo := address % uint32(2)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-	case a2l.Float32Ieee:
-		if rl.AlignmentFloat32Ieee.AlignmentBorderSet {
-			modulo := address % uint32(rl.AlignmentFloat32Ieee.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-		a := cd.A2l.Project.Modules[cd.ModuleIndex].ModCommon.AlignmentFloat32Ieee
-		if a.AlignmentBorderSet {
-			modulo := address % uint32(a.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		} else {
-			modulo := address % uint32(4)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-	case a2l.Float64Ieee:
-		if rl.AlignmentFloat64Ieee.AlignmentBorderSet {
-			modulo := address % uint32(rl.AlignmentFloat64Ieee.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-		a := cd.A2l.Project.Modules[cd.ModuleIndex].ModCommon.AlignmentFloat64Ieee
-		if a.AlignmentBorderSet {
-			modulo := address % uint32(a.AlignmentBorder)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		} else {
-			modulo := address % uint32(8)
-			if modulo != 0 {
-				return address + modulo
-			} else {
-				return address
-			}
-		}
-	default:
-		return address
-	}
+	return alignAddress(address, cd.getAlignment(dte, rl))
 }
 
 // getBytes gets a number of bytes (length) from a given address and returns a byte slice
 // if the address cannot be found an error is returned
 func (cd *CalibrationData) getBytes(address uint32, length uint32) ([]byte, error) {
-	nBytes := length / 8
-	bytes := make([]byte, nBytes, 0)
 	if length%8 != 0 {
 		err := errors.New("unexpected number of bits " + strconv.Itoa(int(length)) + ". Expected a multiple of 8.")
 		log.Error().Err(err).Msg("invalid length")
-		return bytes, err
+		return nil, err
 	}
+	nBytes := length / 8
+	bytes := make([]byte, 0, nBytes)
 	for i := 0; i < int(nBytes); i++ {
 		b, exists := cd.Hex[address+uint32(i)]
 		if !exists {
-			err := errors.New("address " + strconv.Itoa(int(address+uint32(i))) + " not found in hex")
+			err := errors.New("address " + fmt.Sprintf("0x%X", address+uint32(i)) + " not found in hex")
 			log.Error().Err(err).Msg("invalid address")
 			return bytes, err
 		}
@@ -500,164 +233,236 @@ func (cd *CalibrationData) getBytes(address uint32, length uint32) ([]byte, erro
 }
 
 // getValuesFromHex Reads all values for ONE specific characteristic and its corresponding record layout from the hex file and converts it to decimal, and physical values.
-func getValuesFromHex(cv *CharacteristicValues, cd *CalibrationData) {
-	rl, err := cd.getRecordLayout(cv.characteristic)
-	if err != nil {
-		log.Err(err).Msg("record layout for identifier '" + cv.characteristic.Deposit + "' not found")
+// the fields of the record layout are read in the order of their position as the address of each field depends on the size of its predecessors.
+func getValuesFromHex(cv *CharacteristicValues, cd *CalibrationData) error {
+	rl := cv.recordLayout
+	if rl == nil {
+		var err error
+		rl, err = cd.getRecordLayout(cv.characteristic)
+		if err != nil {
+			log.Err(err).Msg("record layout for identifier '" + cv.characteristic.Deposit + "' not found")
+			return err
+		}
+		cv.recordLayout = rl
 	}
 
 	//determine relative positions of each field of the record layout struct
 	relPos, err := rl.GetRecordLayoutRelativePositions()
 	if err != nil {
 		log.Err(err).Msg("could not retrieve positions for record layout '" + cv.characteristic.Deposit + "'")
+		return err
 	}
 	rl.RelativePositions = relPos
+	positions := sortedPositions(relPos)
 
 	//curPos tracks the current position within the deposit structure as an uint32 address
 	//with each field that gets parsed from the hex file curPos is incremented by the length of the datastructure.
 	var curPos uint32
+	var limit uint64
 	//determine the start address of the characteristic
-	curPos, err = cd.convertStringToUint32Address(cv.characteristic.Address)
+	cd, curPos, limit, err = cd.locate(cv.characteristic.Address, cv.characteristic.EcuAddressExtension.Extension)
 	if err != nil {
-		log.Err(err).Msg("could not convert address of characteristic '" + cv.characteristic.Name + "'")
+		log.Err(err).Msg("could not resolve address of characteristic '" + cv.characteristic.Name + "'")
+		return err
 	}
+	begin := curPos
+	cv.image = cd.Hex
 
-	for _, field := range cv.recordLayout.RelativePositions {
+	//ALTERNATE_WITH_X and ALTERNATE_WITH_Y store the axis points interleaved with the function values,
+	//so both fields are read at once when the first of them is reached.
+	altAxis, altField, altDone := -1, "", false
+	switch rl.FncValues.IndexMode {
+	case a2l.AlternateWithX:
+		altAxis, altField = 0, "AxisPtsX"
+	case a2l.AlternateWithY:
+		altAxis, altField = 1, "AxisPtsY"
+	}
+
+	cv.Padding = make(map[string]uint32, len(positions))
+	cv.fields = make([]RecordLayoutField, 0, len(positions))
+	for _, p := range positions {
+		field := rl.RelativePositions[uint16(p)]
+		padding := cd.getFieldPadding(curPos, field, rl)
+		cv.Padding[field] = padding
+		start := curPos + padding
+		if altAxis >= 0 && (field == altField || field == "FncValues") {
+			if !altDone {
+				axis, _ := cv.getAxisValuesRef(altAxis)
+				*axis, cv.fncValues, err = cv.getAlternatingValues(cd, rl, &curPos, altAxis, field == altField)
+				if err != nil {
+					log.Err(err).Msg("could not get alternating values of characteristic '" + cv.characteristic.Name + "'")
+					return err
+				}
+				altDone = true
+				cv.fields = append(cv.fields, cv.newRecordLayoutField(rl, field, uint16(p), start, curPos, padding))
+			} else {
+				//the interleaved memory area is shared by the axis points and the function values
+				shared := cv.fields[len(cv.fields)-1]
+				cv.fields = append(cv.fields, cv.newRecordLayoutField(rl, field, uint16(p), shared.Address, shared.Address+shared.Length, 0))
+			}
+			continue
+		}
 		switch field {
 		case "AxisPtsX":
-			cv.AxisXValues, err = cv.getAxisPointsX(cd, rl, &curPos)
+			cv.AxisXRawValues, err = cv.getAxisPointsX(cd, rl, &curPos)
 			if err != nil {
 				log.Err(err).Msg("could not get values for X-Axis of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "AxisPtsY":
-			cv.AxisYValues, err = cv.getAxisPointsY(cd, rl, &curPos)
+			cv.AxisYRawValues, err = cv.getAxisPointsY(cd, rl, &curPos)
 			if err != nil {
 				log.Err(err).Msg("could not get values for Y-Axis of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "AxisPtsZ":
-			cv.AxisZValues, err = cv.getAxisPointsZ(cd, rl, &curPos)
+			cv.AxisZRawValues, err = cv.getAxisPointsZ(cd, rl, &curPos)
 			if err != nil {
 				log.Err(err).Msg("could not get values for Z-Axis of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "AxisPts4":
-			cv.Axis4Values, err = cv.getAxisPoints4(cd, rl, &curPos)
+			cv.Axis4RawValues, err = cv.getAxisPoints4(cd, rl, &curPos)
 			if err != nil {
 				log.Err(err).Msg("could not get values for 4-Axis of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "AxisPts5":
-			cv.Axis5Values, err = cv.getAxisPoints5(cd, rl, &curPos)
+			cv.Axis5RawValues, err = cv.getAxisPoints5(cd, rl, &curPos)
 			if err != nil {
 				log.Err(err).Msg("could not get values for 5-Axis of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "AxisRescaleX":
-			//To-Do?
-			err = errors.New("undefined case in record layout")
-			log.Err(err).Msg("axisRescaleX not implemented")
+			//the x axis is a rescale axis stored within the deposit of the characteristic.
+			cv.rescalePairsX, err = cd.getAxisRescaleX(rl, &curPos, getNoRescaleXArg(rl, cv.noRescaleXValue), cv.getByteOrder(cd, 0))
+			if err != nil {
+				log.Err(err).Msg("could not get rescale pairs of characteristic '" + cv.characteristic.Name + "'")
+				return err
+			}
+			cv.AxisXRawValues, err = computeRescaleAxisPoints(cv.rescalePairsX, cv.getNoRescaleAxisPts(0, len(cv.rescalePairsX)))
+			if err != nil {
+				log.Err(err).Msg("could not compute rescale axis of characteristic '" + cv.characteristic.Name + "'")
+				return err
+			}
 		case "DistOpX":
-			cv.distOpXValue, err = cd.getDistOpX(rl, &curPos)
+			cv.distOpXValue, err = cd.getDistOpX(rl, &curPos, cv.getByteOrder(cd, 0))
 			if err != nil {
 				log.Err(err).Msg("could not get value for distOpX of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "DistOpY":
-			cv.distOpYValue, err = cd.getDistOpY(rl, &curPos)
+			cv.distOpYValue, err = cd.getDistOpY(rl, &curPos, cv.getByteOrder(cd, 1))
 			if err != nil {
 				log.Err(err).Msg("could not get value for distOpY of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "DistOpZ":
-			cv.distOpZValue, err = cd.getDistOpZ(rl, &curPos)
+			cv.distOpZValue, err = cd.getDistOpZ(rl, &curPos, cv.getByteOrder(cd, 2))
 			if err != nil {
 				log.Err(err).Msg("could not get value for distOpZ of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "DistOp4":
-			cv.distOp4Value, err = cd.getDistOp4(rl, &curPos)
+			cv.distOp4Value, err = cd.getDistOp4(rl, &curPos, cv.getByteOrder(cd, 3))
 			if err != nil {
 				log.Err(err).Msg("could not get value for distOp4 of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "DistOp5":
-			cv.distOp5Value, err = cd.getDistOp5(rl, &curPos)
+			cv.distOp5Value, err = cd.getDistOp5(rl, &curPos, cv.getByteOrder(cd, 4))
 			if err != nil {
 				log.Err(err).Msg("could not get value for distOp5 of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "FncValues":
-			cv.fncValues, err = cd.getFncValues(rl, &curPos, cv)
+			cv.fncValues, err = cv.getFncValues(cd, rl, &curPos)
 			if err != nil {
 				log.Err(err).Msg("could not get value for fncValues of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "Identification":
 			cv.identificationValue, err = cd.getIdentification(rl, &curPos)
 			if err != nil {
 				log.Err(err).Msg("could not get value for identification of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "NoAxisPtsX":
-			cv.noAxisPtsXValue, err = cd.getNoAxisPtsX(rl, &curPos)
+			cv.noAxisPtsXValue, err = cd.getNoAxisPtsX(rl, &curPos, cv.getByteOrder(cd, 0))
 			if err != nil {
 				log.Err(err).Msg("could not get value for noAxisPtsX of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "NoAxisPtsY":
-			cv.noAxisPtsYValue, err = cd.getNoAxisPtsY(rl, &curPos)
+			cv.noAxisPtsYValue, err = cd.getNoAxisPtsY(rl, &curPos, cv.getByteOrder(cd, 1))
 			if err != nil {
 				log.Err(err).Msg("could not get value for noAxisPtsY of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "NoAxisPtsZ":
-			cv.noAxisPtsZValue, err = cd.getNoAxisPtsZ(rl, &curPos)
+			cv.noAxisPtsZValue, err = cd.getNoAxisPtsZ(rl, &curPos, cv.getByteOrder(cd, 2))
 			if err != nil {
 				log.Err(err).Msg("could not get value for noAxisPtsZ of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "NoAxisPts4":
-			cv.noAxisPts4Value, err = cd.getNoAxisPts4(rl, &curPos)
+			cv.noAxisPts4Value, err = cd.getNoAxisPts4(rl, &curPos, cv.getByteOrder(cd, 3))
 			if err != nil {
 				log.Err(err).Msg("could not get value for noAxisPts4 of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "NoAxisPts5":
-			cv.noAxisPts5Value, err = cd.getNoAxisPts5(rl, &curPos)
+			cv.noAxisPts5Value, err = cd.getNoAxisPts5(rl, &curPos, cv.getByteOrder(cd, 4))
 			if err != nil {
 				log.Err(err).Msg("could not get value for noAxisPts5 of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "NoRescaleX":
-			cv.noRescaleXValue, err = cd.getNoRescaleX(rl, &curPos)
+			cv.noRescaleXValue, err = cd.getNoRescaleX(rl, &curPos, cv.getByteOrder(cd, 0))
 			if err != nil {
 				log.Err(err).Msg("could not get value for noRescaleX of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "OffsetX":
-			cv.offsetXValue, err = cd.getOffsetX(rl, &curPos)
+			cv.offsetXValue, err = cd.getOffsetX(rl, &curPos, cv.getByteOrder(cd, 0))
 			if err != nil {
 				log.Err(err).Msg("could not get value for offsetX of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "OffsetY":
-			cv.offsetYValue, err = cd.getOffsetY(rl, &curPos)
+			cv.offsetYValue, err = cd.getOffsetY(rl, &curPos, cv.getByteOrder(cd, 1))
 			if err != nil {
 				log.Err(err).Msg("could not get value for offsetY of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "OffsetZ":
-			cv.offsetZValue, err = cd.getOffsetZ(rl, &curPos)
+			cv.offsetZValue, err = cd.getOffsetZ(rl, &curPos, cv.getByteOrder(cd, 2))
 			if err != nil {
 				log.Err(err).Msg("could not get value for offsetZ of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "Offset4":
-			cv.offset4Value, err = cd.getOffset4(rl, &curPos)
+			cv.offset4Value, err = cd.getOffset4(rl, &curPos, cv.getByteOrder(cd, 3))
 			if err != nil {
 				log.Err(err).Msg("could not get value for offset4 of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "Offset5":
-			cv.offset5Value, err = cd.getOffset5(rl, &curPos)
+			cv.offset5Value, err = cd.getOffset5(rl, &curPos, cv.getByteOrder(cd, 4))
 			if err != nil {
 				log.Err(err).Msg("could not get value for offset5 of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "Reserved":
 			err = cd.getReserved(rl, &curPos)
 			if err != nil {
 				log.Err(err).Msg("could not get offset from reserved datasize characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		/*RIP_ADDR_X-Y-Z-4-5-W
 		are only used to store interpolation results,
-		so they are not read from hex as there is nothing to read
-		case "RipAddrW":
-		case "RipAddrX":
-		case "RipAddrY":
-		case "RipAddrZ":
-		case "RipAddr4":
-		case "RipAddr5":
-
+		so they are not read from hex as there is nothing to read*/
+		case "RipAddrW", "RipAddrX", "RipAddrY", "RipAddrZ", "RipAddr4", "RipAddr5":
 		/*SRC_ADDR_X-Y-Z-4-5
 		define a input quantity meaning a measurement that determines which point of an axis is chosen for reading a value from a given characteristic.
 		e.g. given a curve where the x-Axis is the rpm of the engine and the values are
@@ -665,105 +470,71 @@ func getValuesFromHex(cv *CharacteristicValues, cd *CalibrationData) {
 		x	1000	2000	3000	4000	5000	6000
 		v	  12	  27	  38	  42	  49	  18
 		then  if the input quantity for this curve would be the measurement of the current rpm of the engine
-		depending on the rpm a value is chosen.
-		case "SrcAddrX":
-		case "SrcAddrY":
-		case "SrcAddrZ":
-		case "SrcAddr4":
-		case "SrcAddr5":
-		*/
+		depending on the rpm a value is chosen.*/
+		case "SrcAddrX", "SrcAddrY", "SrcAddrZ", "SrcAddr4", "SrcAddr5":
 		case "ShiftOpX":
-			cv.shiftOpXValue, err = cd.getShiftOpX(rl, &curPos)
+			cv.shiftOpXValue, err = cd.getShiftOpX(rl, &curPos, cv.getByteOrder(cd, 0))
 			if err != nil {
 				log.Err(err).Msg("could not get value for shiftOpX of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "ShiftOpY":
-			cv.shiftOpYValue, err = cd.getShiftOpY(rl, &curPos)
+			cv.shiftOpYValue, err = cd.getShiftOpY(rl, &curPos, cv.getByteOrder(cd, 1))
 			if err != nil {
 				log.Err(err).Msg("could not get value for shiftOpY of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "ShiftOpZ":
-			cv.shiftOpZValue, err = cd.getShiftOpZ(rl, &curPos)
+			cv.shiftOpZValue, err = cd.getShiftOpZ(rl, &curPos, cv.getByteOrder(cd, 2))
 			if err != nil {
 				log.Err(err).Msg("could not get value for shiftOpZ of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "ShiftOp4":
-			cv.shiftOp4Value, err = cd.getShiftOp4(rl, &curPos)
+			cv.shiftOp4Value, err = cd.getShiftOp4(rl, &curPos, cv.getByteOrder(cd, 3))
 			if err != nil {
 				log.Err(err).Msg("could not get value for shiftOp4 of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		case "ShiftOp5":
-			cv.shiftOp5Value, err = cd.getShiftOp5(rl, &curPos)
+			cv.shiftOp5Value, err = cd.getShiftOp5(rl, &curPos, cv.getByteOrder(cd, 4))
 			if err != nil {
 				log.Err(err).Msg("could not get value for shiftOp5 of characteristic '" + cv.characteristic.Name + "'")
+				return err
 			}
 		default:
-			err := errors.New("undefined case in record layout position")
-			if err != nil {
-				log.Err(err).Msg("unexpected case '" + field + "' in characteristic '" + cv.characteristic.Name + "'")
-			}
+			err = errors.New("undefined case in record layout position")
+			log.Err(err).Msg("unexpected case '" + field + "' in characteristic '" + cv.characteristic.Name + "'")
+			return err
 		}
+		cv.fields = append(cv.fields, cv.newRecordLayoutField(rl, field, uint16(p), start, curPos, padding))
+	}
+	err = cd.checkExtent(cv.characteristic.Name, begin, curPos, limit)
+	if err != nil {
+		log.Err(err).Msg("could not read characteristic '" + cv.characteristic.Name + "'")
+		return err
 	}
+	return nil
 }
 
+// sortedPositions returns the positions of the record layout fields in ascending order
+func sortedPositions(relPos map[uint16]string) []int {
+	positions := make([]int, 0, len(relPos))
+	for p := range relPos {
+		positions = append(positions, int(p))
+	}
+	sort.Ints(positions)
+	return positions
+}
+
+// getValue aligns curPos as defined by the record layout for the given datatype and reads the bytes of one value at that address.
+// the caller is responsible to increment curPos by the length of the datatype afterwards.
 func (cd *CalibrationData) getValue(curPos *uint32, dte a2l.DataTypeEnum, rl *a2l.RecordLayout) ([]byte, error) {
-	bytes, err := cd.getBytes(cd.getNextAlignedAddress(*curPos, dte, rl), uint32(dte.GetDatatypeLength()))
+	*curPos = cd.getNextAlignedAddress(*curPos, dte, rl)
+	bytes, err := cd.getBytes(*curPos, uint32(dte.GetDatatypeLength()))
 	if err != nil {
 		log.Err(err).Msg("could not retrieve value as byteSlice")
 		return nil, err
 	}
 	return bytes, nil
 }
-
-func (cd *CalibrationData) getFncValues(rl *a2l.RecordLayout, curPos *uint32, cv *CharacteristicValues) (interface{}, error) {
-	//check access type. DIRECT is the most used. Just read value from a given address.
-	//in case other access types are set this gets more complicated as either offsets or pointers are leveraged to
-	//define the position of the calibration objects.
-	//for VALUE Type: just read one value at curPos
-	//for higher level objects: read the number of elements defined by the matrix dim or NoAxisPts fields with the direction (row, column, alternate, ...) specified.
-	//for applicable objects check whether STATIC_RECORD_LAYOUT and STATIC_ ADDRESS_OFFSET are set
-	//to determine how to read the FNC_Values correctly, this can lead to hard to detect errors when not implemented.
-	//for now just read the values as they are defined in the A2L file.
-	if !rl.FncValues.AddresstypeSet {
-		rl.FncValues.Addresstype = a2l.DIRECT
-	}
-	switch rl.FncValues.Addresstype {
-	case a2l.DIRECT:
-		valBytes, err := cd.getValue(curPos, a2l.UBYTE, rl)
-		if err != nil {
-			log.Err(err).Msg("could not get byte of new adress for fncValues of characteristic '" + cv.characteristic.Name + "'")
-			return nil, err
-		}
-		val, err := cd.convertByteSliceToDatatype(valBytes, a2l.UBYTE)
-		if err != nil {
-			log.Err(err).Msg("could not get adress from new adress bytes for fncValues of characteristic '" + cv.characteristic.Name + "'")
-			return nil, err
-		}
-		log.Info().Msg(strconv.FormatFloat(val, 'f', 0, 64))
-	case a2l.PBYTE:
-		newAdressBytes, err := cd.getValue(curPos, a2l.UBYTE, rl)
-		if err != nil {
-			log.Err(err).Msg("could not get byte of new adress for fncValues of characteristic '" + cv.characteristic.Name + "'")
-			return nil, err
-		}
-		newAdress, err := cd.convertByteSliceToDatatype(newAdressBytes, a2l.UBYTE)
-		if err != nil {
-			log.Err(err).Msg("could not get adress from new adress bytes for fncValues of characteristic '" + cv.characteristic.Name + "'")
-			return nil, err
-		}
-		log.Info().Msg(strconv.FormatFloat(newAdress, 'f', 0, 64))
-		//the address contains an one byte pointer to the first axis point or value
-	case a2l.PWORD:
-		//the address contains an two byte pointer to the first axis point or value
-	case a2l.PLONG:
-		//the address contains an four byte pointer to the first axis point or value
-	case a2l.PLONGLONG:
-		//the address contains an eight byte pointer to the first axis point or value
-	default:
-		//no valid address type
-		err := errors.New("invalid address type for fncValues")
-		log.Err(err).Msg("invalid address type for fncValues of characteristic '" + cv.characteristic.Name + "'")
-		return nil, err
-	}
-	return nil, nil
-}
@@ -2,6 +2,8 @@ package calibrationReader
 
 import (
 	"fmt"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -57,3 +59,94 @@ func TestReadCalibration(t *testing.T) {
 		log.Warn().Msg("time for finding identifier: " + fmt.Sprint(elapsed.Milliseconds()))
 	}
 }
+
+var (
+	demoOnce        sync.Once
+	demoCalibration CalibrationData
+	demoErr         error
+)
+
+// readDemoCalibration parses the demo a2l and hex file once and returns a deep copy of the result,
+// so tests can modify the module maps and the memory image without affecting each other.
+func readDemoCalibration(t *testing.T) CalibrationData {
+	demoOnce.Do(func() {
+		demoCalibration, demoErr = ReadCalibration("testing/ASAP2_Demo_V171_allKeywords.a2l", "testing/ASAP2_Demo_V171.hex")
+	})
+	if demoErr != nil {
+		t.Fatalf("failed parsing with error: %s.", demoErr)
+	}
+	return deepCopy(reflect.ValueOf(demoCalibration)).Interface().(CalibrationData)
+}
+
+// deepCopy copies maps, slices, pointers and the exported fields of structs recursively.
+// unexported fields are copied shallowly.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+func TestReadDemoCalibration(t *testing.T) {
+	cd := readDemoCalibration(t)
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	c := m.Characteristics["ASAM.C.CURVE.STD_AXIS"]
+	c.AxisDescr[0].LowerLimit = -1000
+	m.Characteristics[c.Name] = c
+	delete(m.Characteristics, "ASAM.C.SCALAR.UBYTE.IDENTICAL")
+	cd.Hex[0xF00000] = 0xFF
+
+	//changes of one copy must not be visible in the next one
+	other := readDemoCalibration(t)
+	om := &other.A2l.Project.Modules[other.ModuleIndex]
+	if om.Characteristics[c.Name].AxisDescr[0].LowerLimit == -1000 {
+		t.Errorf("expected axis descriptions not to be shared between copies")
+	}
+	if _, exists := om.Characteristics["ASAM.C.SCALAR.UBYTE.IDENTICAL"]; !exists {
+		t.Errorf("expected characteristics not to be shared between copies")
+	}
+	if _, exists := other.Hex[0xF00000]; exists {
+		t.Errorf("expected memory images not to be shared between copies")
+	}
+}
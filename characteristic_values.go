@@ -1,25 +1,63 @@
 package calibrationReader
 
 import (
-	"sync"
+	"errors"
+	"strconv"
+	"strings"
 
 	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
 )
 
+// CharacteristicValues contains the values of one characteristic as they are read from the hex file
+// as well as the values of the record layout fields that were necessary to locate them.
 type CharacteristicValues struct {
-	characteristic      *a2l.Characteristic
-	recordLayout        *a2l.RecordLayout
-	AxisXValues         []float64
-	AxisYValues         []float64
-	AxisZValues         []float64
-	Axis4Values         []float64
-	Axis5Values         []float64
+	characteristic *a2l.Characteristic
+	recordLayout   *a2l.RecordLayout
+	//Name is the identifier of the characteristic
+	Name string
+	//Type of the characteristic (VALUE, CURVE, MAP, ...) which determines the shape of RawValues and PhyValues
+	Type a2l.TypeEnum
+	//Dimensions contains the number of values in x, y, z, 4 and 5 direction. Empty for VALUE.
+	Dimensions []int
+	//IsVirtual is true for characteristics with VIRTUAL_CHARACTERISTIC.
+	//their values are computed from the referenced characteristics and not stored in the hex file.
+	IsVirtual bool
+	//ReadOnly is true for characteristics that must not be changed, i.e. READ_ONLY and virtual characteristics
+	ReadOnly bool
+	//Saturated is true for virtual characteristics whose computed values exceed the range of their datatype.
+	//the values are saturated to the range just like the ecu would store them.
+	Saturated bool
+	//RawValues contains the decimal values as read from the hex file.
+	//VALUE yields a float64, one dimension a []float64, two dimensions a [][]float64 indexed [x][y] and so on up to five dimensions.
+	RawValues interface{}
+	//PhyValues has the same shape as RawValues and contains the values converted with the compu method of the characteristic.
+	//elements are float64 for numeric conversions and string for verbal conversions.
+	//ASCII characteristics yield a single string.
+	PhyValues interface{}
+	//AxisXRawValues to Axis5RawValues contain the decimal axis points as read from the hex file
+	AxisXRawValues []float64
+	AxisYRawValues []float64
+	AxisZRawValues []float64
+	Axis4RawValues []float64
+	Axis5RawValues []float64
+	//AxisXPhyValues to Axis5PhyValues contain the axis points converted with the compu method of the axis description.
+	//either []float64 or []string for verbal conversions
+	AxisXPhyValues interface{}
+	AxisYPhyValues interface{}
+	AxisZPhyValues interface{}
+	Axis4PhyValues interface{}
+	Axis5PhyValues interface{}
+	//Padding contains the number of bytes inserted before each field of the record layout to align it.
+	//fields are identified by their name within a2l.RecordLayout, e.g. "FncValues".
+	Padding             map[string]uint32
 	distOpXValue        int64
 	distOpYValue        int64
 	distOpZValue        int64
 	distOp4Value        int64
 	distOp5Value        int64
-	fncValues           interface{}
+	fields              []RecordLayoutField
+	fncValues           []float64
 	identificationValue interface{}
 	noAxisPtsXValue     int64
 	noAxisPtsYValue     int64
@@ -32,21 +70,425 @@ type CharacteristicValues struct {
 	offsetZValue        int64
 	offset4Value        int64
 	offset5Value        int64
+	rescalePairsX       []RescalePair
 	shiftOpXValue       int64
 	shiftOpYValue       int64
 	shiftOpZValue       int64
 	shiftOp4Value       int64
 	shiftOp5Value       int64
+	//fncAddresses contains the address of each function value in ROW_DIR order and axisAddresses the address of each axis point
+	//in ascending index order within the memory image they have been read from
+	fncAddresses  []uint32
+	axisAddresses [5][]uint32
+	image         map[uint32]byte
 }
 
 func NewCharacteristicValues(characteristic *a2l.Characteristic, recordLayout *a2l.RecordLayout) *CharacteristicValues {
 	return &CharacteristicValues{
 		characteristic: characteristic,
 		recordLayout:   recordLayout,
+		Name:           characteristic.Name,
+		Type:           characteristic.Type,
 	}
 }
 
-func (cv CharacteristicValues) computeValues(cd *CalibrationData, valueChannel chan interface{}, errChannel chan error, wg *sync.WaitGroup) {
-	defer wg.Done()
-	return
+// GetCharacteristicValues reads the characteristic with the given identifier from the hex file
+// and returns its raw and physical values as well as the values of its axes.
+// the values of virtual characteristics are computed from their referenced characteristics.
+func (cd *CalibrationData) GetCharacteristicValues(name string) (CharacteristicValues, error) {
+	return cd.getCharacteristicValues(name, nil)
+}
+
+// getCharacteristicValues reads or computes the values of the characteristic with the given identifier.
+// resolving holds the names of the characteristics whose values are currently computed in order to detect cyclic references
+// of virtual characteristics and CURVE_AXIS_REF.
+func (cd *CalibrationData) getCharacteristicValues(name string, resolving []string) (CharacteristicValues, error) {
+	c, exists := cd.A2l.Project.Modules[cd.ModuleIndex].Characteristics[name]
+	if !exists {
+		err := errors.New("characteristic " + name + " not found")
+		log.Err(err).Msg("could not get characteristic values")
+		return CharacteristicValues{}, err
+	}
+	for _, r := range resolving {
+		if r == name {
+			err := errors.New("cyclic reference of characteristic " + name + ": " + strings.Join(resolving, " -> ") + " -> " + name)
+			log.Err(err).Msg("could not get characteristic values")
+			return CharacteristicValues{}, err
+		}
+	}
+	//copy to keep sibling references from sharing the backing array
+	stack := make([]string, len(resolving), len(resolving)+1)
+	copy(stack, resolving)
+	stack = append(stack, name)
+	rl, err := cd.getRecordLayout(&c)
+	if err != nil {
+		log.Err(err).Msg("could not get characteristic values")
+		return CharacteristicValues{}, err
+	}
+	cv := NewCharacteristicValues(&c, rl)
+	cv.ReadOnly = c.ReadOnly.Value
+	if len(c.VirtualCharacteristic) > 0 {
+		err = cv.computeVirtualValues(cd, stack)
+	} else {
+		err = cv.computeValues(cd, stack)
+	}
+	if err != nil {
+		log.Err(err).Msg("could not get characteristic values")
+		return *cv, err
+	}
+	return *cv, nil
+}
+
+// computeValues reads all fields of the record layout from the hex file,
+// shapes the function values according to the dimensions of the characteristic
+// and converts function values and axis points to their physical representation.
+// resolving holds the names of the characteristics whose values are currently computed including this one.
+func (cv *CharacteristicValues) computeValues(cd *CalibrationData, resolving []string) error {
+	//axes outside of the deposit have to be known beforehand as they determine the number of function values
+	err := cv.resolveAxes(cd, resolving)
+	if err != nil {
+		log.Err(err).Msg("could not resolve axes of characteristic '" + cv.characteristic.Name + "'")
+		return err
+	}
+	err = getValuesFromHex(cv, cd)
+	if err != nil {
+		log.Err(err).Msg("could not read values of characteristic '" + cv.characteristic.Name + "'")
+		return err
+	}
+	err = cv.computeAxisPhyValues(cd)
+	if err != nil {
+		log.Err(err).Msg("could not convert axis values of characteristic '" + cv.characteristic.Name + "'")
+		return err
+	}
+	cv.Dimensions, err = cv.getDimensions()
+	if err != nil {
+		log.Err(err).Msg("could not determine dimensions of characteristic '" + cv.characteristic.Name + "'")
+		return err
+	}
+	cv.RawValues, err = shapeValues(cv.fncValues, cv.Dimensions)
+	if err != nil {
+		log.Err(err).Msg("could not shape raw values of characteristic '" + cv.characteristic.Name + "'")
+		return err
+	}
+	if cv.characteristic.Type == a2l.ASCII {
+		cv.PhyValues = convertBytesToString(cv.fncValues)
+		return nil
+	}
+	phy, err := cd.convertValuesToPhysical(cv.fncValues, cv.characteristic.Conversion, cv.recordLayout.FncValues.Datatype)
+	if err != nil {
+		log.Err(err).Msg("could not convert values of characteristic '" + cv.characteristic.Name + "'")
+		return err
+	}
+	cv.PhyValues, err = shapeValues(phy, cv.Dimensions)
+	if err != nil {
+		log.Err(err).Msg("could not shape physical values of characteristic '" + cv.characteristic.Name + "'")
+		return err
+	}
+	return nil
+}
+
+// computeAxisPhyValues converts all axis points that have been read from the hex file
+// with the conversion method defined in the corresponding axis description.
+// axes that already carry physical values (CURVE_AXIS) are left untouched.
+func (cv *CharacteristicValues) computeAxisPhyValues(cd *CalibrationData) error {
+	var err error
+	for i := 0; i < 5; i++ {
+		raw, phy := cv.getAxisValuesRef(i)
+		if len(*raw) == 0 || *phy != nil {
+			continue
+		}
+		conversion := ""
+		if i < len(cv.characteristic.AxisDescr) {
+			conversion = cv.characteristic.AxisDescr[i].Conversion
+		}
+		*phy, err = cd.convertValuesToPhysical(*raw, conversion, cv.getAxisDatatype(cd, i))
+		if err != nil {
+			log.Err(err).Msg("could not convert axis " + strconv.Itoa(i) + " values")
+			return err
+		}
+	}
+	return nil
+}
+
+// getDimensions determines the number of values in each direction of the characteristic.
+// VALUE has no dimension, VAL_BLK and ASCII are defined by MATRIX_DIM or NUMBER
+// and CURVE up to CUBE_5 by the number of axis points of each axis.
+func (cv *CharacteristicValues) getDimensions() ([]int, error) {
+	var dims []int
+	c := cv.characteristic
+	switch c.Type {
+	case a2l.Value:
+		return dims, nil
+	case a2l.ASCII, a2l.ValBlk:
+		md := c.MatrixDim
+		if md.DimXSet {
+			dims = append(dims, int(md.DimX))
+		}
+		if md.DimYSet {
+			dims = append(dims, int(md.DimY))
+		}
+		if md.DimZSet {
+			dims = append(dims, int(md.DimZ))
+		}
+		if md.Dim4Set {
+			dims = append(dims, int(md.Dim4))
+		}
+		if md.Dim5Set {
+			dims = append(dims, int(md.Dim5))
+		}
+		//MATRIX_DIM 6 1 1 describes the same array as MATRIX_DIM 6
+		for len(dims) > 1 && dims[len(dims)-1] <= 1 {
+			dims = dims[:len(dims)-1]
+		}
+		if len(dims) == 0 && c.Number.NumberSet {
+			dims = append(dims, int(c.Number.Number))
+		}
+		if len(dims) == 0 {
+			err := errors.New("neither MATRIX_DIM nor NUMBER defined for characteristic " + c.Name)
+			log.Err(err).Msg("could not determine dimensions")
+			return nil, err
+		}
+	case a2l.Curve, a2l.Map, a2l.Cuboid, a2l.Cube4, a2l.Cube5:
+		for i := range c.AxisDescr {
+			n, err := cv.getNoAxisPts(i)
+			if err != nil {
+				log.Err(err).Msg("could not determine dimensions")
+				return nil, err
+			}
+			dims = append(dims, n)
+		}
+		if len(dims) == 0 {
+			err := errors.New("no axis description defined for characteristic " + c.Name)
+			log.Err(err).Msg("could not determine dimensions")
+			return nil, err
+		}
+	default:
+		err := errors.New("unsupported characteristic type " + string(c.Type) + " for characteristic " + c.Name)
+		log.Err(err).Msg("could not determine dimensions")
+		return nil, err
+	}
+	for _, d := range dims {
+		if d <= 0 {
+			err := errors.New("invalid dimension " + strconv.Itoa(d) + " for characteristic " + c.Name)
+			log.Err(err).Msg("could not determine dimensions")
+			return nil, err
+		}
+	}
+	return dims, nil
+}
+
+// getNoAxisPts returns the number of axis points of the axis with the given index (0 = x ... 4 = 5).
+// the number of axis points read from the hex file takes precedence over the fixed number of axis points within the record layout.
+// if neither is available the maximum number of axis points of the axis description is used.
+func (cv *CharacteristicValues) getNoAxisPts(axisIndex int) (int, error) {
+	rl := cv.recordLayout
+	raw := [5][]float64{cv.AxisXRawValues, cv.AxisYRawValues, cv.AxisZRawValues, cv.Axis4RawValues, cv.Axis5RawValues}
+	noAxisPts := [5]int64{cv.noAxisPtsXValue, cv.noAxisPtsYValue, cv.noAxisPtsZValue, cv.noAxisPts4Value, cv.noAxisPts5Value}
+	fix := [5]a2l.FixNoAxisPtsX{
+		a2l.FixNoAxisPtsX(rl.FixNoAxisPtsX),
+		a2l.FixNoAxisPtsX(rl.FixNoAxisPtsY),
+		a2l.FixNoAxisPtsX(rl.FixNoAxisPtsZ),
+		a2l.FixNoAxisPtsX(rl.FixNoAxisPts4),
+		a2l.FixNoAxisPtsX(rl.FixNoAxisPts5),
+	}
+	if axisIndex < 0 || axisIndex >= len(raw) {
+		err := errors.New("axis index " + strconv.Itoa(axisIndex) + " out of range")
+		log.Err(err).Msg("could not determine number of axis points")
+		return 0, err
+	}
+	switch {
+	case len(raw[axisIndex]) > 0:
+		return len(raw[axisIndex]), nil
+	case noAxisPts[axisIndex] > 0:
+		return int(noAxisPts[axisIndex]), nil
+	case fix[axisIndex].NumberOfAxisPointsSet:
+		return int(fix[axisIndex].NumberOfAxisPoints), nil
+	case axisIndex < len(cv.characteristic.AxisDescr) && cv.characteristic.AxisDescr[axisIndex].MaxAxisPointsSet:
+		return int(cv.characteristic.AxisDescr[axisIndex].MaxAxisPoints), nil
+	default:
+		err := errors.New("number of axis points could not be determined for axis " + strconv.Itoa(axisIndex) + " of characteristic " + cv.characteristic.Name)
+		log.Err(err).Msg("could not determine number of axis points")
+		return 0, err
+	}
+}
+
+// convertBytesToString interprets the values of an ASCII characteristic as characters.
+// the string ends at the first zero byte.
+func convertBytesToString(vals []float64) string {
+	var sb strings.Builder
+	for _, v := range vals {
+		if v == 0 {
+			break
+		}
+		sb.WriteByte(byte(v))
+	}
+	return sb.String()
+}
+
+// flatIndex computes the position of the value with the given coordinates (x, y, z, 4, 5)
+// within the flat list of values as stored in the hex file.
+// the x coordinate is the fastest changing one.
+func flatIndex(dims []int, coords ...int) int {
+	idx := 0
+	stride := 1
+	for i, c := range coords {
+		idx += c * stride
+		stride *= dims[i]
+	}
+	return idx
+}
+
+// shapeValues arranges a flat list of values ([]float64 or []string) into nested slices
+// indexed [x][y][z][4][5] depending on the number of dimensions.
+func shapeValues(flat interface{}, dims []int) (interface{}, error) {
+	n := 1
+	for _, d := range dims {
+		n *= d
+	}
+	switch f := flat.(type) {
+	case []float64:
+		if len(f) < n {
+			err := errors.New("expected " + strconv.Itoa(n) + " values, got " + strconv.Itoa(len(f)))
+			log.Err(err).Msg("could not shape values")
+			return nil, err
+		}
+		return shapeFloat64(f, dims), nil
+	case []string:
+		if len(f) < n {
+			err := errors.New("expected " + strconv.Itoa(n) + " values, got " + strconv.Itoa(len(f)))
+			log.Err(err).Msg("could not shape values")
+			return nil, err
+		}
+		return shapeString(f, dims), nil
+	default:
+		err := errors.New("unexpected type of values")
+		log.Err(err).Msg("could not shape values")
+		return nil, err
+	}
+}
+
+func shapeFloat64(f []float64, d []int) interface{} {
+	switch len(d) {
+	case 0:
+		return f[0]
+	case 1:
+		return f[:d[0]]
+	case 2:
+		v := make([][]float64, d[0])
+		for x := range v {
+			v[x] = make([]float64, d[1])
+			for y := range v[x] {
+				v[x][y] = f[flatIndex(d, x, y)]
+			}
+		}
+		return v
+	case 3:
+		v := make([][][]float64, d[0])
+		for x := range v {
+			v[x] = make([][]float64, d[1])
+			for y := range v[x] {
+				v[x][y] = make([]float64, d[2])
+				for z := range v[x][y] {
+					v[x][y][z] = f[flatIndex(d, x, y, z)]
+				}
+			}
+		}
+		return v
+	case 4:
+		v := make([][][][]float64, d[0])
+		for x := range v {
+			v[x] = make([][][]float64, d[1])
+			for y := range v[x] {
+				v[x][y] = make([][]float64, d[2])
+				for z := range v[x][y] {
+					v[x][y][z] = make([]float64, d[3])
+					for w := range v[x][y][z] {
+						v[x][y][z][w] = f[flatIndex(d, x, y, z, w)]
+					}
+				}
+			}
+		}
+		return v
+	default:
+		v := make([][][][][]float64, d[0])
+		for x := range v {
+			v[x] = make([][][][]float64, d[1])
+			for y := range v[x] {
+				v[x][y] = make([][][]float64, d[2])
+				for z := range v[x][y] {
+					v[x][y][z] = make([][]float64, d[3])
+					for w := range v[x][y][z] {
+						v[x][y][z][w] = make([]float64, d[4])
+						for u := range v[x][y][z][w] {
+							v[x][y][z][w][u] = f[flatIndex(d, x, y, z, w, u)]
+						}
+					}
+				}
+			}
+		}
+		return v
+	}
+}
+
+func shapeString(f []string, d []int) interface{} {
+	switch len(d) {
+	case 0:
+		return f[0]
+	case 1:
+		return f[:d[0]]
+	case 2:
+		v := make([][]string, d[0])
+		for x := range v {
+			v[x] = make([]string, d[1])
+			for y := range v[x] {
+				v[x][y] = f[flatIndex(d, x, y)]
+			}
+		}
+		return v
+	case 3:
+		v := make([][][]string, d[0])
+		for x := range v {
+			v[x] = make([][]string, d[1])
+			for y := range v[x] {
+				v[x][y] = make([]string, d[2])
+				for z := range v[x][y] {
+					v[x][y][z] = f[flatIndex(d, x, y, z)]
+				}
+			}
+		}
+		return v
+	case 4:
+		v := make([][][][]string, d[0])
+		for x := range v {
+			v[x] = make([][][]string, d[1])
+			for y := range v[x] {
+				v[x][y] = make([][]string, d[2])
+				for z := range v[x][y] {
+					v[x][y][z] = make([]string, d[3])
+					for w := range v[x][y][z] {
+						v[x][y][z][w] = f[flatIndex(d, x, y, z, w)]
+					}
+				}
+			}
+		}
+		return v
+	default:
+		v := make([][][][][]string, d[0])
+		for x := range v {
+			v[x] = make([][][][]string, d[1])
+			for y := range v[x] {
+				v[x][y] = make([][][]string, d[2])
+				for z := range v[x][y] {
+					v[x][y][z] = make([][]string, d[3])
+					for w := range v[x][y][z] {
+						v[x][y][z][w] = make([]string, d[4])
+						for u := range v[x][y][z][w] {
+							v[x][y][z][w][u] = f[flatIndex(d, x, y, z, w, u)]
+						}
+					}
+				}
+			}
+		}
+		return v
+	}
 }
@@ -0,0 +1,70 @@
+package calibrationReader
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestGetCharacteristicValues(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	scalars := map[string]float64{
+		"ASAM.C.SCALAR.UBYTE.IDENTICAL":        20,
+		"ASAM.C.SCALAR.SBYTE.IDENTICAL":        6,
+		"ASAM.C.SCALAR.UWORD.IDENTICAL":        383,
+		"ASAM.C.SCALAR.SWORD.IDENTICAL":        2,
+		"ASAM.C.SCALAR.ULONG.IDENTICAL":        17,
+		"ASAM.C.SCALAR.SLONG.IDENTICAL":        86,
+		"ASAM.C.SCALAR.SWORD.LINEAR_MUL_2":     4,
+		"ASAM.C.SCALAR.SWORD.RAT_FUNC_DIV_10":  0.2,
+		"ASAM.C.SCALAR.FLOAT32_IEEE.IDENTICAL": 33.23455810546875,
+	}
+	for name, expected := range scalars {
+		cv, err := cd.GetCharacteristicValues(name)
+		if err != nil {
+			t.Fatalf("could not get values of %s: %s", name, err)
+		}
+		phy, ok := cv.PhyValues.(float64)
+		if !ok || phy != expected {
+			t.Errorf("%s: expected %v, got %v", name, expected, cv.PhyValues)
+		}
+	}
+
+	cv, err := cd.GetCharacteristicValues("ASAM.C.CURVE.STD_AXIS")
+	if err != nil {
+		t.Fatalf("could not get values of curve: %s", err)
+	}
+	expectedAxis := []float64{-5, -1, 2, 4, 5, 8, 14, 22}
+	expectedValues := []float64{9, 13, 7, 15, 71, 6, -1, -3}
+	axis, ok := cv.AxisXPhyValues.([]float64)
+	if !ok || fmt.Sprint(axis) != fmt.Sprint(expectedAxis) {
+		t.Errorf("curve axis: expected %v, got %v", expectedAxis, cv.AxisXPhyValues)
+	}
+	values, ok := cv.PhyValues.([]float64)
+	if !ok || fmt.Sprint(values) != fmt.Sprint(expectedValues) {
+		t.Errorf("curve values: expected %v, got %v", expectedValues, cv.PhyValues)
+	}
+
+	cv, err = cd.GetCharacteristicValues("ASAM.C.MAP.STD_AXIS.STD_AXIS")
+	if err != nil {
+		t.Fatalf("could not get values of map: %s", err)
+	}
+	m, ok := cv.PhyValues.([][]float64)
+	if !ok || len(m) != 4 || len(m[0]) != 5 || m[1][2] != 9 {
+		t.Errorf("map values: unexpected shape or content %v", cv.PhyValues)
+	}
+	if _, ok := cv.AxisYPhyValues.([]string); !ok {
+		t.Errorf("map y axis: expected verbal axis points, got %v", cv.AxisYPhyValues)
+	}
+
+	cv, err = cd.GetCharacteristicValues("ASAM.C.ASCII.UBYTE.NUMBER_42")
+	if err != nil {
+		t.Fatalf("could not get values of ascii: %s", err)
+	}
+	if cv.PhyValues != "ASAM Test" {
+		t.Errorf("ascii: expected 'ASAM Test', got %v", cv.PhyValues)
+	}
+}
@@ -3,6 +3,7 @@ package calibrationReader
 import (
 	"errors"
 	"math"
+	"strconv"
 
 	"github.com/JustinasPuzas/calibrationReader/a2l"
 	"github.com/rs/zerolog/log"
@@ -14,10 +15,12 @@ func convDecToPhy(dec float64, cm *a2l.CompuMethod, cd *CalibrationData) (float6
 	case a2l.Identical:
 		return dec, nil
 	case a2l.Form:
-		//implement if found in prod data.
-		err = errors.New("conversion method 'Form' not implemented for compuMethod " + cm.Name)
-		log.Err(err).Msg("decimal value could not be converted")
-		return 0, err
+		phy, err := calcFormula(dec, cm, cd)
+		if err != nil {
+			log.Err(err).Msg("decimal value could not be converted")
+			return dec, err
+		}
+		return phy, err
 	case a2l.Linear:
 		if !(cm.CoeffsLinear.ASet && cm.CoeffsLinear.BSet) {
 			err = errors.New("CoeffsLinear not set in compuMethod: " + cm.Name)
@@ -38,7 +41,12 @@ func convDecToPhy(dec float64, cm *a2l.CompuMethod, cd *CalibrationData) (float6
 		}
 		return phy, err
 	case a2l.TabIntp:
-		return 0, err
+		phy, err := calcTabIntp(dec, cm, cd)
+		if err != nil {
+			log.Err(err).Msg("decimal value could not be converted")
+			return dec, err
+		}
+		return phy, err
 	case a2l.TabNointp:
 		phy, err := calcTabNoIntp(dec, cm, cd)
 		if err != nil {
@@ -57,7 +65,11 @@ func convDecToPhy(dec float64, cm *a2l.CompuMethod, cd *CalibrationData) (float6
 	}
 }
 
-func calcTabVerbRange(dec float64, cvr *a2l.CompuVTabRange, cd *CalibrationData) (string, error) {
+// calcTabVerbRange returns the display string of the range that contains dec.
+// for integer datatypes both limits belong to the range (InValMin <= dec <= InValMax),
+// for floating point datatypes the upper limit is excluded (InValMin <= dec < InValMax) unless both limits are equal.
+// values outside of all ranges are displayed with the DEFAULT_VALUE.
+func calcTabVerbRange(dec float64, cvr *a2l.CompuVTabRange, cd *CalibrationData, dte a2l.DataTypeEnum) (string, error) {
 	var err error
 	tabRange, exists := cd.A2l.Project.Modules[cd.ModuleIndex].CompuVTabRanges[cvr.Name]
 	if !exists {
@@ -66,18 +78,15 @@ func calcTabVerbRange(dec float64, cvr *a2l.CompuVTabRange, cd *CalibrationData)
 		return "", err
 	}
 	if tabRange.NumberValueTriplesSet {
-		var i uint16
-		for i = 0; i < tabRange.NumberValueTriples; i++ {
-			if tabRange.InValMin[i] <= dec && dec < tabRange.InValMin[i] {
-				return tabRange.OutVal[i], err
-			}
+		if out, found := lookupVTabRange(dec, &tabRange, dte); found {
+			return out, err
 		}
 		if !tabRange.DefaultValue.DisplayStringSet {
 			err = errors.New("no default output found for conversion table " + cvr.Name)
 			log.Err(err).Msg("decimal value could not be converted")
 			return "", err
 		}
-		return tabRange.DefaultValue.DisplayString, err
+		return trimQuotes(tabRange.DefaultValue.DisplayString), err
 	} else {
 		err = errors.New("no output at all found for conversion table " + cvr.Name)
 		log.Err(err).Msg("decimal value could not be converted")
@@ -86,6 +95,7 @@ func calcTabVerbRange(dec float64, cvr *a2l.CompuVTabRange, cd *CalibrationData)
 
 }
 
+// calcTabVerb returns the display string of the conversion table entry for dec or the DEFAULT_VALUE if there is none.
 func calcTabVerb(dec float64, cv *a2l.CompuVTab, cd *CalibrationData) (string, error) {
 	var err error
 	tab, exists := cd.A2l.Project.Modules[cd.ModuleIndex].CompuVTabs[cv.Name]
@@ -95,17 +105,15 @@ func calcTabVerb(dec float64, cv *a2l.CompuVTab, cd *CalibrationData) (string, e
 		return "", err
 	}
 	if tab.InValSet && tab.OutValSet {
-		for i := range tab.InVal {
-			if dec == tab.InVal[i] {
-				return tab.OutVal[i], err
-			}
+		if out, found := lookupVTab(dec, &tab); found {
+			return out, err
 		}
 		if !tab.DefaultValue.DisplayStringSet {
 			err = errors.New("no default output found for conversion table " + cv.Name)
 			log.Err(err).Msg("decimal value could not be converted")
 			return "", err
 		}
-		return tab.DefaultValue.DisplayString, err
+		return trimQuotes(tab.DefaultValue.DisplayString), err
 	} else {
 		err = errors.New("no output at all found for conversion table " + cv.Name)
 		log.Err(err).Msg("decimal value could not be converted")
@@ -113,67 +121,119 @@ func calcTabVerb(dec float64, cv *a2l.CompuVTab, cd *CalibrationData) (string, e
 	}
 }
 
-//ToDo: Hier weitermachen. Compu Method Tab_verb / Tab_Verb_Ranges / Status String Ref weiterimplementieren.
+// lookupVTab returns the display string of the entry of a COMPU_VTAB whose input value equals dec.
+// false is returned if there is no such entry, the DEFAULT_VALUE is not considered.
+func lookupVTab(dec float64, tab *a2l.CompuVTab) (string, bool) {
+	for i := range tab.InVal {
+		if dec == tab.InVal[i] && i < len(tab.OutVal) {
+			return trimQuotes(tab.OutVal[i]), true
+		}
+	}
+	return "", false
+}
+
+// lookupVTabRange returns the display string of the range of a COMPU_VTAB_RANGE that contains dec
+// following the same rules for the upper limit as calcTabVerbRange.
+// false is returned if dec is outside of all ranges, the DEFAULT_VALUE is not considered.
+func lookupVTabRange(dec float64, tabRange *a2l.CompuVTabRange, dte a2l.DataTypeEnum) (string, bool) {
+	var i uint16
+	for i = 0; i < tabRange.NumberValueTriples && int(i) < len(tabRange.OutVal) && int(i) < len(tabRange.InValMin) && int(i) < len(tabRange.InValMax); i++ {
+		min, max := tabRange.InValMin[i], tabRange.InValMax[i]
+		if min <= dec && (dec < max || (dec == max && (!isFloatDatatype(dte) || min == max))) {
+			return trimQuotes(tabRange.OutVal[i]), true
+		}
+	}
+	return "", false
+}
 
 /*
-calcRatFunc computes the following formula: f(Physical) = Decimal
-y = (axx + bx + c) / (dxx + ex + f)
-inverted fi(Decimal) = Physical
-y = (e dec - b)/(2 (a - d dec)) ± sqrt((e dec - b)^2 - 4 (d dec - a) (f dec - c))/(2 (a - d dec))
+calcRatFunc computes the physical value of a rational function.
+the rational function is defined as f(Physical) = Decimal:
+dec = (a*phy*phy + b*phy + c) / (d*phy*phy + e*phy + f)
+which results in the quadratic equation
+(a - d*dec)*phy*phy + (b - e*dec)*phy + (c - f*dec) = 0
+that is solved for phy. in case both solutions are valid the one that reproduces dec is chosen,
+preferring the larger one. if the quadratic term vanishes the linear equation is solved.
 */
 func calcRatFunc(dec float64, cm *a2l.CompuMethod) (float64, error) {
 	var err error
-	//following formula defines f(Physical) = Decimal
-	//y = (axx + bx + c) / (dxx + ex + f)
-	//inverted fi(Decimal) = Physical
-	//y = (e dec - b)/(2 (a - d dec)) ± sqrt((e dec - b)^2 - 4 (d dec - a) (f dec - c))/(2 (a - d dec))
-	firstDivisor := (2 * (cm.Coeffs.A - cm.Coeffs.D*dec))
-	if firstDivisor == 0 {
-		err = errors.New("rationality function cannot be computed(zero divisor) for compuMethod: " + cm.Name)
+	qa := cm.Coeffs.A - cm.Coeffs.D*dec
+	qb := cm.Coeffs.B - cm.Coeffs.E*dec
+	qc := cm.Coeffs.C - cm.Coeffs.F*dec
+	if qa == 0 {
+		if qb == 0 {
+			err = errors.New("rationality function cannot be computed(zero divisor) for compuMethod: " + cm.Name)
+			log.Err(err).Msg("decimal value could not be converted")
+			return 0, err
+		}
+		return -qc / qb, err
+	}
+	discriminant := qb*qb - 4*qa*qc
+	if discriminant < 0 {
+		err = errors.New("rationality function has no real solution for value " + strconv.FormatFloat(dec, 'f', -1, 64) + " in compuMethod: " + cm.Name)
 		log.Err(err).Msg("decimal value could not be converted")
 		return 0, err
 	}
-	secondDivisorPositive := (2 * (cm.Coeffs.A - cm.Coeffs.D*dec)) +
-		math.Sqrt(math.Pow((cm.Coeffs.E*dec-cm.Coeffs.B), 2)-4*(cm.Coeffs.D*dec-cm.Coeffs.A)*(cm.Coeffs.F*dec-cm.Coeffs.C))/firstDivisor
-	secondDivisorNegative := (2 * (cm.Coeffs.A - cm.Coeffs.D*dec)) -
-		math.Sqrt(math.Pow((cm.Coeffs.E*dec-cm.Coeffs.B), 2)-4*(cm.Coeffs.D*dec-cm.Coeffs.A)*(cm.Coeffs.F*dec-cm.Coeffs.C))/firstDivisor
-
-	if secondDivisorPositive != 0 && secondDivisorNegative != 0 {
-		plusVal := (cm.Coeffs.E*dec - cm.Coeffs.B) / secondDivisorPositive
-		minusVal := (cm.Coeffs.E*dec - cm.Coeffs.B) / secondDivisorNegative
-		testVal := (cm.Coeffs.A*plusVal*plusVal + cm.Coeffs.B*plusVal + cm.Coeffs.C) / (cm.Coeffs.D*plusVal*plusVal + cm.Coeffs.E*plusVal + cm.Coeffs.F)
-		if testVal == dec {
-			return plusVal, err
-		} else {
-			return minusVal, err
-		}
-	} else if secondDivisorPositive != 0 {
-		plusVal := (cm.Coeffs.E*dec - cm.Coeffs.B) / secondDivisorPositive
+	plusVal := (-qb + math.Sqrt(discriminant)) / (2 * qa)
+	minusVal := (-qb - math.Sqrt(discriminant)) / (2 * qa)
+	if math.Abs(evalRatFunc(plusVal, cm)-dec) <= math.Abs(evalRatFunc(minusVal, cm)-dec) {
 		return plusVal, err
-	} else if secondDivisorNegative != 0 {
-		minusVal := (cm.Coeffs.E*dec - cm.Coeffs.B) / secondDivisorNegative
-		return minusVal, err
-	} else {
-		err = errors.New("rationality function cannot be computed(zero divisor) for compuMethod: " + cm.Name)
-		log.Err(err).Msg("decimal value could not be converted")
-		return 0, err
 	}
+	return minusVal, err
+}
 
+// evalRatFunc computes f(Physical) = Decimal for a rational function
+func evalRatFunc(phy float64, cm *a2l.CompuMethod) float64 {
+	return (cm.Coeffs.A*phy*phy + cm.Coeffs.B*phy + cm.Coeffs.C) / (cm.Coeffs.D*phy*phy + cm.Coeffs.E*phy + cm.Coeffs.F)
 }
 
+// calcTabIntp interpolates the physical value linearly between the two entries of the conversion table that enclose dec.
+// the input values of a TAB_INTP table are in ascending order. values outside of the table are clamped to its first or last output value.
+func calcTabIntp(dec float64, cm *a2l.CompuMethod, cd *CalibrationData) (float64, error) {
+	tab, exists := cd.A2l.Project.Modules[cd.ModuleIndex].CompuTabs[cm.CompuTabRef.ConversionTable]
+	if !exists {
+		err := errors.New("conversion table " + cm.CompuTabRef.ConversionTable + " not found for compuMethod: " + cm.Name)
+		log.Err(err).Msg("decimal value could not be converted")
+		return dec, err
+	}
+	n := len(tab.InVal)
+	if len(tab.OutVal) < n {
+		n = len(tab.OutVal)
+	}
+	if !tab.InValSet || !tab.OutValSet || n == 0 {
+		err := errors.New("no output at all found for conversion table " + cm.CompuTabRef.ConversionTable + " in compu method " + cm.Name)
+		log.Err(err).Msg("decimal value could not be converted")
+		return dec, err
+	}
+	if dec <= tab.InVal[0] {
+		return tab.OutVal[0], nil
+	}
+	for i := 1; i < n; i++ {
+		if dec <= tab.InVal[i] {
+			x0, x1 := tab.InVal[i-1], tab.InVal[i]
+			y0, y1 := tab.OutVal[i-1], tab.OutVal[i]
+			if x1 == x0 {
+				return y1, nil
+			}
+			return y0 + (dec-x0)*(y1-y0)/(x1-x0), nil
+		}
+	}
+	return tab.OutVal[n-1], nil
+}
+
+// calcTabNoIntp returns the output value of the conversion table entry whose input value equals dec
+// or the DEFAULT_VALUE_NUMERIC if there is none.
 func calcTabNoIntp(dec float64, cm *a2l.CompuMethod, cd *CalibrationData) (float64, error) {
 	var err error
 	tab, exists := cd.A2l.Project.Modules[cd.ModuleIndex].CompuTabs[cm.CompuTabRef.ConversionTable]
 	if !exists {
-		if err != nil {
-			err = errors.New("conversion table " + cm.CompuTabRef.ConversionTable + " not found for compuMethod: " + cm.Name)
-			log.Err(err).Msg("decimal value could not be converted")
-			return dec, err
-		}
+		err = errors.New("conversion table " + cm.CompuTabRef.ConversionTable + " not found for compuMethod: " + cm.Name)
+		log.Err(err).Msg("decimal value could not be converted")
+		return dec, err
 	}
 	if tab.InValSet && tab.OutValSet {
 		for i := range tab.InVal {
-			if dec == tab.InVal[i] {
+			if dec == tab.InVal[i] && i < len(tab.OutVal) {
 				return tab.OutVal[i], err
 			}
 		}
@@ -191,3 +251,175 @@ func calcTabNoIntp(dec float64, cm *a2l.CompuMethod, cd *CalibrationData) (float
 		return dec, err
 	}
 }
+
+// PhysicalValue is the result of a numeric conversion.
+// decimal values within the STATUS_STRING_REF of the compu method are not converted numerically,
+// instead IsStatus is set and Status holds the status text (e.g. "Sensor not connected").
+type PhysicalValue struct {
+	//Value is the physical value, it is only valid if IsStatus is false
+	Value float64
+	//Status is the text of the status string conversion table, it is only valid if IsStatus is true
+	Status   string
+	IsStatus bool
+}
+
+// String returns the status text or the formatted physical value.
+func (pv PhysicalValue) String() string {
+	if pv.IsStatus {
+		return pv.Status
+	}
+	return strconv.FormatFloat(pv.Value, 'g', -1, 64)
+}
+
+// ConvertToPhysical converts a decimal value that is stored with the given datatype with a numeric compu method.
+// at first the conversion table referenced by STATUS_STRING_REF is checked.
+// if it contains the decimal value the status text is returned, otherwise the regular numeric conversion is applied.
+// verbal conversions (TAB_VERB) are not numeric and have to be converted with ConvertToDisplay.
+// NO_COMPU_METHOD or an empty conversion leaves the value unchanged.
+func (cd *CalibrationData) ConvertToPhysical(dec float64, conversion string, dte a2l.DataTypeEnum) (PhysicalValue, error) {
+	if conversion == "" || conversion == "NO_COMPU_METHOD" {
+		return PhysicalValue{Value: dec}, nil
+	}
+	cm, exists := cd.A2l.Project.Modules[cd.ModuleIndex].CompuMethods[conversion]
+	if !exists {
+		err := errors.New("compu method " + conversion + " not found")
+		log.Err(err).Msg("decimal value could not be converted")
+		return PhysicalValue{}, err
+	}
+	status, isStatus, err := cd.getStatusString(dec, &cm, dte)
+	if err != nil {
+		log.Err(err).Msg("decimal value could not be converted")
+		return PhysicalValue{}, err
+	}
+	if isStatus {
+		return PhysicalValue{Status: status, IsStatus: true}, nil
+	}
+	phy, err := convDecToPhy(dec, &cm, cd)
+	if err != nil {
+		log.Err(err).Msg("decimal value could not be converted")
+		return PhysicalValue{}, err
+	}
+	return PhysicalValue{Value: phy}, nil
+}
+
+// getStatusString looks up the decimal value within the COMPU_VTAB or COMPU_VTAB_RANGE referenced by the STATUS_STRING_REF of the compu method.
+// the limits of the calibration object are not respected and the DEFAULT_VALUE of the table is ignored,
+// so false is returned for all values that are not explicitly part of the table or if the compu method has no STATUS_STRING_REF.
+func (cd *CalibrationData) getStatusString(dec float64, cm *a2l.CompuMethod, dte a2l.DataTypeEnum) (string, bool, error) {
+	if !cm.StatusStringRef.ConversionTableSet {
+		return "", false, nil
+	}
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	ref := cm.StatusStringRef.ConversionTable
+	if vt, exists := m.CompuVTabs[ref]; exists {
+		status, found := lookupVTab(dec, &vt)
+		return status, found, nil
+	} else if vtr, exists := m.CompuVTabRanges[ref]; exists {
+		status, found := lookupVTabRange(dec, &vtr, dte)
+		return status, found, nil
+	}
+	err := errors.New("status string conversion table " + ref + " not found for compuMethod: " + cm.Name)
+	log.Err(err).Msg("status string could not be looked up")
+	return "", false, err
+}
+
+// ConvertToDisplay converts a decimal value that is stored with the given datatype into the value that is displayed to the user.
+// the result is a float64 for numeric conversions and a string for verbal conversions (TAB_VERB with COMPU_VTAB or COMPU_VTAB_RANGE)
+// as well as for values that are displayed with a status text of the STATUS_STRING_REF.
+// values that are not part of a conversion table are displayed with the DEFAULT_VALUE_NUMERIC or the DEFAULT_VALUE of the table.
+// NO_COMPU_METHOD or an empty conversion leaves the value unchanged.
+func (cd *CalibrationData) ConvertToDisplay(dec float64, conversion string, dte a2l.DataTypeEnum) (interface{}, error) {
+	if conversion == "" || conversion == "NO_COMPU_METHOD" {
+		return dec, nil
+	}
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	cm, exists := m.CompuMethods[conversion]
+	if !exists {
+		err := errors.New("compu method " + conversion + " not found")
+		log.Err(err).Msg("decimal value could not be converted")
+		return nil, err
+	}
+	if cm.ConversionType != a2l.TabVerb {
+		//status texts take precedence over the default value of a numeric conversion table
+		status, isStatus, err := cd.getStatusString(dec, &cm, dte)
+		if err != nil {
+			log.Err(err).Msg("decimal value could not be converted")
+			return nil, err
+		}
+		if isStatus {
+			return status, nil
+		}
+	}
+	ref := cm.CompuTabRef.ConversionTable
+	switch cm.ConversionType {
+	case a2l.TabVerb:
+		if vt, exists := m.CompuVTabs[ref]; exists {
+			return calcTabVerb(dec, &vt, cd)
+		} else if vtr, exists := m.CompuVTabRanges[ref]; exists {
+			return calcTabVerbRange(dec, &vtr, cd, dte)
+		}
+		err := errors.New("conversion table " + ref + " not found for compuMethod: " + cm.Name)
+		log.Err(err).Msg("decimal value could not be converted")
+		return nil, err
+	case a2l.TabNointp:
+		//a table without numeric default may still define a display string for values that are not part of it
+		if tab, exists := m.CompuTabs[ref]; exists && !tab.DefaultValueNumeric.DisplayValueSet && tab.DefaultValue.DisplayStringSet {
+			found := false
+			for i := range tab.InVal {
+				if tab.InVal[i] == dec && i < len(tab.OutVal) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return trimQuotes(tab.DefaultValue.DisplayString), nil
+			}
+		}
+	}
+	pv, err := cd.ConvertToPhysical(dec, conversion, dte)
+	if err != nil {
+		log.Err(err).Msg("decimal value could not be converted")
+		return nil, err
+	}
+	if pv.IsStatus {
+		return pv.Status, nil
+	}
+	return pv.Value, nil
+}
+
+// convertValuesToPhysical converts decimal values that are stored with the given datatype with the compu method referenced by conversion.
+// numeric conversions return a []float64, verbal conversions (TAB_VERB) return a []string.
+// if numeric and verbal results are mixed, e.g. due to the DEFAULT_VALUE of a COMPU_TAB, all values are returned as []string.
+// NO_COMPU_METHOD or an empty conversion leaves the values unchanged.
+func (cd *CalibrationData) convertValuesToPhysical(dec []float64, conversion string, dte a2l.DataTypeEnum) (interface{}, error) {
+	disp := make([]interface{}, len(dec))
+	isVerbal := false
+	for i, d := range dec {
+		var err error
+		disp[i], err = cd.ConvertToDisplay(d, conversion, dte)
+		if err != nil {
+			log.Err(err).Msg("decimal values could not be converted")
+			return nil, err
+		}
+		if _, ok := disp[i].(string); ok {
+			isVerbal = true
+		}
+	}
+	if !isVerbal {
+		phy := make([]float64, len(disp))
+		for i, d := range disp {
+			phy[i] = d.(float64)
+		}
+		return phy, nil
+	}
+	phy := make([]string, len(disp))
+	for i, d := range disp {
+		switch v := d.(type) {
+		case string:
+			phy[i] = v
+		case float64:
+			phy[i] = strconv.FormatFloat(v, 'g', -1, 64)
+		}
+	}
+	return phy, nil
+}
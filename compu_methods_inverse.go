@@ -0,0 +1,409 @@
+package calibrationReader
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
+)
+
+// ConvertPhysicalToDecimal converts physical values back into the decimal values that are stored within the ecu
+// with the given datatype. phy is either a float64, a []float64, a string or a []string for verbal conversions.
+// the decimal values are rounded to integers for integer datatypes and saturated to the range of the datatype.
+// if a value had to be saturated the saturated values are returned together with a *RangeError.
+func (cd *CalibrationData) ConvertPhysicalToDecimal(phy interface{}, conversion string, dte a2l.DataTypeEnum) ([]float64, error) {
+	_, _, err := getDatatypeRange(dte)
+	if err != nil {
+		log.Err(err).Msg("physical values could not be converted")
+		return nil, err
+	}
+	var dec []float64
+	switch p := phy.(type) {
+	case float64:
+		dec, err = cd.convertValuesToDecimal([]float64{p}, conversion, dte)
+	case []float64:
+		dec, err = cd.convertValuesToDecimal(p, conversion, dte)
+	case string:
+		dec, err = cd.convertVerbalValuesToDecimal([]string{p}, conversion)
+	case []string:
+		dec, err = cd.convertVerbalValuesToDecimal(p, conversion)
+	default:
+		err = errors.New("unexpected type of physical values")
+	}
+	if err != nil {
+		log.Err(err).Msg("physical values could not be converted")
+		return nil, err
+	}
+	var rangeErr *RangeError
+	for i := range dec {
+		if math.IsNaN(dec[i]) {
+			err = errors.New("NaN cannot be represented by datatype " + dte.String())
+			log.Err(err).Msg("physical values could not be converted")
+			return nil, err
+		}
+		dec[i], err = saturateToDatatype(dec[i], dte)
+		if err != nil && !errors.As(err, &rangeErr) {
+			log.Err(err).Msg("physical values could not be converted")
+			return nil, err
+		}
+	}
+	if rangeErr != nil {
+		log.Err(rangeErr).Msg("physical values saturated")
+		return dec, rangeErr
+	}
+	return dec, nil
+}
+
+// convertValuesToDecimal converts numeric physical values with the compu method referenced by conversion.
+// NO_COMPU_METHOD or an empty conversion leaves the values unchanged.
+func (cd *CalibrationData) convertValuesToDecimal(phy []float64, conversion string, dte a2l.DataTypeEnum) ([]float64, error) {
+	dec := make([]float64, len(phy))
+	if conversion == "" || conversion == "NO_COMPU_METHOD" {
+		copy(dec, phy)
+		return dec, nil
+	}
+	cm, exists := cd.A2l.Project.Modules[cd.ModuleIndex].CompuMethods[conversion]
+	if !exists {
+		err := errors.New("compu method " + conversion + " not found")
+		log.Err(err).Msg("physical values could not be converted")
+		return nil, err
+	}
+	for i, p := range phy {
+		var err error
+		dec[i], err = convPhyToDec(p, &cm, cd, dte)
+		if err != nil {
+			log.Err(err).Msg("physical value could not be converted")
+			return nil, err
+		}
+	}
+	return dec, nil
+}
+
+// convertVerbalValuesToDecimal converts the display strings of a verbal conversion table back into decimal values.
+func (cd *CalibrationData) convertVerbalValuesToDecimal(phy []string, conversion string) ([]float64, error) {
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	cm, exists := m.CompuMethods[conversion]
+	if !exists {
+		err := errors.New("compu method " + conversion + " not found")
+		log.Err(err).Msg("physical values could not be converted")
+		return nil, err
+	}
+	if cm.ConversionType != a2l.TabVerb {
+		err := errors.New("compu method " + conversion + " is not a verbal conversion")
+		log.Err(err).Msg("physical values could not be converted")
+		return nil, err
+	}
+	dec := make([]float64, len(phy))
+	ref := cm.CompuTabRef.ConversionTable
+	for i, p := range phy {
+		var err error
+		if vt, exists := m.CompuVTabs[ref]; exists {
+			dec[i], err = calcTabVerbInv(p, &vt)
+		} else if vtr, exists := m.CompuVTabRanges[ref]; exists {
+			dec[i], err = calcTabVerbRangeInv(p, &vtr)
+		} else {
+			err = errors.New("conversion table " + ref + " not found for compuMethod: " + cm.Name)
+		}
+		if err != nil {
+			log.Err(err).Msg("physical value could not be converted")
+			return nil, err
+		}
+	}
+	return dec, nil
+}
+
+// convPhyToDec is the inverse of convDecToPhy and converts a physical value into its decimal representation.
+func convPhyToDec(phy float64, cm *a2l.CompuMethod, cd *CalibrationData, dte a2l.DataTypeEnum) (float64, error) {
+	var err error
+	switch cm.ConversionType {
+	case a2l.Identical:
+		return phy, nil
+	case a2l.Form:
+		dec, err := calcFormulaInv(phy, cm, cd, dte)
+		if err != nil {
+			log.Err(err).Msg("physical value could not be converted")
+			return 0, err
+		}
+		return dec, nil
+	case a2l.Linear:
+		if !(cm.CoeffsLinear.ASet && cm.CoeffsLinear.BSet) {
+			err = errors.New("CoeffsLinear not set in compuMethod: " + cm.Name)
+			log.Err(err).Msg("physical value could not be converted")
+			return 0, err
+		}
+		if cm.CoeffsLinear.A == 0 {
+			err = errors.New("linear function with slope zero cannot be inverted in compuMethod: " + cm.Name)
+			log.Err(err).Msg("physical value could not be converted")
+			return 0, err
+		}
+		return (phy - cm.CoeffsLinear.B) / cm.CoeffsLinear.A, nil
+	case a2l.RatFunc:
+		if !(cm.Coeffs.ASet && cm.Coeffs.BSet && cm.Coeffs.CSet && cm.Coeffs.DSet && cm.Coeffs.ESet && cm.Coeffs.FSet) {
+			err = errors.New("Coeffs not set in compuMethod: " + cm.Name)
+			log.Err(err).Msg("physical value could not be converted")
+			return 0, err
+		}
+		//the rational function is defined in the direction physical to decimal
+		if cm.Coeffs.D*phy*phy+cm.Coeffs.E*phy+cm.Coeffs.F == 0 {
+			err = errors.New("rationality function cannot be computed(zero divisor) for compuMethod: " + cm.Name)
+			log.Err(err).Msg("physical value could not be converted")
+			return 0, err
+		}
+		return evalRatFunc(phy, cm), nil
+	case a2l.TabIntp:
+		dec, err := calcTabIntpInv(phy, cm, cd)
+		if err != nil {
+			log.Err(err).Msg("physical value could not be converted")
+			return 0, err
+		}
+		return dec, nil
+	case a2l.TabNointp:
+		dec, err := calcTabNoIntpInv(phy, cm, cd)
+		if err != nil {
+			log.Err(err).Msg("physical value could not be converted")
+			return 0, err
+		}
+		return dec, nil
+	case a2l.TabVerb:
+		err = errors.New("conversion type tabVerb called by numeric function for compuMethod: " + cm.Name)
+		log.Err(err).Msg("physical value could not be converted")
+		return 0, err
+	default:
+		err = errors.New("conversion Type undefined in compuMethod: " + cm.Name)
+		log.Err(err).Msg("physical value could not be converted")
+		return 0, err
+	}
+}
+
+// calcFormulaInv computes the decimal value of a FORM compu method with the inverse formula FORMULA_INV.
+// if no inverse formula is defined the formula is inverted numerically within the range of the datatype.
+func calcFormulaInv(phy float64, cm *a2l.CompuMethod, cd *CalibrationData, dte a2l.DataTypeEnum) (float64, error) {
+	if len(cm.Formula) == 0 || !cm.Formula[0].FxSet {
+		err := errors.New("formula not set in compuMethod: " + cm.Name)
+		log.Err(err).Msg("physical value could not be converted")
+		return 0, err
+	}
+	if cm.Formula[0].FormulaInv.GxSet {
+		dec, err := cd.evalFormula(cm.Formula[0].FormulaInv.Gx, phy)
+		if err != nil {
+			log.Err(err).Msg("physical value could not be converted with compuMethod " + cm.Name)
+			return 0, err
+		}
+		return dec, nil
+	}
+	fx := strings.Join(cm.Formula[0].Fx, " ")
+	f := func(x float64) (float64, error) {
+		return cd.evalFormula(fx, x)
+	}
+	dec, err := invertNumerically(f, phy, dte)
+	if err != nil {
+		log.Err(err).Msg("formula of compuMethod " + cm.Name + " could not be inverted")
+		return 0, err
+	}
+	return dec, nil
+}
+
+// invertNumerically searches the decimal value x within the range of the datatype for which f(x) equals phy
+// by bisection of a finite interval enclosing phy. f has to be continuous and monotonic within the range of the datatype.
+func invertNumerically(f func(float64) (float64, error), phy float64, dte a2l.DataTypeEnum) (float64, error) {
+	lower, upper, err := getDatatypeRange(dte)
+	if err != nil {
+		log.Err(err).Msg("could not invert function")
+		return 0, err
+	}
+	lo, hi, fLo, fHi, found := findBracket(f, phy, lower, upper)
+	if !found {
+		err = errors.New("value " + strconv.FormatFloat(phy, 'g', -1, 64) + " is not reachable within the range of datatype " + dte.String())
+		log.Err(err).Msg("could not invert function")
+		return 0, err
+	}
+	if fLo == phy {
+		return lo, nil
+	}
+	if fHi == phy {
+		return hi, nil
+	}
+	ascending := fHi >= fLo
+	for i := 0; i < 2000; i++ {
+		mid := lo + (hi-lo)/2
+		if mid == lo || mid == hi || (!isFloatDatatype(dte) && hi-lo <= 0.5) {
+			break
+		}
+		fMid, err := f(mid)
+		if err != nil {
+			log.Err(err).Msg("could not invert function")
+			return 0, err
+		}
+		if fMid == phy {
+			return mid, nil
+		}
+		if (fMid < phy) == ascending {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	if isFloatDatatype(dte) {
+		return lo + (hi-lo)/2, nil
+	}
+	//choose the integer whose physical value is closest to phy
+	best, bestDiff := math.Round(lo), math.Inf(1)
+	for _, x := range []float64{math.Floor(lo), math.Ceil(lo), math.Floor(hi), math.Ceil(hi)} {
+		if fx, err := f(x); err == nil && math.Abs(fx-phy) < bestDiff {
+			best, bestDiff = x, math.Abs(fx-phy)
+		}
+	}
+	return best, nil
+}
+
+// findBracket searches an interval [lo, hi] within [lower, upper] whose function values enclose phy.
+// starting at f(0) the interval grows outward by doubling its width in both directions.
+// a direction is not extended any further once the limit of the datatype is reached or f is not finite anymore,
+// so functions that overflow at the limits of wide datatypes (e.g. X*2 for FLOAT64_IEEE) can still be inverted.
+func findBracket(f func(float64) (float64, error), phy float64, lower float64, upper float64) (float64, float64, float64, float64, bool) {
+	isFinite := func(v float64) bool { return !math.IsInf(v, 0) && !math.IsNaN(v) }
+	x0 := math.Min(math.Max(0, lower), upper)
+	f0, err := f(x0)
+	if err != nil || !isFinite(f0) {
+		return 0, 0, 0, 0, false
+	}
+	lo, hi, fLo, fHi := x0, x0, f0, f0
+	loDone, hiDone := lo <= lower, hi >= upper
+	for step := 1.0; ; step *= 2 {
+		if (fLo-phy)*(fHi-phy) <= 0 {
+			return lo, hi, fLo, fHi, true
+		}
+		if loDone && hiDone {
+			return lo, hi, fLo, fHi, false
+		}
+		if !hiDone {
+			x := math.Min(x0+step, upper)
+			fx, err := f(x)
+			if err != nil || !isFinite(fx) {
+				hiDone = true
+			} else {
+				hi, fHi, hiDone = x, fx, x >= upper
+			}
+		}
+		if !loDone {
+			x := math.Max(x0-step, lower)
+			fx, err := f(x)
+			if err != nil || !isFinite(fx) {
+				loDone = true
+			} else {
+				lo, fLo, loDone = x, fx, x <= lower
+			}
+		}
+	}
+}
+
+// calcTabIntpInv looks up the physical value within the output values of the conversion table
+// and interpolates the input value linearly between the two enclosing entries.
+func calcTabIntpInv(phy float64, cm *a2l.CompuMethod, cd *CalibrationData) (float64, error) {
+	tab, exists := cd.A2l.Project.Modules[cd.ModuleIndex].CompuTabs[cm.CompuTabRef.ConversionTable]
+	if !exists || !tab.InValSet || !tab.OutValSet {
+		err := errors.New("conversion table " + cm.CompuTabRef.ConversionTable + " not found for compuMethod: " + cm.Name)
+		log.Err(err).Msg("physical value could not be converted")
+		return 0, err
+	}
+	for i := range tab.OutVal {
+		if tab.OutVal[i] == phy {
+			return tab.InVal[i], nil
+		}
+		if i+1 >= len(tab.OutVal) || i+1 >= len(tab.InVal) {
+			break
+		}
+		lo, hi := tab.OutVal[i], tab.OutVal[i+1]
+		if (lo < phy && phy < hi) || (hi < phy && phy < lo) {
+			return tab.InVal[i] + (phy-lo)*(tab.InVal[i+1]-tab.InVal[i])/(hi-lo), nil
+		}
+	}
+	err := errors.New("value " + strconv.FormatFloat(phy, 'g', -1, 64) + " is outside of conversion table " + cm.CompuTabRef.ConversionTable)
+	log.Err(err).Msg("physical value could not be converted")
+	return 0, err
+}
+
+// calcTabNoIntpInv returns the input value of the conversion table entry whose output value equals the physical value.
+func calcTabNoIntpInv(phy float64, cm *a2l.CompuMethod, cd *CalibrationData) (float64, error) {
+	tab, exists := cd.A2l.Project.Modules[cd.ModuleIndex].CompuTabs[cm.CompuTabRef.ConversionTable]
+	if !exists || !tab.InValSet || !tab.OutValSet {
+		err := errors.New("conversion table " + cm.CompuTabRef.ConversionTable + " not found for compuMethod: " + cm.Name)
+		log.Err(err).Msg("physical value could not be converted")
+		return 0, err
+	}
+	for i := range tab.OutVal {
+		if tab.OutVal[i] == phy && i < len(tab.InVal) {
+			return tab.InVal[i], nil
+		}
+	}
+	err := errors.New("value " + strconv.FormatFloat(phy, 'g', -1, 64) + " not found in conversion table " + cm.CompuTabRef.ConversionTable)
+	log.Err(err).Msg("physical value could not be converted")
+	return 0, err
+}
+
+// calcTabVerbInv returns the input value of the verbal conversion table entry with the given display string.
+func calcTabVerbInv(phy string, vt *a2l.CompuVTab) (float64, error) {
+	for i := range vt.OutVal {
+		if trimQuotes(vt.OutVal[i]) == trimQuotes(phy) && i < len(vt.InVal) {
+			return vt.InVal[i], nil
+		}
+	}
+	err := errors.New("display string " + phy + " not found in conversion table " + vt.Name)
+	log.Err(err).Msg("physical value could not be converted")
+	return 0, err
+}
+
+// calcTabVerbRangeInv returns the lower limit of the range that is displayed with the given display string.
+func calcTabVerbRangeInv(phy string, vtr *a2l.CompuVTabRange) (float64, error) {
+	for i := range vtr.OutVal {
+		if trimQuotes(vtr.OutVal[i]) == trimQuotes(phy) && i < len(vtr.InValMin) {
+			return vtr.InValMin[i], nil
+		}
+	}
+	err := errors.New("display string " + phy + " not found in conversion table " + vtr.Name)
+	log.Err(err).Msg("physical value could not be converted")
+	return 0, err
+}
+
+// trimQuotes removes the quotes the a2l parser keeps around strings.
+func trimQuotes(s string) string {
+	return strings.Trim(strings.TrimSpace(s), "\"")
+}
+
+// RangeError is returned if a decimal value exceeds the range of its datatype and has been saturated.
+type RangeError struct {
+	//Value is the decimal value before it has been saturated
+	Value    float64
+	Datatype a2l.DataTypeEnum
+}
+
+func (e *RangeError) Error() string {
+	return "value " + strconv.FormatFloat(e.Value, 'g', -1, 64) + " exceeds range of datatype " + e.Datatype.String()
+}
+
+// saturateToDatatype rounds the decimal value to an integer for integer datatypes and limits it to the range of the datatype.
+// a *RangeError is returned along with the saturated value if the value exceeds the range.
+func saturateToDatatype(dec float64, dte a2l.DataTypeEnum) (float64, error) {
+	min, max, err := getDatatypeRange(dte)
+	if err != nil {
+		log.Err(err).Msg("value could not be saturated")
+		return 0, err
+	}
+	if math.IsNaN(dec) {
+		err = errors.New("NaN cannot be represented by datatype " + dte.String())
+		log.Err(err).Msg("value could not be saturated")
+		return 0, err
+	}
+	if !isFloatDatatype(dte) {
+		dec = math.Round(dec)
+	}
+	if dec < min || dec > max {
+		err = &RangeError{Value: dec, Datatype: dte}
+		log.Err(err).Msg("value saturated")
+		return math.Max(min, math.Min(max, dec)), err
+	}
+	return dec, nil
+}
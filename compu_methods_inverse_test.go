@@ -0,0 +1,84 @@
+package calibrationReader
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestInverseConversion(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	tests := []struct {
+		conversion string
+		dte        a2l.DataTypeEnum
+		phy        interface{}
+		expected   string
+	}{
+		{conversion: "NO_COMPU_METHOD", dte: a2l.SWORD, phy: []float64{-2.4, 7.5}, expected: "[-2 8]"},
+		{conversion: "CM.LINEAR.MUL_2", dte: a2l.SWORD, phy: []float64{4, 7}, expected: "[2 4]"},
+		{conversion: "CM.RAT_FUNC.DIV_10", dte: a2l.SWORD, phy: 0.2, expected: "[2]"},
+		{conversion: "CM.RAT_FUNC.HYPERBOLIC", dte: a2l.Float32Ieee, phy: 4.0, expected: "[0.25]"},
+		//FORMULA_INV
+		{conversion: "CM.FORM.X_PLUS_4", dte: a2l.SWORD, phy: 10.0, expected: "[6]"},
+		//numeric inversion of 4*X1
+		{conversion: "CM.VIRTUAL.EXTERNAL_VALUE", dte: a2l.SWORD, phy: []float64{-400, 13}, expected: "[-100 3]"},
+		{conversion: "CM.TAB_INTP.NO_DEFAULT_VALUE", dte: a2l.SWORD, phy: []float64{98, 103, 110.5}, expected: "[-3 3 12]"},
+		{conversion: "CM.TAB_NOINTP.NO_DEFAULT_VALUE", dte: a2l.SWORD, phy: 111.0, expected: "[13]"},
+		{conversion: "CM.TAB_VERB.DEFAULT_VALUE", dte: a2l.UBYTE, phy: []string{"Square", "\"Sinus\""}, expected: "[2 3]"},
+		{conversion: "CM.VTAB_RANGE.DEFAULT_VALUE", dte: a2l.UBYTE, phy: "four_to_seven", expected: "[4]"},
+	}
+	for _, tt := range tests {
+		dec, err := cd.ConvertPhysicalToDecimal(tt.phy, tt.conversion, tt.dte)
+		if err != nil {
+			t.Errorf("%s: %s", tt.conversion, err)
+		} else if fmt.Sprint(dec) != tt.expected {
+			t.Errorf("%s: expected %s, got %v", tt.conversion, tt.expected, dec)
+		}
+	}
+	//numeric inversion of FORM methods must not fail for wide floating point datatypes whose limits overflow the formula
+	for _, dte := range []a2l.DataTypeEnum{a2l.Float32Ieee, a2l.Float64Ieee} {
+		dec, err := cd.ConvertPhysicalToDecimal([]float64{-400, 13, 0.5}, "CM.VIRTUAL.EXTERNAL_VALUE", dte)
+		if err != nil {
+			t.Errorf("%s: %s", dte.String(), err)
+			continue
+		}
+		for i, expected := range []float64{-100, 3.25, 0.125} {
+			if math.Abs(dec[i]-expected) > 1e-9 {
+				t.Errorf("%s: expected %v, got %v", dte.String(), expected, dec[i])
+			}
+		}
+	}
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	c := m.Characteristics["ASAM.C.SCALAR.FLOAT64_IEEE.IDENTICAL"]
+	c.Conversion = "CM.VIRTUAL.EXTERNAL_VALUE"
+	m.Characteristics[c.Name] = c
+	if err := cd.SetCharacteristicPhysical(c.Name, CharacteristicValues{PhyValues: 13.0}); err != nil {
+		t.Errorf("could not set FLOAT64_IEEE characteristic with FORM conversion: %s", err)
+	} else if cv, err := cd.GetCharacteristicValues(c.Name); err != nil || cv.RawValues.(float64) != 3.25 {
+		t.Errorf("expected raw value 3.25, got %v, %v", cv.RawValues, err)
+	}
+	//values exceeding the datatype are saturated and reported
+	dec, err := cd.ConvertPhysicalToDecimal([]float64{-10, 300}, "CM.LINEAR.IDENT", a2l.UBYTE)
+	if err == nil || fmt.Sprint(dec) != "[0 255]" {
+		t.Errorf("expected saturated values and an error, got %v, %v", dec, err)
+	}
+	for _, tt := range []struct {
+		conversion string
+		phy        interface{}
+	}{
+		{conversion: "CM.TAB_INTP.NO_DEFAULT_VALUE", phy: 97.0},
+		{conversion: "CM.TAB_NOINTP.NO_DEFAULT_VALUE", phy: 101.0},
+		{conversion: "CM.TAB_VERB.NO_DEFAULT_VALUE", phy: "black"},
+		{conversion: "CM.TAB_VERB.NO_DEFAULT_VALUE", phy: 3.0},
+		{conversion: "CM.LINEAR.IDENT", phy: "red"},
+	} {
+		if _, err := cd.ConvertPhysicalToDecimal(tt.phy, tt.conversion, a2l.SWORD); err == nil {
+			t.Errorf("%s: expected error for %v", tt.conversion, tt.phy)
+		}
+	}
+}
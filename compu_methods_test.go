@@ -0,0 +1,115 @@
+package calibrationReader
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestConvertToDisplay(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	//COMPU_TAB without numeric default value but with a display string for values that are not part of it
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	tab := m.CompuTabs["CM.TAB_NOINTP.NO_DEFAULT_VALUE.REF"]
+	tab.Name = "CM.TAB_NOINTP.STRING_DEFAULT.REF"
+	tab.DefaultValue = a2l.DefaultValue{DisplayString: "\"n/a\"", DisplayStringSet: true}
+	m.CompuTabs[tab.Name] = tab
+	cm := m.CompuMethods["CM.TAB_NOINTP.NO_DEFAULT_VALUE"]
+	cm.Name = "CM.TAB_NOINTP.STRING_DEFAULT"
+	cm.CompuTabRef.ConversionTable = tab.Name
+	m.CompuMethods[cm.Name] = cm
+
+	tests := []struct {
+		conversion string
+		dte        a2l.DataTypeEnum
+		dec        float64
+		expected   interface{}
+	}{
+		{conversion: "CM.LINEAR.MUL_2", dte: a2l.SWORD, dec: 3, expected: 6.0},
+		//interpolation with clamping outside of the table
+		{conversion: "CM.TAB_INTP.NO_DEFAULT_VALUE", dte: a2l.SWORD, dec: -5, expected: 98.0},
+		{conversion: "CM.TAB_INTP.NO_DEFAULT_VALUE", dte: a2l.SWORD, dec: 1, expected: 101.0},
+		{conversion: "CM.TAB_INTP.NO_DEFAULT_VALUE", dte: a2l.Float32Ieee, dec: 11.5, expected: 110.5},
+		{conversion: "CM.TAB_INTP.DEFAULT_VALUE", dte: a2l.SWORD, dec: 20, expected: 111.0},
+		{conversion: "CM.TAB_NOINTP.DEFAULT_VALUE", dte: a2l.SWORD, dec: 2, expected: 102.0},
+		{conversion: "CM.TAB_NOINTP.DEFAULT_VALUE", dte: a2l.SWORD, dec: 3, expected: 300.56},
+		{conversion: "CM.TAB_NOINTP.STRING_DEFAULT", dte: a2l.SWORD, dec: 4, expected: 104.0},
+		{conversion: "CM.TAB_NOINTP.STRING_DEFAULT", dte: a2l.SWORD, dec: 3, expected: "n/a"},
+		{conversion: "CM.TAB_VERB.DEFAULT_VALUE", dte: a2l.UBYTE, dec: 2, expected: "Square"},
+		{conversion: "CM.TAB_VERB.DEFAULT_VALUE", dte: a2l.UBYTE, dec: 9, expected: "unknown signal type"},
+		//integer ranges include their upper limit, floating point ranges exclude it
+		{conversion: "CM.VTAB_RANGE.DEFAULT_VALUE", dte: a2l.UBYTE, dec: 3, expected: "two_to_three"},
+		{conversion: "CM.VTAB_RANGE.DEFAULT_VALUE", dte: a2l.Float32Ieee, dec: 2.5, expected: "two_to_three"},
+		{conversion: "CM.VTAB_RANGE.DEFAULT_VALUE", dte: a2l.Float32Ieee, dec: 3, expected: "out of range value"},
+		{conversion: "CM.VTAB_RANGE.DEFAULT_VALUE", dte: a2l.Float32Ieee, dec: 100, expected: "hundred"},
+		{conversion: "CM.VTAB_RANGE.DEFAULT_VALUE", dte: a2l.UBYTE, dec: 10, expected: "out of range value"},
+	}
+	for _, tt := range tests {
+		v, err := cd.ConvertToDisplay(tt.dec, tt.conversion, tt.dte)
+		if err != nil {
+			t.Errorf("%s(%v): %s", tt.conversion, tt.dec, err)
+		} else if v != tt.expected {
+			t.Errorf("%s(%v): expected %v, got %v", tt.conversion, tt.dec, tt.expected, v)
+		}
+	}
+	for _, conversion := range []string{"CM.TAB_NOINTP.NO_DEFAULT_VALUE", "CM.TAB_VERB.NO_DEFAULT_VALUE", "CM.VTAB_RANGE.NO_DEFAULT_VALUE"} {
+		if _, err := cd.ConvertToDisplay(11, conversion, a2l.UBYTE); err == nil {
+			t.Errorf("%s: expected error for value without table entry", conversion)
+		}
+	}
+	//mixed numeric and verbal results are returned as strings
+	phy, err := cd.convertValuesToPhysical([]float64{4, 3}, "CM.TAB_NOINTP.STRING_DEFAULT", a2l.SWORD)
+	if err != nil || fmt.Sprint(phy) != "[104 n/a]" {
+		t.Errorf("expected mixed values as strings, got %v, %v", phy, err)
+	}
+}
+
+func TestStatusStringRef(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	//COMPU_VTAB_RANGE as status string table
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	cm := m.CompuMethods["CM.LINEAR.MUL_2"]
+	cm.Name = "CM.LINEAR.MUL_2.STATUS_RANGE"
+	cm.StatusStringRef = a2l.StatusStringRef{ConversionTable: "CM.VTAB_RANGE.DEFAULT_VALUE.REF", ConversionTableSet: true}
+	m.CompuMethods[cm.Name] = cm
+	if _, exists := m.CompuVTabRanges[cm.StatusStringRef.ConversionTable]; !exists {
+		t.Fatalf("conversion table %s not found", cm.StatusStringRef.ConversionTable)
+	}
+
+	tests := []struct {
+		conversion string
+		dec        float64
+		expected   PhysicalValue
+	}{
+		{conversion: "CM.LINEAR.IDENT.STATUS_STRING", dec: 5, expected: PhysicalValue{Value: 5}},
+		{conversion: "CM.LINEAR.IDENT.STATUS_STRING", dec: 252, expected: PhysicalValue{Value: 252}},
+		{conversion: "CM.LINEAR.IDENT.STATUS_STRING", dec: 255, expected: PhysicalValue{Status: "Sensor defect", IsStatus: true}},
+		{conversion: "CM.RAT_FUNC.IDENT.STATUS_STRING", dec: 17, expected: PhysicalValue{Value: 17}},
+		{conversion: "CM.RAT_FUNC.IDENT.STATUS_STRING", dec: 254, expected: PhysicalValue{Status: "Sensor not connected", IsStatus: true}},
+		//the default value of the status string table is ignored
+		{conversion: "CM.LINEAR.MUL_2.STATUS_RANGE", dec: 10, expected: PhysicalValue{Value: 20}},
+		{conversion: "CM.LINEAR.MUL_2.STATUS_RANGE", dec: 3, expected: PhysicalValue{Status: "two_to_three", IsStatus: true}},
+		{conversion: "CM.LINEAR.MUL_2", dec: 255, expected: PhysicalValue{Value: 510}},
+	}
+	for _, tt := range tests {
+		pv, err := cd.ConvertToPhysical(tt.dec, tt.conversion, a2l.UBYTE)
+		if err != nil {
+			t.Errorf("%s(%v): %s", tt.conversion, tt.dec, err)
+		} else if pv != tt.expected {
+			t.Errorf("%s(%v): expected %+v, got %+v", tt.conversion, tt.dec, tt.expected, pv)
+		}
+	}
+	if v, err := cd.ConvertToDisplay(253, "CM.LINEAR.IDENT.STATUS_STRING", a2l.UBYTE); err != nil || v != "Sensor not calibrated" {
+		t.Errorf("expected status text, got %v, %v", v, err)
+	}
+	phy, err := cd.convertValuesToPhysical([]float64{1, 255}, "CM.LINEAR.IDENT.STATUS_STRING", a2l.UBYTE)
+	if err != nil || fmt.Sprint(phy) != "[1 Sensor defect]" {
+		t.Errorf("expected mixed values as strings, got %v, %v", phy, err)
+	}
+}
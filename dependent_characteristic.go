@@ -0,0 +1,335 @@
+package calibrationReader
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
+)
+
+// DependentCharacteristicCheck compares the values of a DEPENDENT_CHARACTERISTIC as stored in the hex file
+// with the values computed from its referenced characteristics.
+type DependentCharacteristicCheck struct {
+	Name       string
+	Formula    string
+	References []string
+	//Computed contains the physical values computed with the formula in ROW_DIR order
+	Computed []float64
+	//Stored contains the physical values as stored in the hex file in ROW_DIR order
+	Stored []float64
+	//Consistent is true if the computed values are stored in the hex file
+	//after they have been converted to the decimal representation of the dependent characteristic
+	Consistent bool
+	//Err is set if the dependent characteristic could not be checked
+	Err error
+}
+
+// ComputeDependentCharacteristic computes the physical values of the dependent characteristic with the given name
+// from the physical values of its referenced characteristics X1..Xn.
+// the formula is applied element wise in ROW_DIR order. referenced characteristics either have one value
+// that is used for all elements or the same number of values as the dependent characteristic.
+func (cd *CalibrationData) ComputeDependentCharacteristic(name string) ([]float64, error) {
+	cv, err := cd.GetCharacteristicValues(name)
+	if err != nil {
+		log.Err(err).Msg("could not compute dependent characteristic " + name)
+		return nil, err
+	}
+	return cd.computeDependentValues(cv.characteristic, len(cv.fncValues))
+}
+
+// CheckDependentCharacteristic computes the values of the dependent characteristic with the given name
+// and compares them with the values stored in the hex file.
+func (cd *CalibrationData) CheckDependentCharacteristic(name string) (DependentCharacteristicCheck, error) {
+	dcc := DependentCharacteristicCheck{Name: name}
+	cv, err := cd.GetCharacteristicValues(name)
+	if err != nil {
+		log.Err(err).Msg("could not check dependent characteristic " + name)
+		return dcc, err
+	}
+	dc, err := getDependentCharacteristic(cv.characteristic)
+	if err != nil {
+		log.Err(err).Msg("could not check dependent characteristic " + name)
+		return dcc, err
+	}
+	dcc.Formula, dcc.References = trimQuotes(dc.Formula), dc.Characteristic
+	dcc.Computed, err = cd.computeDependentValues(cv.characteristic, len(cv.fncValues))
+	if err != nil {
+		log.Err(err).Msg("could not check dependent characteristic " + name)
+		return dcc, err
+	}
+	dcc.Stored, err = cd.getNumericPhyValues(&cv)
+	if err != nil {
+		log.Err(err).Msg("could not check dependent characteristic " + name)
+		return dcc, err
+	}
+	dec, err := cd.ConvertPhysicalToDecimal(dcc.Computed, cv.characteristic.Conversion, cv.recordLayout.FncValues.Datatype)
+	var rangeErr *RangeError
+	if err != nil && !errors.As(err, &rangeErr) {
+		log.Err(err).Msg("could not check dependent characteristic " + name)
+		return dcc, err
+	}
+	//values that exceed the range of the datatype cannot be stored at all
+	dcc.Consistent = rangeErr == nil
+	bo := cv.getByteOrder(cd, -1)
+	for i := range dec {
+		stored, err := encodeDatatype(cv.fncValues[i], cv.recordLayout.FncValues.Datatype, bo)
+		if err != nil {
+			log.Err(err).Msg("could not check dependent characteristic " + name)
+			return dcc, err
+		}
+		computed, err := encodeDatatype(dec[i], cv.recordLayout.FncValues.Datatype, bo)
+		if err != nil {
+			log.Err(err).Msg("could not check dependent characteristic " + name)
+			return dcc, err
+		}
+		if string(stored) != string(computed) {
+			dcc.Consistent = false
+		}
+	}
+	return dcc, nil
+}
+
+// CheckDependentCharacteristics checks all characteristics that define a DEPENDENT_CHARACTERISTIC sorted by their name.
+// dependent characteristics that cannot be checked are reported with Err set.
+func (cd *CalibrationData) CheckDependentCharacteristics() []DependentCharacteristicCheck {
+	var names []string
+	for name, c := range cd.A2l.Project.Modules[cd.ModuleIndex].Characteristics {
+		if len(c.DependentCharacteristic) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	checks := make([]DependentCharacteristicCheck, 0, len(names))
+	for _, name := range names {
+		dcc, err := cd.CheckDependentCharacteristic(name)
+		dcc.Err = err
+		checks = append(checks, dcc)
+	}
+	return checks
+}
+
+// PropagateDependentCharacteristics recomputes all dependent characteristics that directly or indirectly reference
+// the characteristic with the given name and writes their new values to the hex file.
+// the dependent characteristics are updated in topological order, so every dependent characteristic is computed
+// after all of its references have been updated. the names of the updated characteristics are returned in that order.
+// virtual characteristics in between are not written as their values follow from their references.
+func (cd *CalibrationData) PropagateDependentCharacteristics(name string) ([]string, error) {
+	if _, exists := cd.A2l.Project.Modules[cd.ModuleIndex].Characteristics[name]; !exists {
+		err := errors.New("characteristic " + name + " not found")
+		log.Err(err).Msg("could not propagate changes")
+		return nil, err
+	}
+	dependents := cd.getDependentsMap()
+	order, err := sortDependents(name, dependents)
+	if err != nil {
+		log.Err(err).Msg("could not propagate changes of characteristic " + name)
+		return nil, err
+	}
+	updated := make([]string, 0, len(order))
+	for _, d := range order {
+		//virtual characteristics are computed on demand and have no memory to update
+		if len(cd.A2l.Project.Modules[cd.ModuleIndex].Characteristics[d].VirtualCharacteristic) > 0 {
+			continue
+		}
+		err = cd.updateDependentCharacteristic(d)
+		if err != nil {
+			log.Err(err).Msg("could not propagate changes of characteristic " + name)
+			return updated, err
+		}
+		updated = append(updated, d)
+	}
+	return updated, nil
+}
+
+// updateDependentCharacteristic computes the values of the dependent characteristic and writes them to the hex file.
+// the decimal values are written like SetCharacteristicRaw, so they are checked against the limits and verified by reading them back.
+func (cd *CalibrationData) updateDependentCharacteristic(name string) error {
+	cv, err := cd.GetCharacteristicValues(name)
+	if err != nil {
+		log.Err(err).Msg("could not update dependent characteristic " + name)
+		return err
+	}
+	phy, err := cd.computeDependentValues(cv.characteristic, len(cv.fncValues))
+	if err != nil {
+		log.Err(err).Msg("could not update dependent characteristic " + name)
+		return err
+	}
+	dec, err := cd.ConvertPhysicalToDecimal(phy, cv.characteristic.Conversion, cv.recordLayout.FncValues.Datatype)
+	if err != nil {
+		log.Err(err).Msg("could not update dependent characteristic " + name)
+		return err
+	}
+	raw, err := shapeValues(dec, cv.Dimensions)
+	if err != nil {
+		log.Err(err).Msg("could not update dependent characteristic " + name)
+		return err
+	}
+	err = cd.setCharacteristic(name, CharacteristicValues{RawValues: raw}, false)
+	if err != nil {
+		log.Err(err).Msg("could not update dependent characteristic " + name)
+		return err
+	}
+	return nil
+}
+
+// computeDependentValues evaluates the formula of the dependent characteristic for each of its n values.
+func (cd *CalibrationData) computeDependentValues(c *a2l.Characteristic, n int) ([]float64, error) {
+	dc, err := getDependentCharacteristic(c)
+	if err != nil {
+		log.Err(err).Msg("could not compute dependent characteristic")
+		return nil, err
+	}
+	_, refs, err := cd.readReferences(c.Name, dc.Characteristic, nil)
+	if err != nil {
+		log.Err(err).Msg("could not compute dependent characteristic")
+		return nil, err
+	}
+	return cd.evalElementWise(c.Name, trimQuotes(dc.Formula), refs, n)
+}
+
+// readReferences reads the characteristics referenced by the formula of a dependent or virtual characteristic
+// and returns them together with their physical values in ROW_DIR order.
+func (cd *CalibrationData) readReferences(name string, references []string, resolving []string) ([]CharacteristicValues, [][]float64, error) {
+	cvs := make([]CharacteristicValues, len(references))
+	refs := make([][]float64, len(references))
+	for i, ref := range references {
+		var err error
+		cvs[i], err = cd.getCharacteristicValues(ref, resolving)
+		if err != nil {
+			log.Err(err).Msg("could not read reference X" + strconv.Itoa(i+1) + " of characteristic " + name)
+			return nil, nil, err
+		}
+		refs[i], err = cd.getNumericPhyValues(&cvs[i])
+		if err != nil {
+			log.Err(err).Msg("could not read reference X" + strconv.Itoa(i+1) + " of characteristic " + name)
+			return nil, nil, err
+		}
+	}
+	return cvs, refs, nil
+}
+
+// evalElementWise evaluates the formula for each of the n values of a characteristic.
+// references either have one value that is used for all elements or n values that are used element wise.
+func (cd *CalibrationData) evalElementWise(name string, formula string, refs [][]float64, n int) ([]float64, error) {
+	for i, r := range refs {
+		if len(r) != 1 && len(r) != n {
+			err := errors.New("reference X" + strconv.Itoa(i+1) + " has " + strconv.Itoa(len(r)) + " values, characteristic " + name + " has " + strconv.Itoa(n))
+			log.Err(err).Msg("could not evaluate formula " + formula)
+			return nil, err
+		}
+	}
+	phy := make([]float64, n)
+	x := make([]float64, len(refs))
+	for i := range phy {
+		for j, r := range refs {
+			if len(r) == 1 {
+				x[j] = r[0]
+			} else {
+				x[j] = r[i]
+			}
+		}
+		var err error
+		phy[i], err = cd.evalFormula(formula, x...)
+		if err != nil {
+			log.Err(err).Msg("could not compute value " + strconv.Itoa(i) + " of characteristic " + name)
+			return nil, err
+		}
+	}
+	return phy, nil
+}
+
+// getDependentCharacteristic returns the DEPENDENT_CHARACTERISTIC of the characteristic.
+func getDependentCharacteristic(c *a2l.Characteristic) (a2l.DependentCharacteristic, error) {
+	if len(c.DependentCharacteristic) == 0 || !c.DependentCharacteristic[0].FormulaSet {
+		err := errors.New("characteristic " + c.Name + " is not a dependent characteristic")
+		log.Err(err).Msg("could not get dependent characteristic")
+		return a2l.DependentCharacteristic{}, err
+	}
+	return c.DependentCharacteristic[0], nil
+}
+
+// getDependentsMap maps the name of each characteristic to the dependent and virtual characteristics that reference it.
+// virtual characteristics are part of the graph as dependent characteristics may reference them in turn.
+func (cd *CalibrationData) getDependentsMap() map[string][]string {
+	dependents := make(map[string][]string)
+	for name, c := range cd.A2l.Project.Modules[cd.ModuleIndex].Characteristics {
+		for _, dc := range c.DependentCharacteristic {
+			for _, ref := range dc.Characteristic {
+				dependents[ref] = append(dependents[ref], name)
+			}
+		}
+		for _, vc := range c.VirtualCharacteristic {
+			for _, ref := range vc.Characteristic {
+				dependents[ref] = append(dependents[ref], name)
+			}
+		}
+	}
+	for ref := range dependents {
+		sort.Strings(dependents[ref])
+	}
+	return dependents
+}
+
+// sortDependents returns all characteristics that directly or indirectly depend on the given one in topological order.
+// an error is returned if the dependencies contain a cycle.
+func sortDependents(name string, dependents map[string][]string) ([]string, error) {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+	var postOrder []string
+	var visit func(n string) error
+	visit = func(n string) error {
+		switch state[n] {
+		case visiting:
+			err := errors.New("cyclic dependency of dependent characteristic " + n)
+			log.Err(err).Msg("could not sort dependent characteristics")
+			return err
+		case done:
+			return nil
+		}
+		state[n] = visiting
+		for _, d := range dependents[n] {
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
+		state[n] = done
+		postOrder = append(postOrder, n)
+		return nil
+	}
+	if err := visit(name); err != nil {
+		return nil, err
+	}
+	//reverse post order without the changed characteristic itself
+	order := make([]string, 0, len(postOrder)-1)
+	for i := len(postOrder) - 2; i >= 0; i-- {
+		order = append(order, postOrder[i])
+	}
+	return order, nil
+}
+
+// getNumericPhyValues converts the function values of the characteristic into physical values in ROW_DIR order.
+// an error is returned for verbal conversions and ASCII characteristics.
+func (cd *CalibrationData) getNumericPhyValues(cv *CharacteristicValues) ([]float64, error) {
+	if cv.characteristic.Type == a2l.ASCII {
+		err := errors.New("ASCII characteristic " + cv.Name + " has no numeric values")
+		log.Err(err).Msg("could not get physical values")
+		return nil, err
+	}
+	phy, err := cd.convertValuesToPhysical(cv.fncValues, cv.characteristic.Conversion, cv.recordLayout.FncValues.Datatype)
+	if err != nil {
+		log.Err(err).Msg("could not get physical values of characteristic " + cv.Name)
+		return nil, err
+	}
+	numeric, isNumeric := phy.([]float64)
+	if !isNumeric {
+		err = errors.New("characteristic " + cv.Name + " has verbal values")
+		log.Err(err).Msg("could not get physical values")
+		return nil, err
+	}
+	return numeric, nil
+}
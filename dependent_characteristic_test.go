@@ -0,0 +1,68 @@
+package calibrationReader
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestDependentCharacteristics(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	m.ModPar.SystemConstants["\"System_Constant_1\""] = a2l.SystemConstant{Name: "\"System_Constant_1\"", NameSet: true, Value: "\"-3.45\"", ValueSet: true}
+
+	//the demo hex file is consistent
+	for _, dcc := range cd.CheckDependentCharacteristics() {
+		if dcc.Err == nil && !dcc.Consistent {
+			t.Errorf("%s: expected %v, stored %v", dcc.Name, dcc.Computed, dcc.Stored)
+		}
+	}
+	v, err := cd.ComputeDependentCharacteristic("ASAM.C.DEPENDENT.REF_3.SWORD")
+	if err != nil || fmt.Sprint(v) != "[56]" {
+		t.Errorf("expected REF_3 to be 56, got %v, %v", v, err)
+	}
+
+	//change the source of REF_1 (X1 + 5), which is itself referenced by REF_3 (X1 + X2) and REF_4 (X1 + sysc)
+	source := "ASAM.C.SCALAR.SBYTE.IDENTICAL"
+	if err = cd.SetCharacteristicRaw(source, CharacteristicValues{RawValues: -20.0}); err != nil {
+		t.Fatalf("could not write %s: %s", source, err)
+	}
+	dcc, err := cd.CheckDependentCharacteristic("ASAM.C.DEPENDENT.REF_1.SWORD")
+	if err != nil || dcc.Consistent || fmt.Sprint(dcc.Computed) != "[-15]" {
+		t.Errorf("expected REF_1 to be inconsistent, got %+v, %v", dcc, err)
+	}
+	updated, err := cd.PropagateDependentCharacteristics(source)
+	if err != nil {
+		t.Fatalf("could not propagate changes: %s", err)
+	}
+	pos := make(map[string]int, len(updated))
+	for i, u := range updated {
+		pos[u] = i
+	}
+	if _, exists := pos["ASAM.C.DEPENDENT.REF_3.SWORD"]; !exists || pos["ASAM.C.DEPENDENT.REF_1.SWORD"] > pos["ASAM.C.DEPENDENT.REF_3.SWORD"] {
+		t.Errorf("expected REF_1 to be updated before REF_3, got %v", updated)
+	}
+	for _, dcc := range cd.CheckDependentCharacteristics() {
+		if dcc.Err == nil && !dcc.Consistent {
+			t.Errorf("%s not updated: expected %v, stored %v", dcc.Name, dcc.Computed, dcc.Stored)
+		}
+	}
+	for name, expected := range map[string]string{"ASAM.C.DEPENDENT.REF_1.SWORD": "-15", "ASAM.C.DEPENDENT.REF_3.SWORD": "30", "ASAM.C.DEPENDENT.REF_4.FLOAT64_IEEE": "-18.45"} {
+		cv, err := cd.GetCharacteristicValues(name)
+		if err != nil || fmt.Sprint(cv.PhyValues) != expected {
+			t.Errorf("%s: expected %s, got %v, %v", name, expected, cv.PhyValues, err)
+		}
+	}
+
+	//dependencies are sorted topologically and cycles are detected
+	order, err := sortDependents("a", map[string][]string{"a": {"b", "c"}, "b": {"c"}, "c": {"d"}})
+	if err != nil || fmt.Sprint(order) != "[b c d]" {
+		t.Errorf("expected [b c d], got %v, %v", order, err)
+	}
+	if _, err = sortDependents("a", map[string][]string{"a": {"b"}, "b": {"a"}}); err == nil {
+		t.Errorf("expected cyclic dependency to be detected")
+	}
+}
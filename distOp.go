@@ -8,7 +8,7 @@ import (
 )
 
 // getDistOpX retrieves the distance operator according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getDistOpX(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getDistOpX(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.DistOpX.DatatypeSet {
 		err := errors.New("distOpX datatype not set")
 		log.Err(err).Msg("could not retrieve distOpX value")
@@ -19,17 +19,17 @@ func (cd *CalibrationData) getDistOpX(rl *a2l.RecordLayout, curPos *uint32) (int
 		log.Err(err).Msg("could not retrieve distOpX value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.DistOpX.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.DistOpX.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve distOpX value")
 		return 0, err
 	}
-	*curPos += uint32(rl.DistOpX.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.DistOpX.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // getDistOpY retrieves the distance operator according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getDistOpY(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getDistOpY(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.DistOpY.DatatypeSet {
 		err := errors.New("distOpY datatype not set")
 		log.Err(err).Msg("could not retrieve distOpY value")
@@ -40,17 +40,17 @@ func (cd *CalibrationData) getDistOpY(rl *a2l.RecordLayout, curPos *uint32) (int
 		log.Err(err).Msg("could not retrieve distOpY value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.DistOpY.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.DistOpY.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve distOpY value")
 		return 0, err
 	}
-	*curPos += uint32(rl.DistOpY.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.DistOpY.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // getDistOpZ retrieves the distance operator according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getDistOpZ(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getDistOpZ(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.DistOpZ.DatatypeSet {
 		err := errors.New("distOpZ datatype not set")
 		log.Err(err).Msg("could not retrieve distOpZ value")
@@ -61,17 +61,17 @@ func (cd *CalibrationData) getDistOpZ(rl *a2l.RecordLayout, curPos *uint32) (int
 		log.Err(err).Msg("could not retrieve distOpZ value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.DistOpZ.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.DistOpZ.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve distOpZ value")
 		return 0, err
 	}
-	*curPos += uint32(rl.DistOpZ.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.DistOpZ.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // getDistOp4 retrieves the distance operator according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getDistOp4(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getDistOp4(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.DistOp4.DatatypeSet {
 		err := errors.New("distOp4 datatype not set")
 		log.Err(err).Msg("could not retrieve distOp4 value")
@@ -82,17 +82,17 @@ func (cd *CalibrationData) getDistOp4(rl *a2l.RecordLayout, curPos *uint32) (int
 		log.Err(err).Msg("could not retrieve distOp4 value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.DistOp4.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.DistOp4.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve distOp4 value")
 		return 0, err
 	}
-	*curPos += uint32(rl.DistOp4.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.DistOp4.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // getDistOp5 retrieves the distance operator according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getDistOp5(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getDistOp5(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.DistOp5.DatatypeSet {
 		err := errors.New("distOp5 datatype not set")
 		log.Err(err).Msg("could not retrieve distOp5 value")
@@ -103,11 +103,11 @@ func (cd *CalibrationData) getDistOp5(rl *a2l.RecordLayout, curPos *uint32) (int
 		log.Err(err).Msg("could not retrieve distOp5 value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.DistOp5.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.DistOp5.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve distOp5 value")
 		return 0, err
 	}
-	*curPos += uint32(rl.DistOp5.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.DistOp5.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
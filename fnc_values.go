@@ -2,66 +2,256 @@ package calibrationReader
 
 import (
 	"errors"
+	"strconv"
 
 	"github.com/JustinasPuzas/calibrationReader/a2l"
 	"github.com/rs/zerolog/log"
 )
 
-// getFncValues1D retrieves Axis Points according to their layout specified within the record layout and their values as calibrated in the hex file
-func (cv *CharacteristicValues) getFncValues1D(cd *CalibrationData, rl *a2l.RecordLayout, curPos *uint32) (float64, []byte, error) {
-	var bufByte []byte
-	var bufFloat float64
-	var err error
-
-	bufByte, err = cd.getValue(curPos, rl.FncValues.Datatype, rl)
-	if err != nil {
-		log.Err(err).Msg("could not retrieve FncValues values")
-		return 0.0, bufByte, err
+// getFncValues retrieves the function values according to their layout specified within the record layout and their values as calibrated in the hex file.
+// the number of values is determined by the dimensions of the characteristic.
+// the values are returned in ROW_DIR order independent of the INDEX_MODE they are stored with.
+func (cv *CharacteristicValues) getFncValues(cd *CalibrationData, rl *a2l.RecordLayout, curPos *uint32) ([]float64, error) {
+	//check access type. DIRECT is the most used. Just read value from a given address.
+	//in case other access types are set this gets more complicated as either offsets or pointers are leveraged to
+	//define the position of the calibration objects.
+	if !rl.FncValues.DatatypeSet {
+		err := errors.New("fncValues datatype not set")
+		log.Err(err).Msg("could not retrieve fncValues of characteristic '" + cv.characteristic.Name + "'")
+		return nil, err
 	}
-	bufFloat, err = cd.convertByteSliceToDatatype(bufByte, rl.FncValues.Datatype)
+	if !rl.FncValues.AddresstypeSet {
+		rl.FncValues.Addresstype = a2l.DIRECT
+	}
+	dims, err := cv.getDimensions()
 	if err != nil {
-		log.Err(err).Msg("could not retrieve FncValues values")
-		return 0.0, bufByte, err
+		log.Err(err).Msg("could not determine number of fncValues of characteristic '" + cv.characteristic.Name + "'")
+		return nil, err
 	}
-	*curPos += uint32(rl.FncValues.Datatype.GetDatatypeLength())
-	return bufFloat, bufByte, err
-}
-
-// getFncValues2D retrieves Axis Points according to their layout specified within the record layout and their values as calibrated in the hex file
-func (cv *CharacteristicValues) getFncValues2D(cd *CalibrationData, rl *a2l.RecordLayout, curPos *uint32) ([]float64, error) {
+	noFncValues := 1
+	for _, d := range dims {
+		noFncValues *= d
+	}
+	cv.fncAddresses = nil
 	var val []float64
-	var bufByte []byte
-	var bufFloat float64
-	var err error
-	var i int64
-	var noFncValues int64
-	if rl.FixNoAxisPtsX.NumberOfAxisPointsSet {
-		noFncValues = int64(rl.FixNoAxisPtsX.NumberOfAxisPoints)
-	} else if !rl.FixNoAxisPtsX.NumberOfAxisPointsSet {
-		if cv.characteristic.MatrixDim.DimX <= 0 {
-			err = errors.New("number of FncValues is smaller or equal to zero")
-			log.Err(err).Msg("could not retrieve NoFncValues value")
+	var target uint32
+	switch rl.FncValues.Addresstype {
+	case a2l.DIRECT:
+		if isStaticRecordLayout(rl) && len(cv.characteristic.AxisDescr) > 0 {
+			//memory is reserved for the maximum number of axis points. the values are already in ROW_DIR order.
+			val, err = cv.getReservedFncValues(cd, rl, curPos, dims)
+			if err != nil {
+				log.Err(err).Msg("could not retrieve fncValues of characteristic '" + cv.characteristic.Name + "'")
+				return nil, err
+			}
+			return val, nil
+		}
+		val, err = cv.getFncValuesDirect(cd, rl, curPos, noFncValues)
+	case a2l.PBYTE, a2l.PWORD, a2l.PLONG, a2l.PLONGLONG:
+		//the deposit only contains a pointer to the first value. all others follow with incrementing address.
+		target, err = cd.getPointerTarget(curPos, rl.FncValues.Addresstype, rl, cv.getByteOrder(cd, -1))
+		if err != nil {
+			log.Err(err).Msg("could not dereference fncValues of characteristic '" + cv.characteristic.Name + "'")
 			return nil, err
 		}
-		//noFncValues = cv.noFncValuesXValue
-	} else {
-		err = errors.New("number of FncValues could not be determined")
-		log.Err(err).Msg("could not convert FncValues values")
+		val, err = cv.getFncValuesDirect(cd, rl, &target, noFncValues)
+	default:
+		//no valid address type
+		err = errors.New("invalid address type for fncValues")
+		log.Err(err).Msg("invalid address type for fncValues of characteristic '" + cv.characteristic.Name + "'")
+		return nil, err
+	}
+	if err != nil {
+		log.Err(err).Msg("could not retrieve fncValues of characteristic '" + cv.characteristic.Name + "'")
 		return nil, err
 	}
-	for i = 0; i < noFncValues; i++ {
-		bufByte, err = cd.getValue(curPos, rl.AxisPtsX.Datatype, rl)
+	order := getIndexModeOrder(rl.FncValues.IndexMode, len(dims))
+	cv.fncAddresses = reorderToRowDir(cv.fncAddresses, dims, order)
+	return reorderToRowDir(val, dims, order), nil
+}
+
+// getAlternatingValues reads function values that alternate with the axis points of the given axis (0 = x, 1 = y)
+// as defined by ALTERNATE_WITH_X and ALTERNATE_WITH_Y.
+// for each axis point the values sharing its coordinate are stored as one block.
+// whether the axis point precedes its block or follows it is determined by the positions of the fields within the record layout.
+// the function values are returned in ROW_DIR order, the axis points in ascending index order.
+func (cv *CharacteristicValues) getAlternatingValues(cd *CalibrationData, rl *a2l.RecordLayout, curPos *uint32, axisIndex int, axisFirst bool) ([]float64, []float64, error) {
+	var axisDatatype a2l.DataTypeEnum
+	var axisSet, indexDecr bool
+	switch axisIndex {
+	case 0:
+		axisDatatype = rl.AxisPtsX.Datatype
+		axisSet = rl.AxisPtsX.DatatypeSet
+		indexDecr = rl.AxisPtsX.IndexIncr == a2l.IndexDecr
+	case 1:
+		axisDatatype = rl.AxisPtsY.Datatype
+		axisSet = rl.AxisPtsY.DatatypeSet
+		indexDecr = rl.AxisPtsY.IndexIncr == a2l.IndexDecr
+	default:
+		err := errors.New("alternating values are only defined for x and y axis")
+		log.Err(err).Msg("could not retrieve alternating values of characteristic '" + cv.characteristic.Name + "'")
+		return nil, nil, err
+	}
+	if !axisSet {
+		err := errors.New("record layout " + rl.Name + " defines alternating values without the corresponding axis points")
+		log.Err(err).Msg("could not retrieve alternating values of characteristic '" + cv.characteristic.Name + "'")
+		return nil, nil, err
+	}
+	if rl.FncValues.AddresstypeSet && rl.FncValues.Addresstype != a2l.DIRECT {
+		err := errors.New("address type " + string(rl.FncValues.Addresstype) + " not supported for alternating values")
+		log.Err(err).Msg("could not retrieve alternating values of characteristic '" + cv.characteristic.Name + "'")
+		return nil, nil, err
+	}
+	dims, err := cv.getDimensions()
+	if err != nil {
+		log.Err(err).Msg("could not determine number of fncValues of characteristic '" + cv.characteristic.Name + "'")
+		return nil, nil, err
+	}
+	if axisIndex >= len(dims) {
+		err = errors.New("characteristic has no axis " + strconv.Itoa(axisIndex) + " to alternate with")
+		log.Err(err).Msg("could not retrieve alternating values of characteristic '" + cv.characteristic.Name + "'")
+		return nil, nil, err
+	}
+	blockSize := 1
+	for i, d := range dims {
+		if i != axisIndex {
+			blockSize *= d
+		}
+	}
+	cv.fncAddresses = nil
+	axis := make([]float64, 0, dims[axisIndex])
+	axisAddresses := make([]uint32, 0, dims[axisIndex])
+	val := make([]float64, 0, blockSize*dims[axisIndex])
+	for i := 0; i < dims[axisIndex]; i++ {
+		if !axisFirst {
+			block, err := cv.getFncValuesDirect(cd, rl, curPos, blockSize)
+			if err != nil {
+				log.Err(err).Msg("could not retrieve alternating values of characteristic '" + cv.characteristic.Name + "'")
+				return nil, nil, err
+			}
+			val = append(val, block...)
+		}
+		pt, addr, err := cd.readAxisPoints(rl, curPos, axisDatatype, a2l.DIRECT, 1, cv.getByteOrder(cd, axisIndex))
+		if err != nil {
+			log.Err(err).Msg("could not retrieve alternating axis points of characteristic '" + cv.characteristic.Name + "'")
+			return nil, nil, err
+		}
+		axis = append(axis, pt...)
+		axisAddresses = append(axisAddresses, addr...)
+		if axisFirst {
+			block, err := cv.getFncValuesDirect(cd, rl, curPos, blockSize)
+			if err != nil {
+				log.Err(err).Msg("could not retrieve alternating values of characteristic '" + cv.characteristic.Name + "'")
+				return nil, nil, err
+			}
+			val = append(val, block...)
+		}
+	}
+	if indexDecr {
+		reverseSlice(axis)
+		reverseSlice(axisAddresses)
+	}
+	cv.axisAddresses[axisIndex] = axisAddresses
+	order := getIndexModeOrder(rl.FncValues.IndexMode, len(dims))
+	cv.fncAddresses = reorderToRowDir(cv.fncAddresses, dims, order)
+	return axis, reorderToRowDir(val, dims, order), nil
+}
+
+// getIndexModeOrder returns the order in which the dimensions change within memory, starting with the fastest changing one.
+// ROW_DIR:				x, y, z, 4, 5
+// COLUMN_DIR:			y, x, z, 4, 5
+// ALTERNATE_CURVES:	y, z, 4, 5, x (curves along x sharing the x axis are stored side by side)
+// ALTERNATE_WITH_X:	y, z, 4, 5, x (blocks of values per x coordinate)
+// ALTERNATE_WITH_Y:	x, z, 4, 5, y (blocks of values per y coordinate)
+func getIndexModeOrder(im a2l.IndexModeEnum, n int) []int {
+	order := make([]int, 0, n)
+	switch {
+	case n < 2:
+		for i := 0; i < n; i++ {
+			order = append(order, i)
+		}
+	case im == a2l.ColumnDir:
+		order = append(order, 1, 0)
+		for i := 2; i < n; i++ {
+			order = append(order, i)
+		}
+	case im == a2l.AlternateCurves || im == a2l.AlternateWithX:
+		for i := 1; i < n; i++ {
+			order = append(order, i)
+		}
+		order = append(order, 0)
+	case im == a2l.AlternateWithY:
+		order = append(order, 0)
+		for i := 2; i < n; i++ {
+			order = append(order, i)
+		}
+		order = append(order, 1)
+	default:
+		for i := 0; i < n; i++ {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// reorderToRowDir rearranges values stored in memory with the given dimension order into ROW_DIR order
+// where the x coordinate is the fastest changing one.
+// it is used for the function values as well as for their addresses.
+func reorderToRowDir[T any](mem []T, dims []int, order []int) []T {
+	isRowDir := true
+	for i, o := range order {
+		if i != o {
+			isRowDir = false
+		}
+	}
+	if isRowDir {
+		return mem
+	}
+	strides := make([]int, len(dims))
+	stride := 1
+	for _, o := range order {
+		strides[o] = stride
+		stride *= dims[o]
+	}
+	val := make([]T, len(mem))
+	coords := make([]int, len(dims))
+	for c := range val {
+		m := 0
+		for i := range coords {
+			m += coords[i] * strides[i]
+		}
+		val[c] = mem[m]
+		//increment coordinates with x being the fastest changing one
+		for i := range coords {
+			coords[i]++
+			if coords[i] < dims[i] {
+				break
+			}
+			coords[i] = 0
+		}
+	}
+	return val
+}
+
+// getFncValuesDirect reads noFncValues consecutive function values starting at curPos.
+// the address of each value is recorded in memory order, so the values can be written back to the image they have been read from.
+func (cv *CharacteristicValues) getFncValuesDirect(cd *CalibrationData, rl *a2l.RecordLayout, curPos *uint32, noFncValues int) ([]float64, error) {
+	bo := cv.getByteOrder(cd, -1)
+	val := make([]float64, 0, noFncValues)
+	for i := 0; i < noFncValues; i++ {
+		bufByte, err := cd.getValue(curPos, rl.FncValues.Datatype, rl)
 		if err != nil {
-			log.Err(err).Msg("could not retrieve FncValues values")
+			log.Err(err).Msg("could not retrieve fncValue " + strconv.Itoa(i))
 			return nil, err
 		}
-		bufFloat, err = cd.convertByteSliceToDatatype(bufByte, rl.AxisPtsX.Datatype)
+		bufFloat, err := decodeDatatype(bufByte, rl.FncValues.Datatype, bo)
 		if err != nil {
-			log.Err(err).Msg("could not retrieve FncValues values")
+			log.Err(err).Msg("could not convert fncValue " + strconv.Itoa(i))
 			return nil, err
 		}
 		val = append(val, bufFloat)
-		*curPos += uint32(rl.AxisPtsX.Datatype.GetDatatypeLength())
+		cv.fncAddresses = append(cv.fncAddresses, *curPos)
+		*curPos += uint32(rl.FncValues.Datatype.GetDatatypeLength() / 8)
 	}
-	return val, err
+	return val, nil
 }
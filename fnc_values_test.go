@@ -0,0 +1,159 @@
+package calibrationReader
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestIndirectAddressing(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	//deposit with the number of axis points followed by pointers to the axis points and function values of ASAM.C.CURVE.STD_AXIS.
+	//the demo module is little endian.
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	rl := a2l.RecordLayout{Name: "RL.TEST.PLONG", NameSet: true}
+	rl.NoAxisPtsX = a2l.NoAxisPtsX{Position: 1, PositionSet: true, Datatype: a2l.ULONG, DatatypeSet: true}
+	rl.AxisPtsX = a2l.AxisPtsX{Position: 2, PositionSet: true, Datatype: a2l.SBYTE, DatatypeSet: true,
+		IndexIncr: a2l.IndexDecr, IndexIncrSet: true, Addressing: a2l.PLONG, AddressingSet: true}
+	rl.FncValues = a2l.FncValues{Position: 3, PositionSet: true, Datatype: a2l.SWORD, DatatypeSet: true,
+		Addresstype: a2l.PLONG, AddresstypeSet: true}
+	m.RecordLayouts[rl.Name] = rl
+	c := m.Characteristics["ASAM.C.CURVE.STD_AXIS"]
+	c.Name = "TEST.CURVE.PLONG"
+	c.Address = "0xF00000"
+	c.Deposit = rl.Name
+	m.Characteristics[c.Name] = c
+	ptrs := []byte{0x08, 0x00, 0x00, 0x00, 0x01, 0x03, 0x81, 0x00, 0x0A, 0x03, 0x81, 0x00}
+	for i, b := range ptrs {
+		cd.Hex[0xF00000+uint32(i)] = b
+	}
+
+	cv, err := cd.GetCharacteristicValues(c.Name)
+	if err != nil {
+		t.Fatalf("could not get values of indirectly addressed curve: %s", err)
+	}
+	expectedAxis := []float64{-5, -1, 2, 4, 5, 8, 14, 22}
+	expectedValues := []float64{9, 13, 7, 15, 71, 6, -1, -3}
+	if fmt.Sprint(cv.AxisXRawValues) != fmt.Sprint(expectedAxis) {
+		t.Errorf("axis: expected %v, got %v", expectedAxis, cv.AxisXRawValues)
+	}
+	if fmt.Sprint(cv.RawValues) != fmt.Sprint(expectedValues) {
+		t.Errorf("values: expected %v, got %v", expectedValues, cv.RawValues)
+	}
+
+	//pointer to the last byte of a memory region so the function values exceed the hex file
+	var last uint32
+	for a := range cd.Hex {
+		if _, exists := cd.Hex[a+1]; !exists && a < 0xF00000 {
+			last = a
+			break
+		}
+	}
+	binary.LittleEndian.PutUint32(ptrs[8:], last)
+	for i, b := range ptrs {
+		cd.Hex[0xF00000+uint32(i)] = b
+	}
+	//the error of the failed read has to be returned instead of continuing with empty values
+	cv, err = cd.GetCharacteristicValues(c.Name)
+	if err == nil || !strings.Contains(err.Error(), "not found in hex") {
+		t.Errorf("expected read error for function values exceeding the hex file, got %v with values %v", err, cv.RawValues)
+	}
+
+	//pointer to an address that is not part of the hex file
+	cd.Hex[0xF0000A] = 0xF1
+	_, err = cd.GetCharacteristicValues(c.Name)
+	if err == nil {
+		t.Errorf("expected error for pointer target outside of the hex file")
+	}
+}
+
+func TestIndexMode(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	//the demo contains the same values deposited in ROW_DIR and COLUMN_DIR
+	pairs := [][2]string{
+		{"ASAM.C.ARRAY.SWORD.MATRIX_DIM_3_4.ROW_DIR", "ASAM.C.ARRAY.SWORD.MATRIX_DIM_3_4.COLUMN_DIR"},
+		{"ASAM.C.CUBOID.ROW_DIR", "ASAM.C.CUBOID.COLUMN_DIR"},
+		{"ASAM.C.CUBE_4.ROW_DIR", "ASAM.C.CUBE_4.COLUMN_DIR"},
+	}
+	for _, p := range pairs {
+		row, err := cd.GetCharacteristicValues(p[0])
+		if err != nil {
+			t.Fatalf("could not get values of %s: %s", p[0], err)
+		}
+		col, err := cd.GetCharacteristicValues(p[1])
+		if err != nil {
+			t.Fatalf("could not get values of %s: %s", p[1], err)
+		}
+		if fmt.Sprint(row.RawValues) != fmt.Sprint(col.RawValues) {
+			t.Errorf("%s and %s differ: %v vs. %v", p[0], p[1], row.RawValues, col.RawValues)
+		}
+	}
+	cv, _ := cd.GetCharacteristicValues("ASAM.C.ARRAY.SWORD.MATRIX_DIM_3_4.COLUMN_DIR")
+	if fmt.Sprint(cv.RawValues) != "[[1 4 7 10] [2 5 8 11] [3 6 9 12]]" {
+		t.Errorf("unexpected COLUMN_DIR values %v", cv.RawValues)
+	}
+
+	//map of the ALTERNATE_WITH_X example in a2l.IndexModeEnum
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	rl := a2l.RecordLayout{Name: "RL.TEST.ALTERNATE_WITH_X", NameSet: true}
+	rl.NoAxisPtsX = a2l.NoAxisPtsX{Position: 1, PositionSet: true, Datatype: a2l.UBYTE, DatatypeSet: true}
+	rl.NoAxisPtsY = a2l.NoAxisPtsY{Position: 2, PositionSet: true, Datatype: a2l.UBYTE, DatatypeSet: true}
+	rl.AxisPtsY = a2l.AxisPtsY{Position: 3, PositionSet: true, Datatype: a2l.UBYTE, DatatypeSet: true}
+	rl.AxisPtsX = a2l.AxisPtsX{Position: 4, PositionSet: true, Datatype: a2l.UBYTE, DatatypeSet: true}
+	rl.FncValues = a2l.FncValues{Position: 5, PositionSet: true, Datatype: a2l.UBYTE, DatatypeSet: true,
+		IndexMode: a2l.AlternateWithX, IndexModeSet: true}
+	m.RecordLayouts[rl.Name] = rl
+	c := m.Characteristics["ASAM.C.MAP.STD_AXIS.STD_AXIS"]
+	c.Name = "TEST.MAP.ALTERNATE_WITH_X"
+	c.Address = "0xF00000"
+	c.Deposit = rl.Name
+	c.Conversion = "NO_COMPU_METHOD"
+	c.AxisDescr = append([]a2l.AxisDescr{}, c.AxisDescr...)
+	for i := range c.AxisDescr {
+		c.AxisDescr[i].Conversion = "NO_COMPU_METHOD"
+	}
+	m.Characteristics[c.Name] = c
+	data := []byte{3, 3, 9, 8, 7, 9, 0, 3, 6, 8, 1, 4, 7, 7, 2, 5, 8}
+	for i, b := range data {
+		cd.Hex[0xF00000+uint32(i)] = b
+	}
+	cv, err := cd.GetCharacteristicValues(c.Name)
+	if err != nil {
+		t.Fatalf("could not get values of alternating map: %s", err)
+	}
+	if fmt.Sprint(cv.AxisXRawValues) != "[9 8 7]" || fmt.Sprint(cv.AxisYRawValues) != "[9 8 7]" {
+		t.Errorf("unexpected axes %v and %v", cv.AxisXRawValues, cv.AxisYRawValues)
+	}
+	if fmt.Sprint(cv.RawValues) != "[[0 3 6] [1 4 7] [2 5 8]]" {
+		t.Errorf("unexpected alternating values %v", cv.RawValues)
+	}
+}
+
+func TestReorderToRowDir(t *testing.T) {
+	//examples of a2l.IndexModeEnum. a 3x3 map stored with different index modes
+	dims := []int{3, 3}
+	tests := []struct {
+		mode a2l.IndexModeEnum
+		mem  []float64
+	}{
+		{mode: a2l.RowDir, mem: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8}},
+		{mode: a2l.ColumnDir, mem: []float64{0, 3, 6, 1, 4, 7, 2, 5, 8}},
+		{mode: a2l.AlternateCurves, mem: []float64{0, 3, 6, 1, 4, 7, 2, 5, 8}},
+		{mode: a2l.AlternateWithX, mem: []float64{0, 3, 6, 1, 4, 7, 2, 5, 8}},
+		{mode: a2l.AlternateWithY, mem: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8}},
+	}
+	for _, tt := range tests {
+		val := reorderToRowDir(tt.mem, dims, getIndexModeOrder(tt.mode, len(dims)))
+		if fmt.Sprint(val) != "[0 1 2 3 4 5 6 7 8]" {
+			t.Errorf("%s: unexpected order %v", tt.mode, val)
+		}
+	}
+}
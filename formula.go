@@ -0,0 +1,579 @@
+package calibrationReader
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
+)
+
+// formulaCache contains the parsed formulas keyed by their expression,
+// so every FORM conversion is only parsed once no matter how many values are converted with it.
+var formulaCache sync.Map
+
+// formula is a parsed ASAP2 formula that can be evaluated for the input values X1..Xn.
+type formula struct {
+	expression string
+	root       formulaNode
+	//noInputs is the highest index n of the referenced input values Xn
+	noInputs int
+}
+
+// formulaEnv holds the input values and the calibration data used to look up system constants during the evaluation of a formula.
+// sysc holds the names of the system constants whose formulas are currently evaluated in order to detect cyclic references.
+type formulaEnv struct {
+	x    []float64
+	cd   *CalibrationData
+	sysc []string
+}
+
+// formulaNode is a node of the syntax tree of a formula.
+type formulaNode interface {
+	eval(env *formulaEnv) (float64, error)
+}
+
+type formulaNumber float64
+
+type formulaInput int
+
+type formulaSysc string
+
+type formulaUnary struct {
+	op string
+	x  formulaNode
+}
+
+type formulaBinary struct {
+	op    string
+	left  formulaNode
+	right formulaNode
+}
+
+type formulaCall struct {
+	name string
+	args []formulaNode
+}
+
+// formulaFunctions lists the supported math functions with their number of arguments.
+var formulaFunctions = map[string]int{
+	"abs": 1, "acos": 1, "arccos": 1, "asin": 1, "arcsin": 1, "atan": 1, "arctan": 1, "atan2": 2, "ceil": 1, "cos": 1, "cosh": 1,
+	"exp": 1, "floor": 1, "ln": 1, "log": 1, "log10": 1, "max": 2, "min": 2, "pow": 2, "sin": 1, "sinh": 1, "sqrt": 1, "tan": 1, "tanh": 1,
+}
+
+// formulaPrecedence defines the binding strength of the binary operators as in C.
+var formulaPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"|":  3,
+	"^":  4,
+	"&":  5,
+	"==": 6, "!=": 6,
+	"<": 7, "<=": 7, ">": 7, ">=": 7,
+	"<<": 8, ">>": 8,
+	"+": 9, "-": 9,
+	"*": 10, "/": 10, "%": 10,
+}
+
+// calcFormula computes the physical value of a FORM compu method for the decimal value dec.
+func calcFormula(dec float64, cm *a2l.CompuMethod, cd *CalibrationData) (float64, error) {
+	if len(cm.Formula) == 0 || !cm.Formula[0].FxSet {
+		err := errors.New("formula not set in compuMethod: " + cm.Name)
+		log.Err(err).Msg("decimal value could not be converted")
+		return 0, err
+	}
+	phy, err := cd.evalFormula(strings.Join(cm.Formula[0].Fx, " "), dec)
+	if err != nil {
+		log.Err(err).Msg("decimal value could not be converted with compuMethod " + cm.Name)
+		return 0, err
+	}
+	return phy, nil
+}
+
+// evalFormula evaluates the formula expression for the input values X1..Xn.
+// the expression is parsed on its first use and taken from the cache afterwards.
+func (cd *CalibrationData) evalFormula(expression string, x ...float64) (float64, error) {
+	f, err := getFormula(expression)
+	if err != nil {
+		log.Err(err).Msg("could not evaluate formula")
+		return 0, err
+	}
+	return f.evaluate(&formulaEnv{x: x, cd: cd})
+}
+
+// getFormula returns the parsed formula for the expression from the cache or parses it.
+func getFormula(expression string) (*formula, error) {
+	if f, exists := formulaCache.Load(expression); exists {
+		return f.(*formula), nil
+	}
+	f, err := parseFormulaExpression(expression)
+	if err != nil {
+		log.Err(err).Msg("could not parse formula " + expression)
+		return nil, err
+	}
+	formulaCache.Store(expression, f)
+	return f, nil
+}
+
+// evaluate computes the result of the formula for the input values X1..Xn.
+func (f *formula) evaluate(env *formulaEnv) (float64, error) {
+	if len(env.x) < f.noInputs {
+		err := errors.New("formula " + f.expression + " needs " + strconv.Itoa(f.noInputs) + " input values, got " + strconv.Itoa(len(env.x)))
+		log.Err(err).Msg("could not evaluate formula")
+		return 0, err
+	}
+	val, err := f.root.eval(env)
+	if err != nil {
+		log.Err(err).Msg("could not evaluate formula " + f.expression)
+		return 0, err
+	}
+	return val, nil
+}
+
+// formulaParser is a recursive descent parser for the ASAP2 formula syntax.
+type formulaParser struct {
+	tokens   []string
+	pos      int
+	noInputs int
+}
+
+// parseFormulaExpression parses an ASAP2 formula. surrounding quotes are removed.
+// the syntax follows C: numbers (decimal, exponent or hex notation), the input values X1..Xn with X (or x) as alias of X1,
+// arithmetic (+ - * / %), bit (& | ^ ~ << >>), comparison (< <= > >= == !=) and logical (&& || !) operators,
+// parentheses, math functions such as sin, cos, exp, log, sqrt, abs or pow and system constants referenced as sysc(NAME).
+func parseFormulaExpression(expression string) (*formula, error) {
+	tokens, err := tokenizeFormula(strings.Trim(strings.TrimSpace(expression), "\""))
+	if err != nil {
+		log.Err(err).Msg("could not tokenize formula")
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		err = errors.New("empty formula")
+		log.Err(err).Msg("could not parse formula")
+		return nil, err
+	}
+	p := formulaParser{tokens: tokens}
+	root, err := p.parseBinary(1)
+	if err != nil {
+		log.Err(err).Msg("could not parse formula " + expression)
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		err = errors.New("unexpected token '" + p.tokens[p.pos] + "' in formula " + expression)
+		log.Err(err).Msg("could not parse formula")
+		return nil, err
+	}
+	return &formula{expression: expression, root: root, noInputs: p.noInputs}, nil
+}
+
+// tokenizeFormula splits a formula into numbers, identifiers, string literals and operators.
+func tokenizeFormula(s string) ([]string, error) {
+	var tokens []string
+	r := []rune(s)
+	for i := 0; i < len(r); {
+		c := r[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsDigit(c) || (c == '.' && i+1 < len(r) && unicode.IsDigit(r[i+1])):
+			j := i + 1
+			if c == '0' && j < len(r) && (r[j] == 'x' || r[j] == 'X') {
+				j++
+				for j < len(r) && strings.ContainsRune("0123456789abcdefABCDEF", r[j]) {
+					j++
+				}
+			} else {
+				for j < len(r) && (unicode.IsDigit(r[j]) || r[j] == '.') {
+					j++
+				}
+				if j < len(r) && (r[j] == 'e' || r[j] == 'E') {
+					k := j + 1
+					if k < len(r) && (r[k] == '+' || r[k] == '-') {
+						k++
+					}
+					if k < len(r) && unicode.IsDigit(r[k]) {
+						j = k
+						for j < len(r) && unicode.IsDigit(r[j]) {
+							j++
+						}
+					}
+				}
+			}
+			tokens = append(tokens, string(r[i:j]))
+			i = j
+		case unicode.IsLetter(c) || c == '_':
+			j := i + 1
+			for j < len(r) && (unicode.IsLetter(r[j]) || unicode.IsDigit(r[j]) || r[j] == '_' || r[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, string(r[i:j]))
+			i = j
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(r) && r[j] != c {
+				j++
+			}
+			if j >= len(r) {
+				err := errors.New("unterminated string in formula " + s)
+				log.Err(err).Msg("could not tokenize formula")
+				return nil, err
+			}
+			tokens = append(tokens, string(r[i:j+1]))
+			i = j + 1
+		default:
+			if i+1 < len(r) {
+				switch two := string(r[i : i+2]); two {
+				case "&&", "||", "<<", ">>", "<=", ">=", "==", "!=":
+					tokens = append(tokens, two)
+					i += 2
+					continue
+				}
+			}
+			if !strings.ContainsRune("+-*/%&|^~!<>(),", c) {
+				err := errors.New("unexpected character '" + string(c) + "' in formula " + s)
+				log.Err(err).Msg("could not tokenize formula")
+				return nil, err
+			}
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// peek returns the current token or an empty string at the end of the formula.
+func (p *formulaParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// expect consumes the current token if it equals tok and returns an error otherwise.
+func (p *formulaParser) expect(tok string) error {
+	if p.peek() != tok {
+		err := errors.New("expected '" + tok + "' but found '" + p.peek() + "'")
+		log.Err(err).Msg("could not parse formula")
+		return err
+	}
+	p.pos++
+	return nil
+}
+
+// parseBinary parses binary operations whose operators bind at least as strong as minPrecedence.
+// all binary operators are left associative.
+func (p *formulaParser) parseBinary(minPrecedence int) (formulaNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		prec, isOperator := formulaPrecedence[op]
+		if !isOperator || prec < minPrecedence {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseBinary(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = formulaBinary{op: op, left: left, right: right}
+	}
+}
+
+// parseUnary parses the unary operators + - ! ~ followed by an operand.
+func (p *formulaParser) parseUnary() (formulaNode, error) {
+	switch op := p.peek(); op {
+	case "+", "-", "!", "~":
+		p.pos++
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return formulaUnary{op: op, x: x}, nil
+	}
+	return p.parseOperand()
+}
+
+// parseOperand parses numbers, input values, function calls, system constants and parenthesized expressions.
+func (p *formulaParser) parseOperand() (formulaNode, error) {
+	tok := p.peek()
+	if tok == "" {
+		err := errors.New("unexpected end of formula")
+		log.Err(err).Msg("could not parse formula")
+		return nil, err
+	}
+	p.pos++
+	switch {
+	case tok == "(":
+		x, err := p.parseBinary(1)
+		if err != nil {
+			return nil, err
+		}
+		return x, p.expect(")")
+	case unicode.IsDigit(rune(tok[0])) || tok[0] == '.':
+		if strings.HasPrefix(tok, "0x") || strings.HasPrefix(tok, "0X") {
+			v, err := strconv.ParseUint(tok[2:], 16, 64)
+			if err != nil {
+				log.Err(err).Msg("could not parse hex number in formula")
+				return nil, err
+			}
+			return formulaNumber(v), nil
+		}
+		v, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			log.Err(err).Msg("could not parse number in formula")
+			return nil, err
+		}
+		return formulaNumber(v), nil
+	case tok == "X" || tok == "x":
+		if p.noInputs < 1 {
+			p.noInputs = 1
+		}
+		return formulaInput(0), nil
+	case (tok[0] == 'X' || tok[0] == 'x') && len(tok) > 1 && isDecimal(tok[1:]):
+		n, err := strconv.Atoi(tok[1:])
+		if err != nil || n < 1 {
+			err = errors.New("invalid input value " + tok)
+			log.Err(err).Msg("could not parse formula")
+			return nil, err
+		}
+		if n > p.noInputs {
+			p.noInputs = n
+		}
+		return formulaInput(n - 1), nil
+	case strings.EqualFold(tok, "sysc"):
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		name := strings.Trim(p.peek(), "\"'")
+		if name == "" || name == ")" {
+			err := errors.New("sysc without system constant name")
+			log.Err(err).Msg("could not parse formula")
+			return nil, err
+		}
+		p.pos++
+		return formulaSysc(name), p.expect(")")
+	default:
+		name := strings.ToLower(tok)
+		noArgs, exists := formulaFunctions[name]
+		if !exists {
+			err := errors.New("unknown identifier '" + tok + "'")
+			log.Err(err).Msg("could not parse formula")
+			return nil, err
+		}
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		call := formulaCall{name: name}
+		for i := 0; i < noArgs; i++ {
+			if i > 0 {
+				if err := p.expect(","); err != nil {
+					return nil, err
+				}
+			}
+			arg, err := p.parseBinary(1)
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+		}
+		return call, p.expect(")")
+	}
+}
+
+// isDecimal reports whether s consists of decimal digits only.
+func isDecimal(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func (n formulaNumber) eval(env *formulaEnv) (float64, error) {
+	return float64(n), nil
+}
+
+func (n formulaInput) eval(env *formulaEnv) (float64, error) {
+	return env.x[n], nil
+}
+
+func (n formulaSysc) eval(env *formulaEnv) (float64, error) {
+	if env.cd == nil {
+		err := errors.New("no calibration data to look up system constant " + string(n))
+		log.Err(err).Msg("could not evaluate sysc")
+		return 0, err
+	}
+	sc, err := env.cd.resolveSystemConstant(string(n), env.sysc)
+	if err != nil {
+		log.Err(err).Msg("could not evaluate sysc")
+		return 0, err
+	}
+	if !sc.IsNumeric {
+		err = errors.New("system constant " + sc.Name + " with value '" + sc.Text + "' is not numeric")
+		log.Err(err).Msg("could not evaluate sysc")
+		return 0, err
+	}
+	return sc.Value, nil
+}
+
+func (n formulaUnary) eval(env *formulaEnv) (float64, error) {
+	x, err := n.x.eval(env)
+	if err != nil {
+		return 0, err
+	}
+	switch n.op {
+	case "-":
+		return -x, nil
+	case "!":
+		return boolToFloat(x == 0), nil
+	case "~":
+		return float64(^int64(x)), nil
+	default:
+		return x, nil
+	}
+}
+
+func (n formulaBinary) eval(env *formulaEnv) (float64, error) {
+	l, err := n.left.eval(env)
+	if err != nil {
+		return 0, err
+	}
+	//logical operators only evaluate their right operand if necessary
+	switch n.op {
+	case "&&":
+		if l == 0 {
+			return 0, nil
+		}
+	case "||":
+		if l != 0 {
+			return 1, nil
+		}
+	}
+	r, err := n.right.eval(env)
+	if err != nil {
+		return 0, err
+	}
+	switch n.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			err = errors.New("division by zero")
+			log.Err(err).Msg("could not evaluate formula")
+			return 0, err
+		}
+		return l / r, nil
+	case "%":
+		if r == 0 {
+			err = errors.New("modulo by zero")
+			log.Err(err).Msg("could not evaluate formula")
+			return 0, err
+		}
+		return math.Mod(l, r), nil
+	case "&":
+		return float64(int64(l) & int64(r)), nil
+	case "|":
+		return float64(int64(l) | int64(r)), nil
+	case "^":
+		return float64(int64(l) ^ int64(r)), nil
+	case "<<":
+		return float64(int64(l) << uint64(r)), nil
+	case ">>":
+		return float64(int64(l) >> uint64(r)), nil
+	case "<":
+		return boolToFloat(l < r), nil
+	case "<=":
+		return boolToFloat(l <= r), nil
+	case ">":
+		return boolToFloat(l > r), nil
+	case ">=":
+		return boolToFloat(l >= r), nil
+	case "==":
+		return boolToFloat(l == r), nil
+	case "!=":
+		return boolToFloat(l != r), nil
+	default:
+		//&& and || with a left operand that does not decide the result
+		return boolToFloat(r != 0), nil
+	}
+}
+
+func (n formulaCall) eval(env *formulaEnv) (float64, error) {
+	args := make([]float64, len(n.args))
+	for i, a := range n.args {
+		var err error
+		args[i], err = a.eval(env)
+		if err != nil {
+			return 0, err
+		}
+	}
+	var val float64
+	switch n.name {
+	case "abs":
+		val = math.Abs(args[0])
+	case "acos", "arccos":
+		val = math.Acos(args[0])
+	case "asin", "arcsin":
+		val = math.Asin(args[0])
+	case "atan", "arctan":
+		val = math.Atan(args[0])
+	case "atan2":
+		val = math.Atan2(args[0], args[1])
+	case "ceil":
+		val = math.Ceil(args[0])
+	case "cos":
+		val = math.Cos(args[0])
+	case "cosh":
+		val = math.Cosh(args[0])
+	case "exp":
+		val = math.Exp(args[0])
+	case "floor":
+		val = math.Floor(args[0])
+	case "ln", "log":
+		val = math.Log(args[0])
+	case "log10":
+		val = math.Log10(args[0])
+	case "max":
+		val = math.Max(args[0], args[1])
+	case "min":
+		val = math.Min(args[0], args[1])
+	case "pow":
+		val = math.Pow(args[0], args[1])
+	case "sin":
+		val = math.Sin(args[0])
+	case "sinh":
+		val = math.Sinh(args[0])
+	case "sqrt":
+		val = math.Sqrt(args[0])
+	case "tan":
+		val = math.Tan(args[0])
+	case "tanh":
+		val = math.Tanh(args[0])
+	}
+	if math.IsNaN(val) {
+		err := errors.New("argument out of domain of function " + n.name)
+		log.Err(err).Msg("could not evaluate formula")
+		return 0, err
+	}
+	return val, nil
+}
+
+// boolToFloat returns 1 for true and 0 for false as in C.
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,72 @@
+package calibrationReader
+
+import (
+	"math"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestFormula(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	m.ModPar.SystemConstants["offset"] = a2l.SystemConstant{Name: "offset", NameSet: true, Value: "\"2.5\"", ValueSet: true}
+	tests := []struct {
+		expression string
+		x          []float64
+		expected   float64
+	}{
+		{expression: "\"X1+4\"", x: []float64{3}, expected: 7},
+		{expression: "2*x-1", x: []float64{3}, expected: 5},
+		{expression: "X1*X2+X3", x: []float64{2, 3, 4}, expected: 10},
+		{expression: "-(X1+2)*3", x: []float64{1}, expected: -9},
+		{expression: "10-4-3", x: nil, expected: 3},
+		{expression: "2+3*4", x: nil, expected: 14},
+		{expression: "1.5e2/0x10", x: nil, expected: 9.375},
+		{expression: "(X1 >> 4) & 0x0F", x: []float64{0xAB}, expected: 0x0A},
+		{expression: "X1 | 1 << 3", x: []float64{1}, expected: 9},
+		{expression: "~X1 & 0xFF", x: []float64{0x0F}, expected: 0xF0},
+		{expression: "X1 ^ 3", x: []float64{5}, expected: 6},
+		{expression: "X1 > 2 && X1 <= 4 || !X1", x: []float64{3}, expected: 1},
+		{expression: "X1 == 2 || X1 != 3", x: []float64{3}, expected: 0},
+		{expression: "sqrt(abs(X1)) + pow(2, 3)", x: []float64{-16}, expected: 12},
+		{expression: "exp(log(X1)) + sin(0) + cos(0)", x: []float64{5}, expected: 6},
+		{expression: "X1 + sysc(offset)", x: []float64{1}, expected: 3.5},
+		{expression: "X1 + sysc(\"offset\")", x: []float64{2}, expected: 4.5},
+	}
+	for _, tt := range tests {
+		v, err := cd.evalFormula(tt.expression, tt.x...)
+		if err != nil {
+			t.Errorf("%s: %s", tt.expression, err)
+		} else if math.Abs(v-tt.expected) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", tt.expression, tt.expected, v)
+		}
+	}
+	for _, expression := range []string{"X1 +", "(X1", "X0", "foo(X1)", "X1 $ 2", "sysc()"} {
+		if _, err := parseFormulaExpression(expression); err == nil {
+			t.Errorf("%s: expected syntax error", expression)
+		}
+	}
+	for _, expression := range []string{"X1/0", "X1+X2", "sqrt(-X1)", "sysc(missing)"} {
+		if _, err := cd.evalFormula(expression, 1); err == nil {
+			t.Errorf("%s: expected evaluation error", expression)
+		}
+	}
+	//formulas are parsed only once
+	f1, _ := getFormula("X1*3")
+	f2, _ := getFormula("X1*3")
+	if f1 != f2 {
+		t.Errorf("expected formula to be cached")
+	}
+
+	cv, err := cd.GetCharacteristicValues("ASAM.C.SCALAR.SWORD.FORM_X_PLUS_4")
+	if err != nil {
+		t.Fatalf("could not get values: %s", err)
+	}
+	if cv.PhyValues.(float64) != cv.RawValues.(float64)+4 {
+		t.Errorf("expected raw value %v plus 4, got %v", cv.RawValues, cv.PhyValues)
+	}
+}
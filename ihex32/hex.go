@@ -97,19 +97,34 @@ var (
 	}
 )
 
+// Hex contains the data of a parsed Intel HEX file and the start addresses given by its start address records.
+type Hex struct {
+	//Data contains all data bytes with their final 32 bit addresses.
+	Data map[uint32]byte
+	//StartSegmentAddress contains CS in the upper and IP in the lower 2 Byte as given by a start segment address record (03).
+	StartSegmentAddress    uint32
+	StartSegmentAddressSet bool
+	//StartLinearAddress contains the EIP register value given by a start linear address record (05).
+	StartLinearAddress    uint32
+	StartLinearAddressSet bool
+}
+
 // parseHex parses a hex-file given as an slice of strings and return a hex struct containing the data in byte form with all the addresses attached.
-func parseHex(lines []string) (map[uint32]byte, error) {
+func parseHex(lines []string) (Hex, error) {
+	var hx Hex
 	var h map[uint32]byte
 	var err error
 
 	//the initial capacity of dataBytes and records should be enough to parse a 10MB file without reallocation
 	h = make(map[uint32]byte, 5000000)
+	hx.Data = h
 	recs := make([]*record, 0, 200000)
 
 	//locRecord contains slices of records that the individual parsers in the goroutines produced
 	//this way we can ensure that the order of the records remains correct
 	//because the positions of the return values are determined by the position of the channel within locRecord
 	var locRecord []chan []*record
+	errChan := make(chan error, numProc)
 	for i := 0; i < numProc; i++ {
 		//calculate start and end for the slices each go routine is given to parse
 		start := (len(lines) / numProc) * i
@@ -120,7 +135,7 @@ func parseHex(lines []string) (map[uint32]byte, error) {
 		}
 		c := make(chan []*record, len(lines))
 		locRecord = append(locRecord, c)
-		go parseRecordRoutine(c, lines[start:end])
+		go parseRecordRoutine(c, errChan, lines[start:end])
 	}
 	//collect records from channels
 	for _, c := range locRecord {
@@ -128,6 +143,35 @@ func parseHex(lines []string) (map[uint32]byte, error) {
 			recs = append(recs, r...)
 		}
 	}
+	close(errChan)
+	if err = <-errChan; err != nil {
+		return hx, err
+	}
+
+	//no records may follow the end of file record. start addresses are only defined once
+	for i, r := range recs {
+		switch r.recordType {
+		case endOfFileToken:
+			if i != len(recs)-1 {
+				err = errors.New("found " + fmt.Sprint(len(recs)-1-i) + " records after end of file record")
+				return hx, err
+			}
+		case startSegmentAddressRecordToken:
+			if hx.StartSegmentAddressSet {
+				err = errors.New("start segment address is defined more than once")
+				return hx, err
+			}
+			hx.StartSegmentAddress = r.startAddress
+			hx.StartSegmentAddressSet = true
+		case startLinearAddressRecordToken:
+			if hx.StartLinearAddressSet {
+				err = errors.New("start linear address is defined more than once")
+				return hx, err
+			}
+			hx.StartLinearAddress = r.startAddress
+			hx.StartLinearAddressSet = true
+		}
+	}
 
 	//apply offsets to all records
 	wgOffset := new(sync.WaitGroup)
@@ -160,7 +204,7 @@ func parseHex(lines []string) (map[uint32]byte, error) {
 			for v := range vcsChan {
 				if !v {
 					err = errors.New("invalid checksums detected")
-					return h, err
+					return hx, err
 				}
 			}
 		}
@@ -191,14 +235,14 @@ func parseHex(lines []string) (map[uint32]byte, error) {
 				//make sure no addresses are defined twice which would indicate a severe error
 				if exists {
 					err = errors.New("colliding address values at address " + fmt.Sprint(data.address) + " value1 " + fmt.Sprint(val) + " and value2 " + fmt.Sprint(data.value))
-					return h, err
+					return hx, err
 				}
 				h[data.address] = data.value
 			}
 		}
 	}
 
-	return h, nil
+	return hx, nil
 }
 
 // readFileToString returns a string by reading a document from a given filepath.
@@ -214,14 +258,20 @@ func readFileToString(filepath string) (string, error) {
 
 // ParseFromFile parses a hex file from a given filepath and return a hex struct containing all data as bytes with their addresses.
 func ParseFromFile(filepath string) (map[uint32]byte, error) {
-	var h map[uint32]byte
+	hx, err := ParseHexFromFile(filepath)
+	return hx.Data, err
+}
+
+// ParseHexFromFile parses a hex file from a given filepath and returns the data together with the start addresses of the file.
+func ParseHexFromFile(filepath string) (Hex, error) {
+	var hx Hex
 	var text string
 	var err error
 
 	text, err = readFileToString(filepath)
 	if err != nil {
 		log.Err(err).Msg("hex test-file could not be read")
-		return h, err
+		return hx, err
 	}
 	//split the text into lines
 	lines := strings.Split(text, "\r\n")
@@ -229,23 +279,25 @@ func ParseFromFile(filepath string) (map[uint32]byte, error) {
 		//in case unix line terminator is used.
 		lines = strings.Split(text, "\n")
 	}
-	h, err = parseHex(lines)
+	hx, err = parseHex(lines)
 	if err != nil {
 		log.Err(err).Msg("failed parsing with error")
-		return h, err
+		return hx, err
 	}
-	return h, nil
+	return hx, nil
 }
 
 // parseRecordRoutine calls the parseRecord method for each line given and return them via a channel.
-func parseRecordRoutine(c chan []*record, lines []string) {
+// the first record that cannot be parsed is reported via the error channel.
+func parseRecordRoutine(c chan []*record, ce chan error, lines []string) {
 	var recs []*record
 forLoop:
 	for _, l := range lines {
 		if len(l) >= 11 {
 			r, err := parseRecord(l)
 			if err != nil {
-				log.Err(err)
+				log.Err(err).Msg("could not parse record " + l)
+				ce <- err
 				break forLoop
 			}
 			recs = append(recs, r)
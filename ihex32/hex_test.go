@@ -28,6 +28,152 @@ func TestParseFromFile(t *testing.T) {
 	}
 }
 
+func TestParseRecordTypes(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+	lines := []string{
+		formatRecord(0, extendedSegmentAddressRecordToken, []byte{0x12, 0x00}),
+		formatRecord(0x0010, dataRecordToken, []byte{1, 2}),
+		//the 16 bit address wraps around within the segment
+		formatRecord(0xFFFF, dataRecordToken, []byte{3, 4}),
+		formatRecord(0, extendedLinearAddressRecordToken, []byte{0x00, 0x02}),
+		formatRecord(0x0010, dataRecordToken, []byte{5}),
+		formatRecord(0, startSegmentAddressRecordToken, []byte{0x12, 0x00, 0x00, 0x10}),
+		formatRecord(0, startLinearAddressRecordToken, []byte{0x00, 0x02, 0x00, 0x10}),
+		formatRecord(0, endOfFileToken, nil),
+		"\x1a",
+	}
+	hx, err := parseHex(lines)
+	if err != nil {
+		t.Fatalf("failed parsing with error: %s.", err)
+	}
+	expected := map[uint32]byte{0x12010: 1, 0x12011: 2, 0x21FFF: 3, 0x12000: 4, 0x20010: 5}
+	if len(hx.Data) != len(expected) {
+		t.Fatalf("expected %d bytes, got %d", len(expected), len(hx.Data))
+	}
+	for a, b := range expected {
+		if hx.Data[a] != b {
+			t.Fatalf("expected %d at 0x%X, got %d", b, a, hx.Data[a])
+		}
+	}
+	if !hx.StartSegmentAddressSet || hx.StartSegmentAddress != 0x12000010 {
+		t.Fatalf("start segment address has not been read correctly")
+	}
+	if !hx.StartLinearAddressSet || hx.StartLinearAddress != 0x00020010 {
+		t.Fatalf("start linear address has not been read correctly")
+	}
+	//data after the end of file record is rejected
+	_, err = parseHex(append(lines[:len(lines)-1], formatRecord(0x20, dataRecordToken, []byte{6})))
+	if err == nil {
+		t.Fatalf("data after end of file record has not been rejected")
+	}
+	//unknown record types and wrong byte counts are rejected instead of being dropped
+	_, err = parseHex([]string{formatRecord(0, "06", nil), formatRecord(0, endOfFileToken, nil)})
+	if err == nil {
+		t.Fatalf("unknown record type has not been rejected")
+	}
+	_, err = parseHex([]string{formatRecord(0, extendedLinearAddressRecordToken, []byte{1}), formatRecord(0, endOfFileToken, nil)})
+	if err == nil {
+		t.Fatalf("extended linear address record with wrong byte count has not been rejected")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+	h, err := ParseFromFile("testing/ASAP2_Demo_V171.hex")
+	if err != nil {
+		t.Fatalf("failed parsing with error: %s.", err)
+	}
+	for _, rl := range []int{16, 32, 64} {
+		path := t.TempDir() + "/out.hex"
+		err = WriteToFile(path, h, WriteOptions{RecordLength: rl, StartAddress: 0x10000, StartAddressSet: true})
+		if err != nil {
+			t.Fatalf("failed writing with error: %s.", err)
+		}
+		text, _ := readFileToString(path)
+		if !strings.HasSuffix(text, ":0400000500010000F6\r\n:00000001FF\r\n") {
+			t.Fatalf("record length %d: missing start address or end of file record", rl)
+		}
+		for _, line := range strings.Split(strings.TrimSpace(text), "\r\n") {
+			if line[7:9] == dataRecordToken && len(line) > 11+2*rl {
+				t.Fatalf("record length %d: record %s too long", rl, line)
+			}
+		}
+		w, err := ParseFromFile(path)
+		if err != nil {
+			t.Fatalf("failed parsing written file with error: %s.", err)
+		}
+		if len(w) != len(h) {
+			t.Fatalf("record length %d: expected %d bytes, got %d", rl, len(h), len(w))
+		}
+		for a, b := range h {
+			if w[a] != b {
+				t.Fatalf("record length %d: byte at 0x%X differs", rl, a)
+			}
+		}
+	}
+	err = Write(&strings.Builder{}, h, WriteOptions{RecordLength: 20})
+	if err == nil {
+		t.Fatalf("invalid record length has not been rejected")
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+	hexPath := "testing/ASAP2_Demo_V171.hex"
+	template, _ := readFileToString(hexPath)
+	h, err := ParseFromFile(hexPath)
+	if err != nil {
+		t.Fatalf("failed parsing with error: %s.", err)
+	}
+	var sb strings.Builder
+	err = WriteRoundTrip(&sb, template, h)
+	if err != nil {
+		t.Fatalf("failed writing with error: %s.", err)
+	}
+	if sb.String() != template {
+		t.Fatalf("unchanged image did not reproduce the original file")
+	}
+	//change a single byte and expect exactly one changed line
+	var addr uint32
+	for a := range h {
+		addr = a
+		break
+	}
+	h[addr]++
+	sb.Reset()
+	err = WriteRoundTrip(&sb, template, h)
+	if err != nil {
+		t.Fatalf("failed writing with error: %s.", err)
+	}
+	orig := strings.SplitAfter(template, "\n")
+	changed := strings.SplitAfter(sb.String(), "\n")
+	if len(orig) != len(changed) {
+		t.Fatalf("expected %d lines, got %d", len(orig), len(changed))
+	}
+	diff := 0
+	for i := range orig {
+		if orig[i] != changed[i] {
+			diff++
+		}
+	}
+	if diff != 1 {
+		t.Fatalf("expected one changed line, got %d", diff)
+	}
+	w, err := parseHex(strings.Split(sb.String(), "\n"))
+	if err != nil || w.Data[addr] != h[addr] {
+		t.Fatalf("changed byte has not been written correctly: %v", err)
+	}
+	//bytes outside of the template cannot be placed
+	h[0xFFFFFFF0] = 1
+	err = WriteRoundTrip(&strings.Builder{}, template, h)
+	if err == nil {
+		t.Fatalf("byte outside of the template has not been rejected")
+	}
+}
+
 func FuzzParseHex(f *testing.F) {
 	configureLogger()
 	zerolog.SetGlobalLevel(zerolog.WarnLevel)
@@ -54,8 +200,8 @@ func FuzzParseHex(f *testing.F) {
 			}
 			if !exists {
 				errList = append(errList, err)
-				fmt.Println(len(h))
-				log.Err(err).Msg("could not parse hex-file with length " + strconv.Itoa(len(h)))
+				fmt.Println(len(h.Data))
+				log.Err(err).Msg("could not parse hex-file with length " + strconv.Itoa(len(h.Data)))
 				log.Err(err).Msg(orig)
 			}
 		}
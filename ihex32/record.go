@@ -3,6 +3,7 @@ package ihex32
 import (
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"sync"
 )
 
@@ -20,37 +21,78 @@ type record struct {
 	data []string
 	//checksum contains a single byte hex string with the checksum computed from the individual fields of the record.
 	checksum string
-	//offset contains the upper 2 Byte of the final adress value of a entry.
-	offset string
+	//offset contains the base address that is added to the addressField of a data record.
+	//it is set by extended linear address records (upper 2 Byte) or extended segment address records (segment * 16).
+	offset uint32
+	//startAddress contains the 4 Byte start address of a start segment (CS:IP) or start linear address record.
+	startAddress uint32
 }
 
 // parseRecord takes a line as string and fills the respective fields in the record struct.
 func parseRecord(line string) (*record, error) {
 	r := record{}
-	if line[0] == beginLineToken[0] && len(line) >= 11 {
-		r.byteCount = line[1:3]
-		r.addressField = line[3:7]
-		r.recordType = line[7:9]
-		for i := 9; i < len(line)-3; i += 2 {
-			r.data = append(r.data, line[i:i+2])
+	if line[0] != beginLineToken[0] || len(line) < 11 {
+		err := errors.New("entry has no begin line symbol or is too short")
+		return &r, err
+	}
+	r.byteCount = line[1:3]
+	r.addressField = line[3:7]
+	r.recordType = line[7:9]
+	count, err := hexToByte(r.byteCount)
+	if err != nil {
+		return &r, err
+	}
+	if len(line) != 11+2*int(count) {
+		err = errors.New("length of line does not match its byte count " + r.byteCount)
+		return &r, err
+	}
+	for i := 9; i < len(line)-3; i += 2 {
+		r.data = append(r.data, line[i:i+2])
+	}
+	r.checksum = line[len(line)-2:]
+	//check that each record type contains the expected number of data bytes
+	expected := -1
+	switch r.recordType {
+	case dataRecordToken:
+	case endOfFileToken:
+		expected = 0
+	case extendedSegmentAddressRecordToken, extendedLinearAddressRecordToken:
+		expected = 2
+	case startSegmentAddressRecordToken, startLinearAddressRecordToken:
+		expected = 4
+	default:
+		err = errors.New("unknown record type " + r.recordType)
+		return &r, err
+	}
+	if expected >= 0 && int(count) != expected {
+		err = errors.New("record of type " + r.recordType + " must contain " + fmt.Sprint(expected) + " data bytes")
+		return &r, err
+	}
+	switch r.recordType {
+	case extendedLinearAddressRecordToken, extendedSegmentAddressRecordToken:
+		bs, err := HexToByteSlice(line[9:13])
+		if err != nil {
+			return &r, err
 		}
+		//linear addresses define the upper 16 bit, segment addresses are multiplied by 16
 		if r.recordType == extendedLinearAddressRecordToken {
-			if len(line) >= 13 {
-				r.offset = line[9:13]
-			} else {
-				err := errors.New("line is too short to parse extended address record")
-				return &r, err
-			}
+			r.offset = uint32(binary.BigEndian.Uint16(bs)) << 16
 		} else {
-			r.offset = "0000"
+			r.offset = uint32(binary.BigEndian.Uint16(bs)) << 4
 		}
-		r.checksum = line[len(line)-2:]
-		return &r, nil
-	} else {
-		err := errors.New("entry has no begin line symbol or is too short")
-		return &r, err
+	case startSegmentAddressRecordToken, startLinearAddressRecordToken:
+		bs, err := HexToByteSlice(line[9:17])
+		if err != nil {
+			return &r, err
+		}
+		r.startAddress = binary.BigEndian.Uint32(bs)
 	}
+	return &r, nil
+}
 
+// isAddressRecord returns true for records that set the base address of the following data records.
+func (r *record) isAddressRecord() bool {
+	return r.recordType == extendedLinearAddressRecordToken || r.recordType == extendedSegmentAddressRecordToken
 }
 
 // addOffsets walks through all records and updates the offset-value in the records of type data.
@@ -58,11 +100,11 @@ func parseRecord(line string) (*record, error) {
 func addOffsets(wg *sync.WaitGroup, recs []*record, start int) {
 	defer wg.Done()
 	firstRecordAfterNewOffset := false
-	offs := "0000"
+	offs := uint32(0)
 forLoop:
 	for i := start; i < len(recs); i++ {
 		recs[i].rwm.Lock()
-		if recs[i].recordType == extendedLinearAddressRecordToken {
+		if recs[i].isAddressRecord() {
 			offs = recs[i].offset
 			firstRecordAfterNewOffset = true
 		} else if recs[i].recordType == dataRecordToken {
@@ -72,7 +114,7 @@ forLoop:
 			} else if firstRecordAfterNewOffset && recs[i].offset != offs {
 				recs[i].offset = offs
 				firstRecordAfterNewOffset = false
-			} else if recs[i].offset != offs && offs != "0000" /*only write if offset != 0000 as this is the init value anyway*/ {
+			} else if recs[i].offset != offs && offs != 0 /*only write if offset != 0 as this is the init value anyway*/ {
 				recs[i].offset = offs
 			}
 		}
@@ -168,6 +210,7 @@ func calcDataRoutine(c chan []dataByte, recs []*record) {
 }
 
 // calcDataEntries calculates all data entries with their final addresses.
+// the 16 bit address wraps around within the current segment before the offset is added.
 func (r *record) calcDataEntries() ([]dataByte, error) {
 	var d []dataByte
 	var err error
@@ -175,33 +218,19 @@ func (r *record) calcDataEntries() ([]dataByte, error) {
 	var b byte
 	var lineAddress uint16
 
+	bs, err = HexToByteSlice(r.addressField)
+	if err != nil {
+		return d, err
+	}
 	for i, s := range r.data {
-
 		//add index position to the address of the line to get the individual byte position
-		bs, err = HexToByteSlice(r.addressField)
-		if err != nil {
-			return d, err
-		}
-		//convert bytes to uint16, add index
-		lineAddress = binary.BigEndian.Uint16(bs)
-		lineAddress = lineAddress + uint16(i)
-		//create 2 byte buffer
-		lineAddressBytes := []byte{0, 0}
-		//fill byte slice buffer with thenew value of line address
-		binary.BigEndian.PutUint16(lineAddressBytes, lineAddress)
-		//convert offset string to byte slice
-		bs, err = HexToByteSlice(r.offset)
-		if err != nil {
-			return d, err
-		}
-		//add the offset to the existing address
-		bs = append(bs, lineAddressBytes...)
+		lineAddress = binary.BigEndian.Uint16(bs) + uint16(i)
 		b, err = hexToByte(s)
 		if err != nil {
 			return d, err
 		}
 		//construct data with final uint32 address and a byte as value
-		d = append(d, dataByte{address: binary.BigEndian.Uint32(bs), value: b})
+		d = append(d, dataByte{address: r.offset + uint32(lineAddress), value: b})
 	}
 	return d, nil
 }
@@ -3,11 +3,8 @@ package ihex32
 //const emptyToken = ""
 const beginLineToken = ":"
 const dataRecordToken = "00"
-
-//const endOfFileToken = "01"
-
-//const extendedSegmentAddressRecordToken = "02"
-//const startSegmentAddressRecordToken = "03"
+const endOfFileToken = "01"
+const extendedSegmentAddressRecordToken = "02"
+const startSegmentAddressRecordToken = "03"
 const extendedLinearAddressRecordToken = "04"
-
-//const startLinearAddressRecordToken = "05"
+const startLinearAddressRecordToken = "05"
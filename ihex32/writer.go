@@ -0,0 +1,203 @@
+package ihex32
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// WriteOptions configures how a memory image is serialised to Intel HEX.
+type WriteOptions struct {
+	//RecordLength is the number of data bytes per data record. Valid values are 16, 32 and 64. Defaults to 32.
+	RecordLength int
+	//StartAddress is written as start linear address record (05) if StartAddressSet is true.
+	StartAddress    uint32
+	StartAddressSet bool
+	//LineEnding terminates each record. Either "\r\n" or "\n". Defaults to "\r\n".
+	LineEnding string
+}
+
+// WriteToFile serialises the memory image to an Intel HEX file at the given filepath.
+func WriteToFile(filepath string, h map[uint32]byte, opts WriteOptions) error {
+	file, err := os.Create(filepath)
+	if err != nil {
+		log.Err(err).Msg("could not create hex file")
+		return err
+	}
+	defer file.Close()
+	err = Write(file, h, opts)
+	if err != nil {
+		log.Err(err).Msg("could not write hex file")
+		return err
+	}
+	return nil
+}
+
+// Write serialises the memory image to Intel HEX.
+// contiguous bytes are grouped into data records of opts.RecordLength bytes which are aligned to multiples of the record length.
+// an extended linear address record (04) is written whenever the upper 16 bit of the address change.
+// the file is terminated with an optional start linear address record (05) and the end of file record (01).
+func Write(w io.Writer, h map[uint32]byte, opts WriteOptions) error {
+	if opts.RecordLength == 0 {
+		opts.RecordLength = 32
+	}
+	if opts.RecordLength != 16 && opts.RecordLength != 32 && opts.RecordLength != 64 {
+		err := errors.New("invalid record length " + fmt.Sprint(opts.RecordLength) + ". Expected 16, 32 or 64")
+		log.Err(err).Msg("could not write hex")
+		return err
+	}
+	if opts.LineEnding == "" {
+		opts.LineEnding = "\r\n"
+	}
+	if opts.LineEnding != "\r\n" && opts.LineEnding != "\n" {
+		err := errors.New("invalid line ending " + fmt.Sprintf("%q", opts.LineEnding))
+		log.Err(err).Msg("could not write hex")
+		return err
+	}
+	addresses := make([]uint32, 0, len(h))
+	for a := range h {
+		addresses = append(addresses, a)
+	}
+	sort.Slice(addresses, func(i, j int) bool { return addresses[i] < addresses[j] })
+
+	bw := bufio.NewWriter(w)
+	upper := uint32(0)
+	length := uint32(opts.RecordLength)
+	for i := 0; i < len(addresses); {
+		start := addresses[i]
+		if start>>16 != upper {
+			upper = start >> 16
+			writeRecord(bw, 0, extendedLinearAddressRecordToken, []byte{byte(upper >> 8), byte(upper)}, opts.LineEnding)
+		}
+		//a record ends at a gap, at the next multiple of the record length or at the next 64k boundary
+		data := []byte{h[start]}
+		i++
+		for i < len(addresses) && addresses[i] == start+uint32(len(data)) && addresses[i]%length != 0 && addresses[i]&0xFFFF != 0 {
+			data = append(data, h[addresses[i]])
+			i++
+		}
+		writeRecord(bw, uint16(start), dataRecordToken, data, opts.LineEnding)
+	}
+	if opts.StartAddressSet {
+		sa := make([]byte, 4)
+		binary.BigEndian.PutUint32(sa, opts.StartAddress)
+		writeRecord(bw, 0, startLinearAddressRecordToken, sa, opts.LineEnding)
+	}
+	writeRecord(bw, 0, endOfFileToken, nil, opts.LineEnding)
+	return bw.Flush()
+}
+
+// WriteToFileRoundTrip writes the memory image to filepath using the hex file at templatePath as layout.
+// see WriteRoundTrip.
+func WriteToFileRoundTrip(filepath string, templatePath string, h map[uint32]byte) error {
+	template, err := readFileToString(templatePath)
+	if err != nil {
+		log.Err(err).Msg("template hex file could not be read")
+		return err
+	}
+	file, err := os.Create(filepath)
+	if err != nil {
+		log.Err(err).Msg("could not create hex file")
+		return err
+	}
+	defer file.Close()
+	err = WriteRoundTrip(file, template, h)
+	if err != nil {
+		log.Err(err).Msg("could not write hex file")
+		return err
+	}
+	return nil
+}
+
+// WriteRoundTrip writes the memory image with the record boundaries and line endings of the template,
+// which usually is the original hex file the image has been parsed from.
+// data records are filled with the bytes of the image at their addresses and get a new checksum,
+// all other lines are copied unchanged. lines whose content did not change stay byte-identical.
+// the image has to contain exactly the addresses of the data records of the template.
+func WriteRoundTrip(w io.Writer, template string, h map[uint32]byte) error {
+	bw := bufio.NewWriter(w)
+	offset := uint32(0)
+	written := 0
+	for _, line := range strings.SplitAfter(template, "\n") {
+		content := strings.TrimRight(line, "\r\n")
+		if len(content) < 11 {
+			bw.WriteString(line)
+			continue
+		}
+		r, err := parseRecord(content)
+		if err != nil {
+			log.Err(err).Msg("could not parse template record " + content)
+			return err
+		}
+		switch r.recordType {
+		case extendedLinearAddressRecordToken, extendedSegmentAddressRecordToken:
+			offset = r.offset
+		case dataRecordToken:
+			r.offset = offset
+			entries, err := r.calcDataEntries()
+			if err != nil {
+				log.Err(err).Msg("could not compute addresses of template record " + content)
+				return err
+			}
+			data := make([]byte, len(entries))
+			for i, e := range entries {
+				b, exists := h[e.address]
+				if !exists {
+					err = errors.New("address " + fmt.Sprintf("0x%X", e.address) + " of template not found in image")
+					log.Err(err).Msg("could not write hex")
+					return err
+				}
+				data[i] = b
+			}
+			written += len(entries)
+			rec := formatRecord(binary.BigEndian.Uint16(mustDecode(r.addressField)), dataRecordToken, data)
+			if strings.ToUpper(content) != content {
+				rec = strings.ToLower(rec)
+			}
+			line = rec + line[len(content):]
+		}
+		bw.WriteString(line)
+	}
+	if written != len(h) {
+		err := errors.New("image contains " + fmt.Sprint(len(h)-written) + " bytes outside of the records of the template")
+		log.Err(err).Msg("could not write hex")
+		return err
+	}
+	return bw.Flush()
+}
+
+// writeRecord writes a single record with the given 16 bit address, record type and data.
+func writeRecord(w *bufio.Writer, address uint16, recordType string, data []byte, lineEnding string) {
+	w.WriteString(formatRecord(address, recordType, data))
+	w.WriteString(lineEnding)
+}
+
+// formatRecord formats a record as upper case hex string including the checksum,
+// which is the two's complement of the sum of all bytes of the record.
+func formatRecord(address uint16, recordType string, data []byte) string {
+	rt := hexBytes[recordType]
+	sum := byte(len(data)) + byte(address>>8) + byte(address) + rt
+	var sb strings.Builder
+	sb.Grow(11 + 2*len(data))
+	sb.WriteString(beginLineToken)
+	fmt.Fprintf(&sb, "%02X%04X%02X", len(data), address, rt)
+	for _, b := range data {
+		fmt.Fprintf(&sb, "%02X", b)
+		sum += b
+	}
+	fmt.Fprintf(&sb, "%02X", byte(-int(sum)))
+	return sb.String()
+}
+
+// mustDecode decodes a hex string that has already been validated by the parser.
+func mustDecode(s string) []byte {
+	b, _ := HexToByteSlice(s)
+	return b
+}
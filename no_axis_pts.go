@@ -8,7 +8,7 @@ import (
 )
 
 // getAxisPtsX retrieves the number of X-axis points according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getNoAxisPtsX(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getNoAxisPtsX(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.NoAxisPtsX.DatatypeSet {
 		err := errors.New("noAxisPtsX datatype not set")
 		log.Err(err).Msg("could not retrieve noAxisPtsX value")
@@ -19,17 +19,17 @@ func (cd *CalibrationData) getNoAxisPtsX(rl *a2l.RecordLayout, curPos *uint32) (
 		log.Err(err).Msg("could not retrieve noAxisPtsX value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.NoAxisPtsX.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.NoAxisPtsX.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve noAxisPtsX value")
 		return 0, err
 	}
-	*curPos += uint32(rl.NoAxisPtsX.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.NoAxisPtsX.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // getAxisPtsY retrieves the number of X-axis points according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getNoAxisPtsY(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getNoAxisPtsY(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.NoAxisPtsY.DatatypeSet {
 		err := errors.New("noAxisPtsY datatype not set")
 		log.Err(err).Msg("could not retrieve noAxisPtsY value")
@@ -40,17 +40,17 @@ func (cd *CalibrationData) getNoAxisPtsY(rl *a2l.RecordLayout, curPos *uint32) (
 		log.Err(err).Msg("could not retrieve noAxisPtsY value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.NoAxisPtsY.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.NoAxisPtsY.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve noAxisPtsY value")
 		return 0, err
 	}
-	*curPos += uint32(rl.NoAxisPtsY.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.NoAxisPtsY.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // getAxisPtsZ retrieves the number of X-axis points according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getNoAxisPtsZ(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getNoAxisPtsZ(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.NoAxisPtsZ.DatatypeSet {
 		err := errors.New("noAxisPtsZ datatype not set")
 		log.Err(err).Msg("could not retrieve noAxisPtsZ value")
@@ -61,17 +61,17 @@ func (cd *CalibrationData) getNoAxisPtsZ(rl *a2l.RecordLayout, curPos *uint32) (
 		log.Err(err).Msg("could not retrieve noAxisPtsZ value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.NoAxisPtsZ.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.NoAxisPtsZ.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve noAxisPtsZ value")
 		return 0, err
 	}
-	*curPos += uint32(rl.NoAxisPtsZ.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.NoAxisPtsZ.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // getAxisPts4 retrieves the number of X-axis points according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getNoAxisPts4(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getNoAxisPts4(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.NoAxisPts4.DatatypeSet {
 		err := errors.New("noAxisPts4 datatype not set")
 		log.Err(err).Msg("could not retrieve noAxisPts4 value")
@@ -82,17 +82,17 @@ func (cd *CalibrationData) getNoAxisPts4(rl *a2l.RecordLayout, curPos *uint32) (
 		log.Err(err).Msg("could not retrieve noAxisPts4 value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.NoAxisPts4.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.NoAxisPts4.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve noAxisPts4 value")
 		return 0, err
 	}
-	*curPos += uint32(rl.NoAxisPts4.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.NoAxisPts4.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // getAxisPts5 retrieves the number of X-axis points according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getNoAxisPts5(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getNoAxisPts5(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.NoAxisPts5.DatatypeSet {
 		err := errors.New("noAxisPts5 datatype not set")
 		log.Err(err).Msg("could not retrieve noAxisPts5 value")
@@ -103,11 +103,11 @@ func (cd *CalibrationData) getNoAxisPts5(rl *a2l.RecordLayout, curPos *uint32) (
 		log.Err(err).Msg("could not retrieve noAxisPts5 value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.NoAxisPts5.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.NoAxisPts5.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve noAxisPts5 value")
 		return 0, err
 	}
-	*curPos += uint32(rl.NoAxisPts5.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.NoAxisPts5.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
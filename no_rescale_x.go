@@ -8,7 +8,7 @@ import (
 )
 
 // getNoRescaleX retrieves the number of Rescale X-axis pairs according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getNoRescaleX(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getNoRescaleX(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.NoRescaleX.DatatypeSet {
 		err := errors.New("noRescaleX datatype not set")
 		log.Err(err).Msg("could not retrieve noRescaleX value")
@@ -19,11 +19,11 @@ func (cd *CalibrationData) getNoRescaleX(rl *a2l.RecordLayout, curPos *uint32) (
 		log.Err(err).Msg("could not retrieve noRescaleX value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.NoRescaleX.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.NoRescaleX.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve noRescaleX value")
 		return 0, err
 	}
-	*curPos += uint32(rl.NoRescaleX.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.NoRescaleX.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
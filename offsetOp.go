@@ -8,7 +8,7 @@ import (
 )
 
 // getOffsetX retrieves the offset operator according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getOffsetX(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getOffsetX(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.OffsetX.DatatypeSet {
 		err := errors.New("offsetX datatype not set")
 		log.Err(err).Msg("could not retrieve offsetX value")
@@ -19,17 +19,17 @@ func (cd *CalibrationData) getOffsetX(rl *a2l.RecordLayout, curPos *uint32) (int
 		log.Err(err).Msg("could not retrieve offsetX value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.OffsetX.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.OffsetX.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve offsetX value")
 		return 0, err
 	}
-	*curPos += uint32(rl.OffsetX.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.OffsetX.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // getOffsetY retrieves the offset operator according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getOffsetY(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getOffsetY(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.OffsetY.DatatypeSet {
 		err := errors.New("offsetY datatype not set")
 		log.Err(err).Msg("could not retrieve offsetY value")
@@ -40,17 +40,17 @@ func (cd *CalibrationData) getOffsetY(rl *a2l.RecordLayout, curPos *uint32) (int
 		log.Err(err).Msg("could not retrieve offsetY value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.OffsetY.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.OffsetY.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve offsetY value")
 		return 0, err
 	}
-	*curPos += uint32(rl.OffsetY.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.OffsetY.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // getOffsetZ retrieves the offset operator according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getOffsetZ(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getOffsetZ(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.OffsetZ.DatatypeSet {
 		err := errors.New("offsetZ datatype not set")
 		log.Err(err).Msg("could not retrieve offsetZ value")
@@ -61,17 +61,17 @@ func (cd *CalibrationData) getOffsetZ(rl *a2l.RecordLayout, curPos *uint32) (int
 		log.Err(err).Msg("could not retrieve offsetZ value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.OffsetZ.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.OffsetZ.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve offsetZ value")
 		return 0, err
 	}
-	*curPos += uint32(rl.OffsetZ.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.OffsetZ.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // getOffset4 retrieves the offset operator according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getOffset4(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getOffset4(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.Offset4.DatatypeSet {
 		err := errors.New("offset4 datatype not set")
 		log.Err(err).Msg("could not retrieve offset4 value")
@@ -82,17 +82,17 @@ func (cd *CalibrationData) getOffset4(rl *a2l.RecordLayout, curPos *uint32) (int
 		log.Err(err).Msg("could not retrieve offset4 value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.Offset4.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.Offset4.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve offset4 value")
 		return 0, err
 	}
-	*curPos += uint32(rl.Offset4.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.Offset4.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // getOffset5 retrieves the offset operator according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getOffset5(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getOffset5(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.Offset5.DatatypeSet {
 		err := errors.New("offset5 datatype not set")
 		log.Err(err).Msg("could not retrieve offset5 value")
@@ -103,11 +103,11 @@ func (cd *CalibrationData) getOffset5(rl *a2l.RecordLayout, curPos *uint32) (int
 		log.Err(err).Msg("could not retrieve offset5 value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.Offset5.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.Offset5.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve offset5 value")
 		return 0, err
 	}
-	*curPos += uint32(rl.Offset5.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.Offset5.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
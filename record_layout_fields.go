@@ -0,0 +1,200 @@
+package calibrationReader
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
+)
+
+// RecordLayoutField describes where one field of a record layout is located within the hex file
+// and which value has been decoded from it.
+type RecordLayoutField struct {
+	//Name of the field within a2l.RecordLayout, e.g. "NoAxisPtsX" or "FncValues"
+	Name string `json:"name"`
+	//Position of the field as defined within the record layout
+	Position uint16 `json:"position"`
+	//Address is the absolute address of the first byte of the field after padding
+	Address uint32 `json:"address"`
+	//Length is the number of bytes occupied by the field including memory reserved by a static record layout.
+	//for indirect addressing only the pointer is part of the deposit.
+	Length uint32 `json:"length"`
+	//Datatype of the elements of the field. For indirect addressing the datatype of the pointer.
+	Datatype a2l.DataTypeEnum `json:"datatype"`
+	//Padding is the number of bytes inserted before the field to align it
+	Padding uint32 `json:"padding"`
+	//RawValue is the decimal value decoded from the field. []float64 for axis points and function values,
+	//[]RescalePair for rescale axes and nil for fields that are not evaluated (RESERVED, RIP_ADDR, SRC_ADDR).
+	RawValue interface{} `json:"rawValue"`
+}
+
+// RecordLayoutMap is the memory footprint of a characteristic within the hex file.
+// it lists all fields of the record layout in the order they are stored in memory.
+type RecordLayoutMap struct {
+	Characteristic string `json:"characteristic"`
+	RecordLayout   string `json:"recordLayout"`
+	//Address is the start address of the characteristic
+	Address uint32 `json:"address"`
+	//Size is the number of bytes from the start address up to the end of the last field
+	Size   uint32              `json:"size"`
+	Fields []RecordLayoutField `json:"fields"`
+}
+
+// GetRecordLayoutMap walks the record layout of the characteristic with the given identifier
+// the same way GetCharacteristicValues does and returns the address, length, padding and raw value of each field.
+// the values are not converted to their physical representation,
+// so the memory footprint can be retrieved even if the conversion fails.
+func (cd *CalibrationData) GetRecordLayoutMap(name string) (RecordLayoutMap, error) {
+	c, exists := cd.A2l.Project.Modules[cd.ModuleIndex].Characteristics[name]
+	if !exists {
+		err := errors.New("characteristic " + name + " not found")
+		log.Err(err).Msg("could not get record layout map")
+		return RecordLayoutMap{}, err
+	}
+	if len(c.VirtualCharacteristic) > 0 {
+		err := errors.New("virtual characteristic " + name + " has no memory")
+		log.Err(err).Msg("could not get record layout map")
+		return RecordLayoutMap{}, err
+	}
+	rl, err := cd.getRecordLayout(&c)
+	if err != nil {
+		log.Err(err).Msg("could not get record layout map")
+		return RecordLayoutMap{}, err
+	}
+	cv := NewCharacteristicValues(&c, rl)
+	err = cv.resolveAxes(cd, []string{name})
+	if err != nil {
+		log.Err(err).Msg("could not resolve axes of characteristic '" + name + "'")
+		return RecordLayoutMap{}, err
+	}
+	err = getValuesFromHex(cv, cd)
+	if err != nil {
+		log.Err(err).Msg("could not walk record layout of characteristic '" + name + "'")
+		return RecordLayoutMap{}, err
+	}
+	address, err := cd.ResolveAddress(c.Address, c.EcuAddressExtension.Extension)
+	if err != nil {
+		log.Err(err).Msg("could not resolve address of characteristic '" + name + "'")
+		return RecordLayoutMap{}, err
+	}
+	rlm := RecordLayoutMap{Characteristic: name, RecordLayout: rl.Name, Address: address, Fields: cv.fields}
+	for _, f := range cv.fields {
+		if end := f.Address + f.Length; end > address+rlm.Size {
+			rlm.Size = end - address
+		}
+	}
+	return rlm, nil
+}
+
+// String renders the record layout map as a table with one line per field.
+func (rlm RecordLayoutMap) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s (%s) 0x%X-0x%X, %d bytes\n", rlm.Characteristic, rlm.RecordLayout, rlm.Address, rlm.Address+rlm.Size, rlm.Size)
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "POS\tFIELD\tADDRESS\tLENGTH\tPADDING\tDATATYPE\tRAW")
+	for _, f := range rlm.Fields {
+		datatype := f.Datatype.String()
+		if datatype == "" {
+			datatype = "-"
+		}
+		raw := "-"
+		if f.RawValue != nil {
+			raw = fmt.Sprint(f.RawValue)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t0x%X\t%d\t%d\t%s\t%s\n", f.Position, f.Name, f.Address, f.Length, f.Padding, datatype, raw)
+	}
+	tw.Flush()
+	return sb.String()
+}
+
+// JSON renders the record layout map as indented JSON.
+func (rlm RecordLayoutMap) JSON() ([]byte, error) {
+	b, err := json.MarshalIndent(rlm, "", "  ")
+	if err != nil {
+		log.Err(err).Msg("could not marshal record layout map of characteristic '" + rlm.Characteristic + "'")
+		return nil, err
+	}
+	return b, nil
+}
+
+// newRecordLayoutField describes the field that has been read from start up to end.
+func (cv *CharacteristicValues) newRecordLayoutField(rl *a2l.RecordLayout, field string, position uint16, start uint32, end uint32, padding uint32) RecordLayoutField {
+	f := RecordLayoutField{Name: field, Position: position, Address: start, Padding: padding, RawValue: cv.getFieldRawValue(field)}
+	if dte, exists := getFieldDatatype(field, rl); exists {
+		f.Datatype = dte
+	}
+	if end > start {
+		f.Length = end - start
+	}
+	return f
+}
+
+// getFieldRawValue returns the decimal value that has been read for the given record layout field.
+func (cv *CharacteristicValues) getFieldRawValue(field string) interface{} {
+	switch field {
+	case "AxisPtsX":
+		return cv.AxisXRawValues
+	case "AxisPtsY":
+		return cv.AxisYRawValues
+	case "AxisPtsZ":
+		return cv.AxisZRawValues
+	case "AxisPts4":
+		return cv.Axis4RawValues
+	case "AxisPts5":
+		return cv.Axis5RawValues
+	case "AxisRescaleX":
+		return cv.rescalePairsX
+	case "DistOpX":
+		return cv.distOpXValue
+	case "DistOpY":
+		return cv.distOpYValue
+	case "DistOpZ":
+		return cv.distOpZValue
+	case "DistOp4":
+		return cv.distOp4Value
+	case "DistOp5":
+		return cv.distOp5Value
+	case "FncValues":
+		return cv.fncValues
+	case "Identification":
+		return cv.identificationValue
+	case "NoAxisPtsX":
+		return cv.noAxisPtsXValue
+	case "NoAxisPtsY":
+		return cv.noAxisPtsYValue
+	case "NoAxisPtsZ":
+		return cv.noAxisPtsZValue
+	case "NoAxisPts4":
+		return cv.noAxisPts4Value
+	case "NoAxisPts5":
+		return cv.noAxisPts5Value
+	case "NoRescaleX":
+		return cv.noRescaleXValue
+	case "OffsetX":
+		return cv.offsetXValue
+	case "OffsetY":
+		return cv.offsetYValue
+	case "OffsetZ":
+		return cv.offsetZValue
+	case "Offset4":
+		return cv.offset4Value
+	case "Offset5":
+		return cv.offset5Value
+	case "ShiftOpX":
+		return cv.shiftOpXValue
+	case "ShiftOpY":
+		return cv.shiftOpYValue
+	case "ShiftOpZ":
+		return cv.shiftOpZValue
+	case "ShiftOp4":
+		return cv.shiftOp4Value
+	case "ShiftOp5":
+		return cv.shiftOp5Value
+	default:
+		return nil
+	}
+}
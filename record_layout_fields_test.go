@@ -0,0 +1,57 @@
+package calibrationReader
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestRecordLayoutMap(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	rlm, err := cd.GetRecordLayoutMap("ASAM.C.MAP.STD_AXIS.STD_AXIS")
+	if err != nil {
+		t.Fatalf("could not get record layout map: %s", err)
+	}
+	expected := []RecordLayoutField{
+		{Name: "NoAxisPtsX", Position: 1, Address: 0x810400, Length: 1, Datatype: a2l.UBYTE},
+		{Name: "NoAxisPtsY", Position: 2, Address: 0x810401, Length: 1, Datatype: a2l.UBYTE},
+		{Name: "AxisPtsX", Position: 3, Address: 0x810402, Length: 4, Datatype: a2l.SBYTE},
+		{Name: "AxisPtsY", Position: 4, Address: 0x810406, Length: 5, Datatype: a2l.SBYTE},
+		{Name: "FncValues", Position: 5, Address: 0x81040C, Length: 40, Datatype: a2l.SWORD, Padding: 1},
+	}
+	if len(rlm.Fields) != len(expected) {
+		t.Fatalf("expected %d fields, got %d", len(expected), len(rlm.Fields))
+	}
+	for i, e := range expected {
+		f := rlm.Fields[i]
+		if f.Name != e.Name || f.Position != e.Position || f.Address != e.Address || f.Length != e.Length || f.Datatype != e.Datatype || f.Padding != e.Padding {
+			t.Errorf("field %d: expected %+v, got %+v", i, e, f)
+		}
+	}
+	if rlm.Size != 0x34 {
+		t.Errorf("expected size of 52 bytes, got %d", rlm.Size)
+	}
+	if fmt.Sprint(rlm.Fields[3].RawValue) != "[2 3 4 5 6]" {
+		t.Errorf("unexpected raw value of AxisPtsY %v", rlm.Fields[3].RawValue)
+	}
+	if !strings.Contains(rlm.String(), "0x81040C") {
+		t.Errorf("text rendering misses address of FncValues:\n%s", rlm.String())
+	}
+	b, err := rlm.JSON()
+	if err != nil {
+		t.Fatalf("could not render JSON: %s", err)
+	}
+	var decoded RecordLayoutMap
+	if err = json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("could not decode JSON: %s", err)
+	}
+	if decoded.Fields[4].Address != 0x81040C || decoded.RecordLayout != "RL.MAP.SWORD.SBYTE.SBYTE.INCR" {
+		t.Errorf("unexpected JSON rendering %s", string(b))
+	}
+}
@@ -14,32 +14,6 @@ import (
 	"github.com/x448/float16"
 )
 
-func (cd *CalibrationData) getSystemConstant(ident string) (a2l.SystemConstant, error) {
-	modPar := cd.A2l.Project.Modules[cd.ModuleIndex].ModPar
-	s, exists := modPar.SystemConstants[ident]
-	if !exists {
-		err := errors.New("no system constant with name " + ident)
-		log.Err(err).Msg("system constant not found")
-		return s, err
-	}
-	return s, nil
-}
-
-func (cd *CalibrationData) getSystemConstantValue(ident string) (string, error) {
-	sc, err := cd.getSystemConstant(ident)
-	if err != nil {
-		log.Err(err).Msg("could not get value of system constant")
-		return "", err
-	}
-	var val string
-	if !sc.ValueSet {
-		err = errors.New("no value defined in system constant " + sc.Name)
-		log.Err(err).Msg("could not get value of system constant")
-		return "", err
-	}
-	return val, nil
-}
-
 // GetObjectByIdent returns an object with a given identifier that is defined within the a2l
 // not all datastructures are checked. Only the most relevant ones
 func (cd *CalibrationData) GetObjectByIdent(ident string) []interface{} {
@@ -109,33 +83,54 @@ func hexToByteSlice(hexVal string) ([]byte, error) {
 	return decoded, err
 }
 
-// convertStringToUint32Address is used to convert the adresses in string format in the characteristics to a uint32
+// convertStringToUint32Address is used to convert the adresses in string format in the characteristics to a uint32.
+// addresses are written as hex literals (e.g. 0x810000) in the a2l and therefore independent of the byte order of the ecu.
 func (cd *CalibrationData) convertStringToUint32Address(str string) (uint32, error) {
-	var val uint32
-	byteSlice, err := hexToByteSlice(strings.ReplaceAll(str, "0x", ""))
+	val, err := strconv.ParseUint(strings.TrimSpace(str), 0, 32)
 	if err != nil {
 		log.Err(err).Msg("string '" + str + "' could not be parsed")
-		return val, err
+		return 0, err
+	}
+	return uint32(val), nil
+}
+
+// getPointerTarget reads the pointer stored at curPos for the indirect address types PBYTE, PWORD, PLONG and PLONGLONG
+// and returns the address it points to. curPos is incremented by the size of the pointer.
+// the pointer is decoded with the byte order of the object it belongs to. In case the target address is not part of the hex file an error is returned.
+func (cd *CalibrationData) getPointerTarget(curPos *uint32, at a2l.AddrTypeEnum, rl *a2l.RecordLayout, bo a2l.ByteOrderEnum) (uint32, error) {
+	dte, isPointer := getPointerDatatype(at)
+	if !isPointer {
+		err := errors.New("address type " + string(at) + " is not an indirect address type")
+		log.Err(err).Msg("could not dereference pointer")
+		return 0, err
 	}
-	modCom := &cd.A2l.Project.Modules[cd.ModuleIndex].ModCommon
-	if modCom.ByteOrder.ByteOrder == a2l.MsbFirstMswLast || modCom.ByteOrder.ByteOrder == a2l.MsbLastMswFirst {
-		err = errors.New("unexpected byte order")
-		log.Err(err).Msg("byte order " + modCom.ByteOrder.ByteOrder.String() + "not implemented")
-		return val, err
+	bufBytes, err := cd.getValue(curPos, dte, rl)
+	if err != nil {
+		log.Err(err).Msg("could not read pointer")
+		return 0, err
 	}
-	if !modCom.ByteOrder.ByteOrderSet || modCom.ByteOrder.ByteOrder == a2l.BigEndian || modCom.ByteOrder.ByteOrder == a2l.MsbFirst {
-		val = binary.BigEndian.Uint32(byteSlice)
-	} else {
-		val = binary.LittleEndian.Uint32(byteSlice)
+	ptr, err := decodeDatatype(bufBytes, dte, bo)
+	if err != nil {
+		log.Err(err).Msg("could not convert pointer")
+		return 0, err
 	}
-	return val, nil
+	if ptr < 0 || ptr > math.MaxUint32 {
+		err = errors.New("pointer target " + fmt.Sprintf("0x%X", uint64(ptr)) + " at address " + fmt.Sprintf("0x%X", *curPos) + " exceeds 32 bit address space")
+		log.Err(err).Msg("could not dereference pointer")
+		return 0, err
+	}
+	target, err := cd.resolvePointerTarget(uint32(ptr), at)
+	if err != nil {
+		log.Err(err).Msg("could not dereference pointer at address " + fmt.Sprintf("0x%X", *curPos))
+		return 0, err
+	}
+	*curPos += uint32(dte.GetDatatypeLength() / 8)
+	return target, nil
 }
 
-// converts a byteSlice into a a2l.DatatypeEnum datatype.
+// decodeDatatype converts a byteSlice stored with the given byte order into a a2l.DatatypeEnum datatype.
 // if not enough bytes are supplied the conversion fails.
-// if MsbFirstMswLast or MsbLastMswFirst are used as binary encoding then the conversion fails
-// as those are not implemented
-func (cd *CalibrationData) convertByteSliceToDatatype(byteSlice []byte, dte a2l.DataTypeEnum) (float64, error) {
+func decodeDatatype(byteSlice []byte, dte a2l.DataTypeEnum, bo a2l.ByteOrderEnum) (float64, error) {
 	//bounds check
 	if len(byteSlice) == 0 || len(byteSlice)*8 < int(dte.GetDatatypeLength()) {
 		err := errors.New("byte slice holds " + fmt.Sprintf("%d", len(byteSlice)) + " bytes. " +
@@ -143,92 +138,190 @@ func (cd *CalibrationData) convertByteSliceToDatatype(byteSlice []byte, dte a2l.
 		log.Err(err).Msg("conversion failed")
 		return 0.0, err
 	}
-	//check which byteorder is used
-	modCom := &cd.A2l.Project.Modules[cd.ModuleIndex].ModCommon
-	if modCom.ByteOrder.ByteOrder == a2l.MsbFirstMswLast || modCom.ByteOrder.ByteOrder == a2l.MsbLastMswFirst {
-		err := errors.New("unexpected byte order")
-		log.Err(err).Msg("byte order " + modCom.ByteOrder.ByteOrder.String() + "not implemented")
+	b, err := toBigEndian(byteSlice[:dte.GetDatatypeLength()/8], bo)
+	if err != nil {
+		log.Err(err).Msg("conversion failed")
 		return 0.0, err
 	}
-	if !modCom.ByteOrder.ByteOrderSet || modCom.ByteOrder.ByteOrder == a2l.BigEndian || modCom.ByteOrder.ByteOrder == a2l.MsbFirst {
-		switch dte {
-		case a2l.UBYTE:
-			return float64(byteSlice[0]), nil
-		case a2l.SBYTE:
-			return float64(int8(byteSlice[0])), nil
-		case a2l.UWORD:
-			val := binary.BigEndian.Uint32(byteSlice)
-			return float64(val), nil
-		case a2l.SWORD:
-			val := int32(binary.BigEndian.Uint32(byteSlice))
-			return float64(val), nil
-		case a2l.ULONG:
-			val := binary.BigEndian.Uint64(byteSlice)
-			return float64(val), nil
-		case a2l.SLONG:
-			val := int64(binary.BigEndian.Uint64(byteSlice))
-			return float64(val), nil
-		case a2l.AUint64:
-			val := binary.BigEndian.Uint64(byteSlice)
-			return float64(val), nil
-		case a2l.AInt64:
-			val := int64(binary.BigEndian.Uint64(byteSlice))
-			return float64(val), nil
-		case a2l.Float16Ieee:
-			val := float16.Frombits(binary.BigEndian.Uint16(byteSlice))
-			return float64(val), nil
-		case a2l.Float32Ieee:
-			val := math.Float32frombits(binary.BigEndian.Uint32(byteSlice))
-			return float64(val), nil
-		case a2l.Float64Ieee:
-			val := math.Float64frombits(binary.BigEndian.Uint64(byteSlice))
-			return float64(val), nil
-		default:
-			err := errors.New("unexpected datatype")
-			log.Err(err).Msg("datatype " + dte.String() + " not implemented")
-			return 0.0, err
+	switch dte {
+	case a2l.UBYTE:
+		return float64(b[0]), nil
+	case a2l.SBYTE:
+		return float64(int8(b[0])), nil
+	case a2l.UWORD:
+		val := binary.BigEndian.Uint16(b)
+		return float64(val), nil
+	case a2l.SWORD:
+		val := int16(binary.BigEndian.Uint16(b))
+		return float64(val), nil
+	case a2l.ULONG:
+		val := binary.BigEndian.Uint32(b)
+		return float64(val), nil
+	case a2l.SLONG:
+		val := int32(binary.BigEndian.Uint32(b))
+		return float64(val), nil
+	case a2l.AUint64:
+		val := binary.BigEndian.Uint64(b)
+		return float64(val), nil
+	case a2l.AInt64:
+		val := int64(binary.BigEndian.Uint64(b))
+		return float64(val), nil
+	case a2l.Float16Ieee:
+		val := float16.Frombits(binary.BigEndian.Uint16(b))
+		return float64(val.Float32()), nil
+	case a2l.Float32Ieee:
+		val := math.Float32frombits(binary.BigEndian.Uint32(b))
+		return float64(val), nil
+	case a2l.Float64Ieee:
+		val := math.Float64frombits(binary.BigEndian.Uint64(b))
+		return float64(val), nil
+	default:
+		err := errors.New("unexpected datatype")
+		log.Err(err).Msg("datatype " + dte.String() + " not implemented")
+		return 0.0, err
+	}
+}
+
+// encodeDatatype converts a decimal value into the byte representation of the given datatype and byte order.
+// integer datatypes are rounded to the nearest integer. Values outside of the range of the datatype cannot be encoded.
+func encodeDatatype(val float64, dte a2l.DataTypeEnum, bo a2l.ByteOrderEnum) ([]byte, error) {
+	b := make([]byte, dte.GetDatatypeLength()/8)
+	min, max, err := getDatatypeRange(dte)
+	if err != nil {
+		log.Err(err).Msg("conversion failed")
+		return nil, err
+	}
+	if isFloatDatatype(dte) {
+		//infinity is a valid IEEE value
+		min, max = math.Inf(-1), math.Inf(1)
+	}
+	if math.IsNaN(val) && !isFloatDatatype(dte) {
+		err := errors.New("NaN cannot be encoded as datatype " + dte.String())
+		log.Err(err).Msg("conversion failed")
+		return nil, err
+	}
+	if !isFloatDatatype(dte) {
+		val = math.Round(val)
+	}
+	if val < min || val > max {
+		err := errors.New("value " + strconv.FormatFloat(val, 'g', -1, 64) + " exceeds range of datatype " + dte.String())
+		log.Err(err).Msg("conversion failed")
+		return nil, err
+	}
+	switch dte {
+	case a2l.UBYTE:
+		b[0] = uint8(val)
+	case a2l.SBYTE:
+		b[0] = uint8(int8(val))
+	case a2l.UWORD:
+		binary.BigEndian.PutUint16(b, uint16(val))
+	case a2l.SWORD:
+		binary.BigEndian.PutUint16(b, uint16(int16(val)))
+	case a2l.ULONG:
+		binary.BigEndian.PutUint32(b, uint32(val))
+	case a2l.SLONG:
+		binary.BigEndian.PutUint32(b, uint32(int32(val)))
+	case a2l.AUint64:
+		if val >= math.MaxUint64 {
+			//float64 cannot represent MaxUint64 exactly
+			binary.BigEndian.PutUint64(b, math.MaxUint64)
+		} else {
+			binary.BigEndian.PutUint64(b, uint64(val))
+		}
+	case a2l.AInt64:
+		if val >= math.MaxInt64 {
+			binary.BigEndian.PutUint64(b, math.MaxInt64)
+		} else {
+			binary.BigEndian.PutUint64(b, uint64(int64(val)))
+		}
+	case a2l.Float16Ieee:
+		binary.BigEndian.PutUint16(b, float16.Fromfloat32(float32(val)).Bits())
+	case a2l.Float32Ieee:
+		binary.BigEndian.PutUint32(b, math.Float32bits(float32(val)))
+	case a2l.Float64Ieee:
+		binary.BigEndian.PutUint64(b, math.Float64bits(val))
+	}
+	//converting from big endian is the same operation as converting to big endian
+	return toBigEndian(b, bo)
+}
+
+// toBigEndian rearranges the bytes of a value stored with the given byte order so that they are in big endian order.
+// the operation is its own inverse and can therefore also be used to convert big endian bytes into the given byte order.
+//
+// MSB_FIRST / BIG_ENDIAN:	0x11223344 is stored as 11 22 33 44
+// MSB_LAST / LITTLE_ENDIAN:	0x11223344 is stored as 44 33 22 11
+// MSB_FIRST_MSW_LAST:		0x11223344 is stored as 33 44 11 22 (big endian words, least significant word first)
+// MSB_LAST_MSW_FIRST:		0x11223344 is stored as 22 11 44 33 (little endian words, most significant word first)
+func toBigEndian(byteSlice []byte, bo a2l.ByteOrderEnum) ([]byte, error) {
+	b := make([]byte, len(byteSlice))
+	copy(b, byteSlice)
+	switch bo {
+	case a2l.BigEndian, a2l.MsbFirst:
+	case a2l.LittleEndian, a2l.MsbLast:
+		for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+			b[i], b[j] = b[j], b[i]
+		}
+	case a2l.MsbFirstMswLast:
+		if len(b)%2 != 0 && len(b) > 1 {
+			err := errors.New("byte order " + bo.String() + " needs a multiple of two bytes")
+			log.Err(err).Msg("could not rearrange bytes")
+			return nil, err
 		}
-	} else if modCom.ByteOrder.ByteOrder == a2l.LittleEndian || modCom.ByteOrder.ByteOrder == a2l.MsbLast {
-		switch dte {
-		case a2l.UBYTE:
-			return float64(byteSlice[0]), nil
-		case a2l.SBYTE:
-			return float64(int8(byteSlice[0])), nil
-		case a2l.UWORD:
-			val := binary.LittleEndian.Uint32(byteSlice)
-			return float64(val), nil
-		case a2l.SWORD:
-			val := int32(binary.LittleEndian.Uint32(byteSlice))
-			return float64(val), nil
-		case a2l.ULONG:
-			val := binary.LittleEndian.Uint64(byteSlice)
-			return float64(val), nil
-		case a2l.SLONG:
-			val := int64(binary.LittleEndian.Uint64(byteSlice))
-			return float64(val), nil
-		case a2l.AUint64:
-			val := binary.LittleEndian.Uint64(byteSlice)
-			return float64(val), nil
-		case a2l.AInt64:
-			val := int64(binary.LittleEndian.Uint64(byteSlice))
-			return float64(val), nil
-		case a2l.Float16Ieee:
-			val := float16.Frombits(binary.LittleEndian.Uint16(byteSlice))
-			return float64(val), nil
-		case a2l.Float32Ieee:
-			val := math.Float32frombits(binary.LittleEndian.Uint32(byteSlice))
-			return float64(val), nil
-		case a2l.Float64Ieee:
-			val := math.Float64frombits(binary.LittleEndian.Uint64(byteSlice))
-			return float64(val), nil
-		default:
-			err := errors.New("unexpected datatype")
-			log.Err(err).Msg("datatype " + dte.String() + " not implemented")
-			return 0.0, err
+		//reverse the order of the words. The bytes within each word stay in place.
+		for i, j := 0, len(b)-2; i < j; i, j = i+2, j-2 {
+			b[i], b[i+1], b[j], b[j+1] = b[j], b[j+1], b[i], b[i+1]
 		}
-	} else {
+	case a2l.MsbLastMswFirst:
+		if len(b)%2 != 0 && len(b) > 1 {
+			err := errors.New("byte order " + bo.String() + " needs a multiple of two bytes")
+			log.Err(err).Msg("could not rearrange bytes")
+			return nil, err
+		}
+		//swap the bytes within each word. The words stay in place.
+		for i := 0; i+1 < len(b); i += 2 {
+			b[i], b[i+1] = b[i+1], b[i]
+		}
+	default:
 		err := errors.New("unexpected byte order")
-		log.Err(err).Msg("byte order " + modCom.ByteOrder.ByteOrder.String() + " not implemented")
-		return 0.0, err
+		log.Err(err).Msg("byte order " + bo.String() + " not implemented")
+		return nil, err
 	}
+	return b, nil
+}
+
+// getDatatypeRange returns the smallest and the largest finite value that can be represented by the datatype.
+func getDatatypeRange(dte a2l.DataTypeEnum) (float64, float64, error) {
+	switch dte {
+	case a2l.UBYTE:
+		return 0, math.MaxUint8, nil
+	case a2l.SBYTE:
+		return math.MinInt8, math.MaxInt8, nil
+	case a2l.UWORD:
+		return 0, math.MaxUint16, nil
+	case a2l.SWORD:
+		return math.MinInt16, math.MaxInt16, nil
+	case a2l.ULONG:
+		return 0, math.MaxUint32, nil
+	case a2l.SLONG:
+		return math.MinInt32, math.MaxInt32, nil
+	case a2l.AUint64:
+		return 0, math.MaxUint64, nil
+	case a2l.AInt64:
+		return math.MinInt64, math.MaxInt64, nil
+	case a2l.Float16Ieee:
+		return -65504, 65504, nil
+	case a2l.Float32Ieee:
+		return -math.MaxFloat32, math.MaxFloat32, nil
+	case a2l.Float64Ieee:
+		return -math.MaxFloat64, math.MaxFloat64, nil
+	default:
+		err := errors.New("unexpected datatype")
+		log.Err(err).Msg("datatype " + dte.String() + " not implemented")
+		return 0, 0, err
+	}
+}
+
+// isFloatDatatype reports whether the datatype is one of the IEEE floating point types.
+func isFloatDatatype(dte a2l.DataTypeEnum) bool {
+	return dte == a2l.Float16Ieee || dte == a2l.Float32Ieee || dte == a2l.Float64Ieee
 }
@@ -7,8 +7,8 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// getDistOpX retrieves the identifier in the deposit structure according to its layout
-// specified within the record layout and their values as calibrated in the hex file
+// getReserved skips the reserved bytes in the deposit structure according to its layout
+// specified within the record layout
 func (cd *CalibrationData) getReserved(rl *a2l.RecordLayout, curPos *uint32) error {
 	if !rl.Reserved.DataSizeSet {
 		err := errors.New("reserved datasize not set")
@@ -16,6 +16,9 @@ func (cd *CalibrationData) getReserved(rl *a2l.RecordLayout, curPos *uint32) err
 		return err
 	}
 	//Value of reserved is not relevant. Only its datasize and the resulting offset for other datastructres are necessary
-	*curPos += uint32(rl.Reserved.DataSize.GetDataSizeLength())
+	//the reserved bytes are aligned like an unsigned value of the same size.
+	dte, _ := getReservedDatatype(rl)
+	*curPos = cd.getNextAlignedAddress(*curPos, dte, rl)
+	*curPos += uint32(rl.Reserved.DataSize.GetDataSizeLength() / 8)
 	return nil
 }
@@ -0,0 +1,479 @@
+package calibrationReader
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
+)
+
+// SetCharacteristicPhysical writes physical values into the memory image of the characteristic with the given name.
+// values is shaped like the result of GetCharacteristicValues: PhyValues contains the function values and
+// AxisXPhyValues to Axis5PhyValues the axis points. axes that are nil keep their current axis points.
+// numeric values are rounded to STEP_SIZE and have to be within the limits of the characteristic or axis description.
+// the number of axis points of standard axes may change as long as NO_AXIS_PTS is part of the record layout
+// and the maximum number of axis points is not exceeded. the function values have to match the new dimensions.
+// the values are validated by reading them back. in case of an error the memory image is left unchanged.
+func (cd *CalibrationData) SetCharacteristicPhysical(name string, values CharacteristicValues) error {
+	err := cd.setCharacteristic(name, values, true)
+	if err != nil {
+		log.Err(err).Msg("could not set physical values of characteristic " + name)
+		return err
+	}
+	return nil
+}
+
+// SetCharacteristicRaw writes decimal values into the memory image of the characteristic with the given name.
+// it behaves like SetCharacteristicPhysical but takes RawValues and AxisXRawValues to Axis5RawValues
+// and skips the inverse conversion and the rounding to STEP_SIZE.
+func (cd *CalibrationData) SetCharacteristicRaw(name string, values CharacteristicValues) error {
+	err := cd.setCharacteristic(name, values, false)
+	if err != nil {
+		log.Err(err).Msg("could not set raw values of characteristic " + name)
+		return err
+	}
+	return nil
+}
+
+// setCharacteristic converts the given values to their decimal representation and writes them to the memory image.
+// changed numbers of axis points are written first, so the positions of the axis points and function values
+// can be determined by reading the characteristic again.
+func (cd *CalibrationData) setCharacteristic(name string, values CharacteristicValues, physical bool) error {
+	cv, err := cd.GetCharacteristicValues(name)
+	if err != nil {
+		return err
+	}
+	err = cv.checkWritable()
+	if err != nil {
+		return err
+	}
+	var axes [5][]float64
+	dims := append([]int(nil), cv.Dimensions...)
+	resized := false
+	if cv.Type != a2l.ValBlk && cv.Type != a2l.ASCII {
+		for i := range dims {
+			axes[i], err = cd.getNewAxisPoints(&cv, &values, i, physical)
+			if err != nil {
+				return err
+			}
+			if axes[i] != nil && len(axes[i]) != dims[i] {
+				dims[i] = len(axes[i])
+				resized = true
+			}
+		}
+	}
+	input := values.RawValues
+	if physical {
+		input = values.PhyValues
+	}
+	var dec []float64
+	if input != nil {
+		dec, err = cd.getNewFncValues(&cv, input, dims, physical)
+		if err != nil {
+			return err
+		}
+	} else if resized {
+		return errors.New("function values are required when the number of axis points changes")
+	}
+
+	w := newImageWriter(cv.image)
+	err = cd.writeCharacteristic(&cv, w, axes, dec, resized)
+	if err != nil {
+		w.rollback()
+		return err
+	}
+	//validate the change by reading it back
+	err = cd.verifyCharacteristic(&cv, axes, dec)
+	if err != nil {
+		w.rollback()
+		return err
+	}
+	return nil
+}
+
+// checkWritable returns an error for virtual and read-only characteristics
+// as well as for characteristics whose CALIBRATION_ACCESS does not allow changes.
+func (cv *CharacteristicValues) checkWritable() error {
+	switch {
+	case cv.IsVirtual:
+		return errors.New("virtual characteristic " + cv.Name + " has no memory")
+	case cv.ReadOnly:
+		return errors.New("characteristic " + cv.Name + " is read-only")
+	case cv.characteristic.CalibrationAccess == a2l.NoCalibration || cv.characteristic.CalibrationAccess == a2l.NotInMcdSystem:
+		return errors.New("calibration access " + string(cv.characteristic.CalibrationAccess) + " of characteristic " + cv.Name + " does not allow changes")
+	case cv.image == nil:
+		return errors.New("characteristic " + cv.Name + " has not been read from a memory image")
+	}
+	return nil
+}
+
+// getNewAxisPoints converts the axis points of the given axis to decimal values.
+// nil is returned if no axis points are given or if they equal the current axis points.
+// only standard axes are stored within the deposit of the characteristic and can be changed.
+func (cd *CalibrationData) getNewAxisPoints(cv *CharacteristicValues, values *CharacteristicValues, axisIndex int, physical bool) ([]float64, error) {
+	raw, phy := values.getAxisValuesRef(axisIndex)
+	var input interface{} = *phy
+	if !physical {
+		input = *raw
+	}
+	if input == nil {
+		return nil, nil
+	}
+	if v := reflect.ValueOf(input); v.Kind() != reflect.Slice {
+		return nil, errors.New("axis points of axis " + strconv.Itoa(axisIndex) + " have to be a slice")
+	} else if v.Len() == 0 {
+		return nil, nil
+	}
+	if axisIndex >= len(cv.characteristic.AxisDescr) {
+		return nil, errors.New("characteristic " + cv.Name + " has no axis description for axis " + strconv.Itoa(axisIndex))
+	}
+	ad := &cv.characteristic.AxisDescr[axisIndex]
+	currentRaw, currentPhy := cv.getAxisValuesRef(axisIndex)
+	if ad.Attribute != a2l.StdAxis {
+		//axes that are not part of the deposit may only be passed unchanged
+		if (physical && reflect.DeepEqual(input, *currentPhy)) || (!physical && reflect.DeepEqual(input, *currentRaw)) {
+			return nil, nil
+		}
+		return nil, errors.New("axis " + strconv.Itoa(axisIndex) + " of characteristic " + cv.Name + " is a " + string(ad.Attribute) + " that is not stored within the characteristic")
+	}
+	dte := cv.getAxisDatatype(cd, axisIndex)
+	l := limits{lower: ad.LowerLimit, upper: ad.UpperLimit, extended: ad.ExtendedLimits.LowerLimitSet && ad.ExtendedLimits.UpperLimitSet,
+		extLower: ad.ExtendedLimits.LowerLimit, extUpper: ad.ExtendedLimits.UpperLimit}
+	dec, err := cd.toDecimal(input, ad.Conversion, dte, l, ad.StepSize, physical)
+	if err != nil {
+		return nil, errors.New("axis " + strconv.Itoa(axisIndex) + " of characteristic " + cv.Name + ": " + err.Error())
+	}
+	current := *currentRaw
+	if equalFloat64(dec, current) {
+		return nil, nil
+	}
+	if ad.ReadOnly.Value {
+		return nil, errors.New("axis " + strconv.Itoa(axisIndex) + " of characteristic " + cv.Name + " is read-only")
+	}
+	if len(dec) != len(current) {
+		if _, exists := cv.getField(noAxisPtsFieldNames[axisIndex]); !exists {
+			return nil, errors.New("number of axis points of axis " + strconv.Itoa(axisIndex) + " of characteristic " + cv.Name + " is fixed to " + strconv.Itoa(len(current)))
+		}
+		if ad.MaxAxisPointsSet && len(dec) > int(ad.MaxAxisPoints) {
+			return nil, errors.New(strconv.Itoa(len(dec)) + " axis points exceed the maximum of " + strconv.Itoa(int(ad.MaxAxisPoints)) + " of axis " + strconv.Itoa(axisIndex) + " of characteristic " + cv.Name)
+		}
+	}
+	return dec, nil
+}
+
+// getNewFncValues flattens the function values given in the shape of the dimensions into ROW_DIR order
+// and converts them to decimal values. ASCII characteristics take a string as physical value.
+func (cd *CalibrationData) getNewFncValues(cv *CharacteristicValues, input interface{}, dims []int, physical bool) ([]float64, error) {
+	c := cv.characteristic
+	if c.Type == a2l.ASCII && physical {
+		s, isString := input.(string)
+		if !isString {
+			return nil, errors.New("ASCII characteristic " + cv.Name + " expects a string")
+		}
+		if len(s) > len(cv.fncValues) {
+			return nil, errors.New("string of length " + strconv.Itoa(len(s)) + " exceeds the " + strconv.Itoa(len(cv.fncValues)) + " characters of characteristic " + cv.Name)
+		}
+		//the remaining characters are filled with zeros
+		dec := make([]float64, len(cv.fncValues))
+		for i := 0; i < len(s); i++ {
+			dec[i] = float64(s[i])
+		}
+		return dec, nil
+	}
+	flat, err := flattenValues(input, dims)
+	if err != nil {
+		return nil, errors.New("function values of characteristic " + cv.Name + ": " + err.Error())
+	}
+	if c.Type == a2l.ASCII {
+		return cd.toDecimal(flat, "", cv.recordLayout.FncValues.Datatype, limits{lower: math.Inf(-1), upper: math.Inf(1)}, a2l.StepSize{}, false)
+	}
+	l := limits{lower: c.LowerLimit, upper: c.UpperLimit, extended: c.ExtendedLimits.LowerLimitSet && c.ExtendedLimits.UpperLimitSet,
+		extLower: c.ExtendedLimits.LowerLimit, extUpper: c.ExtendedLimits.UpperLimit}
+	dec, err := cd.toDecimal(flat, c.Conversion, cv.recordLayout.FncValues.Datatype, l, c.StepSize, physical)
+	if err != nil {
+		return nil, errors.New("function values of characteristic " + cv.Name + ": " + err.Error())
+	}
+	return dec, nil
+}
+
+// toDecimal converts physical or raw values to decimal values of the given datatype.
+// numeric physical values are rounded to the step size before they are converted.
+// values outside of the limits or the range of the datatype are rejected.
+// the limits are checked on the physical values of the decimal values, i.e. on what is actually stored in the image.
+func (cd *CalibrationData) toDecimal(input interface{}, conversion string, dte a2l.DataTypeEnum, l limits, ss a2l.StepSize, physical bool) ([]float64, error) {
+	if !physical {
+		raw, isNumeric := input.([]float64)
+		if !isNumeric {
+			return nil, errors.New("raw values have to be numeric")
+		}
+		dec := make([]float64, len(raw))
+		for i, r := range raw {
+			var err error
+			dec[i], err = saturateToDatatype(r, dte)
+			if err != nil {
+				return nil, err
+			}
+		}
+		phy, err := cd.convertValuesToPhysical(dec, conversion, dte)
+		if err != nil {
+			return nil, err
+		}
+		if numeric, isNumeric := phy.([]float64); isNumeric {
+			err = checkAllLimits(numeric, l)
+		}
+		return dec, err
+	}
+	if numeric, isNumeric := input.([]float64); isNumeric {
+		rounded := make([]float64, len(numeric))
+		for i, v := range numeric {
+			rounded[i] = roundToStepSize(v, ss)
+		}
+		err := checkAllLimits(rounded, l)
+		if err != nil {
+			return nil, err
+		}
+		input = rounded
+	}
+	dec, err := cd.ConvertPhysicalToDecimal(input, conversion, dte)
+	if err != nil {
+		return nil, err
+	}
+	//the decimal values are rounded to the datatype, so the limits are checked again on the values that end up in the image
+	phy, err := cd.convertValuesToPhysical(dec, conversion, dte)
+	if err != nil {
+		return nil, err
+	}
+	if numeric, isNumeric := phy.([]float64); isNumeric {
+		err = checkAllLimits(numeric, l)
+	}
+	return dec, err
+}
+
+// checkAllLimits returns an error for the first value that is outside of the limits.
+func checkAllLimits(vals []float64, l limits) error {
+	for i, v := range vals {
+		if violation, ok := checkLimits(v, l); !ok {
+			return errors.New(violation.Message + " at index " + strconv.Itoa(i))
+		}
+	}
+	return nil
+}
+
+// roundToStepSize rounds the physical value to the nearest multiple of STEP_SIZE.
+func roundToStepSize(v float64, ss a2l.StepSize) float64 {
+	if !ss.StepSizeSet || ss.StepSize <= 0 {
+		return v
+	}
+	return math.Round(v/ss.StepSize) * ss.StepSize
+}
+
+// writeCharacteristic writes the decimal axis points and function values of the characteristic.
+// if the number of axis points changes NO_AXIS_PTS is written first and the characteristic is read again
+// to determine the new positions of the axis points and function values.
+func (cd *CalibrationData) writeCharacteristic(cv *CharacteristicValues, w *imageWriter, axes [5][]float64, dec []float64, resized bool) error {
+	if resized {
+		for i, a := range axes {
+			if a == nil || len(a) == len(cv.axisAddresses[i]) {
+				continue
+			}
+			field, _ := cv.getField(noAxisPtsFieldNames[i])
+			err := w.write(field.Address, float64(len(a)), field.Datatype, cv.getByteOrder(cd, i))
+			if err != nil {
+				return err
+			}
+		}
+		reread, err := cd.GetCharacteristicValues(cv.Name)
+		if err != nil {
+			return err
+		}
+		*cv = reread
+	}
+	for i, a := range axes {
+		if a == nil {
+			continue
+		}
+		if len(a) != len(cv.axisAddresses[i]) {
+			return errors.New("axis " + strconv.Itoa(i) + " of characteristic " + cv.Name + " has " + strconv.Itoa(len(cv.axisAddresses[i])) + " axis points, got " + strconv.Itoa(len(a)))
+		}
+		dte, bo := cv.getAxisDatatype(cd, i), cv.getByteOrder(cd, i)
+		for j, addr := range cv.axisAddresses[i] {
+			err := w.write(addr, a[j], dte, bo)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if dec == nil {
+		return nil
+	}
+	if len(dec) != len(cv.fncAddresses) {
+		return errors.New("characteristic " + cv.Name + " has " + strconv.Itoa(len(cv.fncAddresses)) + " function values, got " + strconv.Itoa(len(dec)))
+	}
+	bo := cv.getByteOrder(cd, -1)
+	for i, addr := range cv.fncAddresses {
+		err := w.write(addr, dec[i], cv.recordLayout.FncValues.Datatype, bo)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyCharacteristic reads the characteristic again and compares its axis points and function values with the written ones.
+func (cd *CalibrationData) verifyCharacteristic(cv *CharacteristicValues, axes [5][]float64, dec []float64) error {
+	reread, err := cd.GetCharacteristicValues(cv.Name)
+	if err != nil {
+		return err
+	}
+	for i, a := range axes {
+		raw, _ := reread.getAxisValuesRef(i)
+		if a != nil && !sameEncoding(a, *raw, reread.getAxisDatatype(cd, i)) {
+			return errors.New("axis " + strconv.Itoa(i) + " of characteristic " + cv.Name + " differs after writing")
+		}
+	}
+	if dec != nil && !sameEncoding(dec, reread.fncValues, reread.recordLayout.FncValues.Datatype) {
+		return errors.New("function values of characteristic " + cv.Name + " differ after writing")
+	}
+	return nil
+}
+
+// sameEncoding compares two lists of decimal values by their representation in the given datatype.
+func sameEncoding(a []float64, b []float64, dte a2l.DataTypeEnum) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		ea, errA := encodeDatatype(a[i], dte, a2l.BigEndian)
+		eb, errB := encodeDatatype(b[i], dte, a2l.BigEndian)
+		if errA != nil || errB != nil || string(ea) != string(eb) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalFloat64(a []float64, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+var noAxisPtsFieldNames = []string{"NoAxisPtsX", "NoAxisPtsY", "NoAxisPtsZ", "NoAxisPts4", "NoAxisPts5"}
+
+// getField returns the record layout field with the given name as it has been located while reading the characteristic.
+func (cv *CharacteristicValues) getField(name string) (RecordLayoutField, bool) {
+	for _, f := range cv.fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return RecordLayoutField{}, false
+}
+
+// flattenValues arranges values given in the nested shape of shapeValues into a flat list in ROW_DIR order.
+// it returns a []float64 for numeric values and a []string for verbal values.
+func flattenValues(shaped interface{}, dims []int) (interface{}, error) {
+	n := 1
+	for _, d := range dims {
+		n *= d
+	}
+	v := reflect.ValueOf(shaped)
+	var floats []float64
+	var strs []string
+	var flatten func(v reflect.Value, coords []int) error
+	flatten = func(v reflect.Value, coords []int) error {
+		if len(coords) == len(dims) {
+			if v.Kind() == reflect.Interface {
+				v = v.Elem()
+			}
+			switch v.Kind() {
+			case reflect.Float64:
+				if floats == nil {
+					floats = make([]float64, n)
+				}
+				floats[flatIndex(dims, coords...)] = v.Float()
+			case reflect.String:
+				if strs == nil {
+					strs = make([]string, n)
+				}
+				strs[flatIndex(dims, coords...)] = v.String()
+			default:
+				return errors.New("unexpected type " + v.Kind().String() + " of value")
+			}
+			return nil
+		}
+		if v.Kind() != reflect.Slice {
+			return errors.New("expected " + strconv.Itoa(len(dims)) + " dimensions")
+		}
+		d := dims[len(coords)]
+		if v.Len() != d {
+			return errors.New("expected " + strconv.Itoa(d) + " values in dimension " + strconv.Itoa(len(coords)) + ", got " + strconv.Itoa(v.Len()))
+		}
+		for i := 0; i < d; i++ {
+			err := flatten(v.Index(i), append(coords, i))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	err := flatten(v, make([]int, 0, len(dims)))
+	if err != nil {
+		return nil, err
+	}
+	if floats != nil && strs != nil {
+		return nil, errors.New("numeric and verbal values must not be mixed")
+	}
+	if strs != nil {
+		return strs, nil
+	}
+	return floats, nil
+}
+
+// imageWriter writes encoded values into a memory image
+// and keeps the original content of each changed byte to be able to roll back all changes.
+type imageWriter struct {
+	image    map[uint32]byte
+	original map[uint32]byte
+	existed  map[uint32]bool
+}
+
+func newImageWriter(image map[uint32]byte) *imageWriter {
+	return &imageWriter{image: image, original: make(map[uint32]byte), existed: make(map[uint32]bool)}
+}
+
+// write encodes the decimal value with the given datatype and byte order and stores it at the given address.
+func (w *imageWriter) write(address uint32, dec float64, dte a2l.DataTypeEnum, bo a2l.ByteOrderEnum) error {
+	b, err := encodeDatatype(dec, dte, bo)
+	if err != nil {
+		return err
+	}
+	for i := range b {
+		addr := address + uint32(i)
+		if _, saved := w.existed[addr]; !saved {
+			w.original[addr], w.existed[addr] = w.image[addr]
+		}
+		w.image[addr] = b[i]
+	}
+	return nil
+}
+
+// rollback restores the content of all bytes that have been written.
+func (w *imageWriter) rollback() {
+	for addr, existed := range w.existed {
+		if existed {
+			w.image[addr] = w.original[addr]
+		} else {
+			delete(w.image, addr)
+		}
+	}
+}
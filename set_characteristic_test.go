@@ -0,0 +1,113 @@
+package calibrationReader
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestSetCharacteristic(t *testing.T) {
+	cd := readDemoCalibration(t)
+	var err error
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+
+	//limits 10..200, STEP_SIZE 0.025 and UBYTE
+	name := "ASAM.C.SCALAR.UBYTE.IDENTICAL"
+	if err = cd.SetCharacteristicPhysical(name, CharacteristicValues{PhyValues: 42.3}); err != nil {
+		t.Errorf("could not set %s: %s", name, err)
+	}
+	if err = cd.SetCharacteristicPhysical(name, CharacteristicValues{PhyValues: 250.0}); err == nil {
+		t.Errorf("expected value outside of limits to be rejected")
+	}
+	if err = cd.SetCharacteristicRaw(name, CharacteristicValues{RawValues: 300.0}); err == nil {
+		t.Errorf("expected value outside of datatype range to be rejected")
+	}
+	//199.6 is within the limits but is stored as 200
+	c := m.Characteristics[name]
+	c.UpperLimit = 199.6
+	m.Characteristics[name] = c
+	if err = cd.SetCharacteristicPhysical(name, CharacteristicValues{PhyValues: 199.6}); err == nil {
+		t.Errorf("expected value that is stored outside of the limits to be rejected")
+	}
+	c.UpperLimit = 200
+	m.Characteristics[name] = c
+	cv, err := cd.GetCharacteristicValues(name)
+	if err != nil || cv.PhyValues != 42.0 {
+		t.Errorf("expected 42, got %v, %v", cv.PhyValues, err)
+	}
+	if err = cd.SetCharacteristicRaw(name, CharacteristicValues{RawValues: 11.0}); err != nil {
+		t.Errorf("could not set raw value of %s: %s", name, err)
+	}
+	if cv, err = cd.GetCharacteristicValues(name); err != nil || cv.RawValues != 11.0 {
+		t.Errorf("expected 11, got %v, %v", cv.RawValues, err)
+	}
+
+	//read-only, virtual and NO_CALIBRATION characteristics cannot be changed
+	c = m.Characteristics[name]
+	c.CalibrationAccess = a2l.NoCalibration
+	m.Characteristics[name] = c
+	for _, n := range []string{name, "ASAM.C.VIRTUAL.REF_1.SWORD"} {
+		if err = cd.SetCharacteristicRaw(n, CharacteristicValues{RawValues: 12.0}); err == nil {
+			t.Errorf("expected %s not to be writable", n)
+		}
+	}
+
+	//a round trip of a map with a verbal y axis only changes the modified value
+	name = "ASAM.C.MAP.STD_AXIS.STD_AXIS"
+	cv, err = cd.GetCharacteristicValues(name)
+	if err != nil {
+		t.Fatalf("could not read %s: %s", name, err)
+	}
+	cv.PhyValues.([][]float64)[1][2] = 77
+	if err = cd.SetCharacteristicPhysical(name, cv); err != nil {
+		t.Errorf("could not set %s: %s", name, err)
+	}
+	after, err := cd.GetCharacteristicValues(name)
+	if err != nil || fmt.Sprint(after.PhyValues) != fmt.Sprint(cv.PhyValues) || fmt.Sprint(after.AxisYPhyValues) != "[red orange yellow green blue]" {
+		t.Errorf("expected %v, got %v, %v", cv.PhyValues, after.PhyValues, err)
+	}
+	if err = cd.SetCharacteristicPhysical(name, CharacteristicValues{PhyValues: []float64{1, 2, 3}}); err == nil {
+		t.Errorf("expected values with wrong dimensions to be rejected")
+	}
+
+	//reducing the number of axis points updates NO_AXIS_PTS_X and moves the function values
+	name = "ASAM.C.CURVE.STD_AXIS"
+	before := make(map[uint32]byte, len(cd.Hex))
+	for k, v := range cd.Hex {
+		before[k] = v
+	}
+	if err = cd.SetCharacteristicPhysical(name, CharacteristicValues{AxisXPhyValues: []float64{-5, -1, 2, 4, 5, 8, 9, 10, 11}, PhyValues: make([]float64, 9)}); err == nil {
+		t.Errorf("expected more than MAX_AXIS_POINTS to be rejected")
+	}
+	if err = cd.SetCharacteristicPhysical(name, CharacteristicValues{AxisXPhyValues: []float64{-5, -1, 2, 4, 5, 8}}); err == nil {
+		t.Errorf("expected function values to be required for a new number of axis points")
+	}
+	if fmt.Sprint(before) != fmt.Sprint(cd.Hex) {
+		t.Errorf("expected rejected changes to leave the image unchanged")
+	}
+	if err = cd.SetCharacteristicPhysical(name, CharacteristicValues{AxisXPhyValues: []float64{-5, -1, 2, 4, 5, 8}, PhyValues: []float64{1, 2, 3, 4, 5, 6}}); err != nil {
+		t.Errorf("could not set %s: %s", name, err)
+	}
+	cv, err = cd.GetCharacteristicValues(name)
+	if err != nil || fmt.Sprint(cv.AxisXPhyValues) != "[-5 -1 2 4 5 8]" || fmt.Sprint(cv.PhyValues) != "[1 2 3 4 5 6]" {
+		t.Errorf("expected 6 axis points and values, got %v %v, %v", cv.AxisXPhyValues, cv.PhyValues, err)
+	}
+
+	//the image writer restores all bytes on rollback
+	image := map[uint32]byte{0: 1}
+	w := newImageWriter(image)
+	if err = w.write(0, 0x1234, a2l.UWORD, a2l.BigEndian); err != nil || image[0] != 0x12 || image[1] != 0x34 {
+		t.Errorf("unexpected image %v, %v", image, err)
+	}
+	w.rollback()
+	if fmt.Sprint(image) != "map[0:1]" {
+		t.Errorf("expected image to be restored, got %v", image)
+	}
+	flat, err := flattenValues([][]float64{{1, 2, 3}, {4, 5, 6}}, []int{2, 3})
+	if err != nil || fmt.Sprint(flat) != fmt.Sprint([]float64{1, 4, 2, 5, 3, 6}) {
+		t.Errorf("unexpected flat values %v, %v", flat, err)
+	}
+}
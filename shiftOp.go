@@ -8,7 +8,7 @@ import (
 )
 
 // getShiftOpX retrieves the shift operator according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getShiftOpX(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getShiftOpX(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.ShiftOpX.DatatypeSet {
 		err := errors.New("shiftOpX datatype not set")
 		log.Err(err).Msg("could not retrieve shiftOpX value")
@@ -19,17 +19,17 @@ func (cd *CalibrationData) getShiftOpX(rl *a2l.RecordLayout, curPos *uint32) (in
 		log.Err(err).Msg("could not retrieve shiftOpX value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.ShiftOpX.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.ShiftOpX.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve shiftOpX value")
 		return 0, err
 	}
-	*curPos += uint32(rl.ShiftOpX.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.ShiftOpX.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // getShiftOpY retrieves the shift operator according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getShiftOpY(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getShiftOpY(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.ShiftOpY.DatatypeSet {
 		err := errors.New("shiftOpY datatype not set")
 		log.Err(err).Msg("could not retrieve shiftOpY value")
@@ -40,17 +40,17 @@ func (cd *CalibrationData) getShiftOpY(rl *a2l.RecordLayout, curPos *uint32) (in
 		log.Err(err).Msg("could not retrieve shiftOpY value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.ShiftOpY.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.ShiftOpY.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve shiftOpY value")
 		return 0, err
 	}
-	*curPos += uint32(rl.ShiftOpY.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.ShiftOpY.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // getShiftOpZ retrieves the shift operator according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getShiftOpZ(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getShiftOpZ(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.ShiftOpZ.DatatypeSet {
 		err := errors.New("shiftOpZ datatype not set")
 		log.Err(err).Msg("could not retrieve shiftOpZ value")
@@ -61,17 +61,17 @@ func (cd *CalibrationData) getShiftOpZ(rl *a2l.RecordLayout, curPos *uint32) (in
 		log.Err(err).Msg("could not retrieve shiftOpZ value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.ShiftOpZ.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.ShiftOpZ.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve shiftOpZ value")
 		return 0, err
 	}
-	*curPos += uint32(rl.ShiftOpZ.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.ShiftOpZ.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // / getShiftOp4 retrieves the shift operator according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getShiftOp4(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getShiftOp4(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.ShiftOp4.DatatypeSet {
 		err := errors.New("shiftOp4 datatype not set")
 		log.Err(err).Msg("could not retrieve shiftOp4 value")
@@ -82,17 +82,17 @@ func (cd *CalibrationData) getShiftOp4(rl *a2l.RecordLayout, curPos *uint32) (in
 		log.Err(err).Msg("could not retrieve shiftOp4 value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.ShiftOp4.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.ShiftOp4.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve shiftOp4 value")
 		return 0, err
 	}
-	*curPos += uint32(rl.ShiftOp4.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.ShiftOp4.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
 
 // getShiftOp5 retrieves the shift operator according to its layout specified within the record layout and their values as calibrated in the hex file
-func (cd *CalibrationData) getShiftOp5(rl *a2l.RecordLayout, curPos *uint32) (int64, error) {
+func (cd *CalibrationData) getShiftOp5(rl *a2l.RecordLayout, curPos *uint32, bo a2l.ByteOrderEnum) (int64, error) {
 	if !rl.ShiftOp5.DatatypeSet {
 		err := errors.New("shiftOp5 datatype not set")
 		log.Err(err).Msg("could not retrieve shiftOp5 value")
@@ -103,11 +103,11 @@ func (cd *CalibrationData) getShiftOp5(rl *a2l.RecordLayout, curPos *uint32) (in
 		log.Err(err).Msg("could not retrieve shiftOp5 value")
 		return 0, err
 	}
-	val, err := cd.convertByteSliceToDatatype(bufBytes, rl.ShiftOp5.Datatype)
+	val, err := decodeDatatype(bufBytes, rl.ShiftOp5.Datatype, bo)
 	if err != nil {
 		log.Err(err).Msg("could not retrieve shiftOp5 value")
 		return 0, err
 	}
-	*curPos += uint32(rl.ShiftOp5.Datatype.GetDatatypeLength())
+	*curPos += uint32(rl.ShiftOp5.Datatype.GetDatatypeLength() / 8)
 	return int64(val), err
 }
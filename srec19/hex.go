@@ -97,19 +97,33 @@ var (
 	}
 )
 
+// Hex contains the data of a parsed S-record file together with its header and entry point.
+type Hex struct {
+	//Data contains all data bytes of the S1, S2 and S3 records with their addresses.
+	Data map[uint32]byte
+	//Header contains the text of the S0 header record.
+	Header string
+	//StartAddress contains the entry point given by the S7, S8 or S9 termination record.
+	StartAddress    uint32
+	StartAddressSet bool
+}
+
 // parseHex parses a hex-file given as an slice of strings and return a hex struct containing the data in byte form with all the addresses attached.
-func parseHex(lines []string) (map[uint32]byte, error) {
+func parseHex(lines []string) (Hex, error) {
+	var hx Hex
 	var h map[uint32]byte
 	var err error
 
 	//the initial capacity of dataBytes and records should be enough to parse a 10MB file without reallocation
 	h = make(map[uint32]byte, 5000000)
+	hx.Data = h
 	recs := make([]*record, 0, 200000)
 
 	//locRecord contains slices of records that the individual parsers in the goroutines produced
 	//this way we can ensure that the order of the records remains correct
 	//because the positions of the return values are determined by the position of the channel within locRecord
 	var locRecord []chan []*record
+	errChan := make(chan error, numProc)
 	for i := 0; i < numProc; i++ {
 		//calculate start and end for the slices each go routine is given to parse
 		start := (len(lines) / numProc) * i
@@ -120,7 +134,7 @@ func parseHex(lines []string) (map[uint32]byte, error) {
 		}
 		c := make(chan []*record, len(lines))
 		locRecord = append(locRecord, c)
-		go parseRecordRoutine(c, lines[start:end])
+		go parseRecordRoutine(c, errChan, lines[start:end])
 	}
 	//collect records from channels
 	for _, c := range locRecord {
@@ -128,6 +142,15 @@ func parseHex(lines []string) (map[uint32]byte, error) {
 			recs = append(recs, r...)
 		}
 	}
+	close(errChan)
+	if err = <-errChan; err != nil {
+		return hx, err
+	}
+
+	err = hx.readControlRecords(recs)
+	if err != nil {
+		return hx, err
+	}
 
 	if validateChecksums {
 		//start validation of all checksums
@@ -152,7 +175,7 @@ func parseHex(lines []string) (map[uint32]byte, error) {
 			for v := range vcsChan {
 				if !v {
 					err = errors.New("invalid checksums detected")
-					return h, err
+					return hx, err
 				}
 			}
 		}
@@ -180,14 +203,53 @@ func parseHex(lines []string) (map[uint32]byte, error) {
 				//make sure no addresses are defined twice which would indicate a severe error
 				if exists {
 					err = errors.New("colliding address values at address " + fmt.Sprint(data.address) + " value1 " + fmt.Sprint(val) + " and value2 " + fmt.Sprint(data.value))
-					return h, err
+					return hx, err
 				}
 				h[data.address] = data.value
 			}
 		}
 	}
 
-	return h, nil
+	return hx, nil
+}
+
+// readControlRecords reads the header and start address from the S0 and S7/S8/S9 records
+// and validates the record counts given by S5/S6 records against the number of preceding data records.
+// no records may follow the termination record.
+func (hx *Hex) readControlRecords(recs []*record) error {
+	var err error
+	headerSet := false
+	dataRecords := uint32(0)
+	for i, r := range recs {
+		switch {
+		case r.isDataRecord():
+			dataRecords++
+		case r.recordType == headerRecordToken:
+			if headerSet {
+				err = errors.New("header record is defined more than once")
+				return err
+			}
+			bs, err := hexToByteSlice(strings.Join(r.data, ""))
+			if err != nil {
+				return err
+			}
+			hx.Header = strings.TrimRight(string(bs), "\x00")
+			headerSet = true
+		case r.recordType == count16RecordToken || r.recordType == count24RecordToken:
+			if r.address != dataRecords {
+				err = errors.New("count record S" + r.recordType + " expects " + fmt.Sprint(r.address) + " data records, found " + fmt.Sprint(dataRecords))
+				return err
+			}
+		case r.isTerminationRecord():
+			if i != len(recs)-1 {
+				err = errors.New("found " + fmt.Sprint(len(recs)-1-i) + " records after termination record S" + r.recordType)
+				return err
+			}
+			hx.StartAddress = r.address
+			hx.StartAddressSet = true
+		}
+	}
+	return nil
 }
 
 // readFileToString returns a string by reading a document from a given filepath.
@@ -203,14 +265,20 @@ func readFileToString(filepath string) (string, error) {
 
 // ParseFromFile parses a hex file from a given filepath and return a hex struct containing all data as bytes with their addresses.
 func ParseFromFile(filepath string) (map[uint32]byte, error) {
-	var h map[uint32]byte
+	hx, err := ParseHexFromFile(filepath)
+	return hx.Data, err
+}
+
+// ParseHexFromFile parses a hex file from a given filepath and returns the data together with header and start address of the file.
+func ParseHexFromFile(filepath string) (Hex, error) {
+	var hx Hex
 	var text string
 	var err error
 
 	text, err = readFileToString(filepath)
 	if err != nil {
 		log.Err(err).Msg("hex test-file could not be read")
-		return h, err
+		return hx, err
 	}
 	//split the text into lines
 	lines := strings.Split(text, "\r\n")
@@ -218,27 +286,32 @@ func ParseFromFile(filepath string) (map[uint32]byte, error) {
 		//in case unix line terminator is used.
 		lines = strings.Split(text, "\n")
 	}
-	h, err = parseHex(lines)
+	hx, err = parseHex(lines)
 	if err != nil {
 		log.Err(err).Msg("failed parsing with error")
-		return h, err
+		return hx, err
 	}
-	return h, nil
+	return hx, nil
 }
 
 // parseRecordRoutine calls the parseRecord method for each line given and return them via a channel.
-func parseRecordRoutine(c chan []*record, lines []string) {
+// empty lines are skipped, the first line that cannot be parsed is reported via the error channel.
+func parseRecordRoutine(c chan []*record, ce chan error, lines []string) {
 	var recs []*record
 forLoop:
 	for _, l := range lines {
-		if len(l) >= 11 {
-			r, err := parseRecord(l)
-			if err != nil {
-				log.Err(err)
-				break forLoop
-			}
-			recs = append(recs, r)
+		//ignore whitespace and the dos end of file character
+		l = strings.Trim(l, " \t\r\x1a")
+		if len(l) == 0 {
+			continue
+		}
+		r, err := parseRecord(l)
+		if err != nil {
+			log.Err(err).Msg("could not parse record " + l)
+			ce <- err
+			break forLoop
 		}
+		recs = append(recs, r)
 	}
 	c <- recs
 	close(c)
@@ -28,6 +28,115 @@ func TestParseFromFile(t *testing.T) {
 	}
 }
 
+func TestParseRecordTypes(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+	lines := []string{
+		formatRecord(headerRecordToken, 0, []byte("HDR")),
+		formatRecord(data16RecordToken, 0x1234, []byte{1, 2}),
+		formatRecord(data24RecordToken, 0x123456, []byte{3}),
+		formatRecord(dataRecordToken, 0x12345678, []byte{4}),
+		formatRecord(count16RecordToken, 3, nil),
+		formatRecord(startAddress24RecordToken, 0x123456, nil),
+	}
+	hx, err := parseHex(lines)
+	if err != nil {
+		t.Fatalf("failed parsing with error: %s.", err)
+	}
+	expected := map[uint32]byte{0x1234: 1, 0x1235: 2, 0x123456: 3, 0x12345678: 4}
+	if len(hx.Data) != len(expected) {
+		t.Fatalf("expected %d bytes, got %d", len(expected), len(hx.Data))
+	}
+	for a, b := range expected {
+		if hx.Data[a] != b {
+			t.Fatalf("expected %d at 0x%X, got %d", b, a, hx.Data[a])
+		}
+	}
+	if hx.Header != "HDR" || !hx.StartAddressSet || hx.StartAddress != 0x123456 {
+		t.Fatalf("header or start address have not been read correctly")
+	}
+	//wrong record counts are rejected
+	wrongCount := append([]string{}, lines...)
+	wrongCount[4] = formatRecord(count16RecordToken, 2, nil)
+	_, err = parseHex(wrongCount)
+	if err == nil {
+		t.Fatalf("wrong record count has not been rejected")
+	}
+	//records after the termination record are rejected
+	_, err = parseHex(append(append([]string{}, lines...), formatRecord(dataRecordToken, 0, []byte{5})))
+	if err == nil {
+		t.Fatalf("record after termination record has not been rejected")
+	}
+	//unparsable records are reported instead of being dropped
+	_, err = parseHex(append([]string{"S4030000FC"}, lines...))
+	if err == nil {
+		t.Fatalf("unknown record type has not been rejected")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	configureLogger()
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+	h, err := ParseFromFile("testing/ASAP2_Demo_V171.s19")
+	if err != nil {
+		t.Fatalf("failed parsing with error: %s.", err)
+	}
+	//the highest address of the demo image needs 24 bit, so S2 records are chosen automatically
+	var sb strings.Builder
+	err = Write(&sb, h, WriteOptions{ModuleName: "ASAP2_Demo", Version: "V171", StartAddress: 0x320})
+	if err != nil {
+		t.Fatalf("failed writing with error: %s.", err)
+	}
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\r\n")
+	if lines[0] != "S012000041534150325F44656D6F2056313731A3" {
+		t.Fatalf("unexpected header record %s", lines[0])
+	}
+	if lines[len(lines)-1] != "S804000320D8" {
+		t.Fatalf("unexpected termination record %s", lines[len(lines)-1])
+	}
+	count := 0
+	for _, l := range lines[1 : len(lines)-2] {
+		if !strings.HasPrefix(l, "S2") {
+			t.Fatalf("expected S2 record, got %s", l)
+		}
+		count++
+	}
+	if lines[len(lines)-2] != formatRecord(count16RecordToken, uint32(count), nil) {
+		t.Fatalf("unexpected count record %s for %d records", lines[len(lines)-2], count)
+	}
+	w, err := parseHex(lines)
+	if err != nil {
+		t.Fatalf("failed parsing written records with error: %s.", err)
+	}
+	if w.Header != "ASAP2_Demo V171" || !w.StartAddressSet || w.StartAddress != 0x320 {
+		t.Fatalf("header or start address have not been read correctly")
+	}
+	//forced S3 records can be parsed again
+	sb.Reset()
+	err = Write(&sb, h, WriteOptions{RecordType: "3", RecordLength: 16, LineEnding: "\n"})
+	if err != nil {
+		t.Fatalf("failed writing with error: %s.", err)
+	}
+	w3, err := parseHex(strings.Split(sb.String(), "\n"))
+	if err != nil {
+		t.Fatalf("failed parsing written records with error: %s.", err)
+	}
+	for _, hx := range []Hex{w, w3} {
+		if len(hx.Data) != len(h) {
+			t.Fatalf("expected %d bytes, got %d", len(h), len(hx.Data))
+		}
+		for a, b := range h {
+			if hx.Data[a] != b {
+				t.Fatalf("byte at 0x%X differs", a)
+			}
+		}
+	}
+	err = Write(&strings.Builder{}, h, WriteOptions{RecordType: "1"})
+	if err == nil {
+		t.Fatalf("address exceeding S1 records has not been rejected")
+	}
+}
+
 func FuzzParseHex(f *testing.F) {
 	configureLogger()
 	zerolog.SetGlobalLevel(zerolog.WarnLevel)
@@ -54,8 +163,8 @@ func FuzzParseHex(f *testing.F) {
 			}
 			if !exists {
 				errList = append(errList, err)
-				fmt.Println(len(h))
-				log.Err(err).Msg("could not parse s19-file with length " + strconv.Itoa(len(h)))
+				fmt.Println(len(h.Data))
+				log.Err(err).Msg("could not parse s19-file with length " + strconv.Itoa(len(h.Data)))
 				log.Err(err).Msg(orig)
 			}
 		}
@@ -1,45 +1,79 @@
 package srec19
 
 import (
-	"encoding/binary"
 	"errors"
 	"sync"
 )
 
-// record stores the data of one line/record of an ihex32 file.
+// record stores the data of one line/record of an srec19 file.
 type record struct {
 	//rwm is used to coordinate multithreaded reading/writing when applying offsets or calculating checksums.
 	//not used in s19 because no offsets need to be applied.
 	//rwm sync.Mutex
 	//byteCount contains the hex string value that defines the number of data bytes contained in the record, including address & checksum.
 	byteCount string
-	//addressField contains the 4 Byte of the 32Bit address.
+	//addressField contains the 2, 3 or 4 Byte address depending on the record type.
+	//count records contain the number of data records and termination records the start address instead.
 	addressField string
-	//recordType defines which kind of data is contained within the record (data, address offset, eof-marker, etc.).
+	//recordType defines which kind of data is contained within the record (header, data, count, start address).
 	recordType string
 	//data contains byteCount number of hex Strings, each representing a single byte (e.g. FF -> 255).
 	data []string
 	//checksum contains a single byte hex string with the checksum computed from the individual fields of the record.
 	checksum string
+	//address contains the decoded value of the addressField.
+	address uint32
 }
 
 // parseRecord takes a line as string and fills the respective fields in the record struct.
 func parseRecord(line string) (*record, error) {
 	r := record{}
-	r.recordType = line[1:2]
-	if line[0] == beginLineToken[0] && r.recordType == "3" && len(line) >= 12 {
-		r.byteCount = line[2:4]
-		r.addressField = line[4:12]
-		for i := 12; i < len(line)-3; i += 2 {
-			r.data = append(r.data, line[i:i+2])
-		}
-		r.checksum = line[len(line)-2:]
-		return &r, nil
-	} else {
+	if len(line) < 10 || line[0] != beginLineToken[0] {
 		err := errors.New("entry has no begin line symbol or is too short")
 		return &r, err
 	}
+	r.recordType = line[1:2]
+	addressLength, exists := addressLengths[r.recordType]
+	if !exists {
+		err := errors.New("unknown record type S" + r.recordType)
+		return &r, err
+	}
+	r.byteCount = line[2:4]
+	count, err := hexToByte(r.byteCount)
+	if err != nil {
+		return &r, err
+	}
+	if len(line) != 4+2*int(count) || int(count) < addressLength+1 {
+		err = errors.New("length of line does not match its byte count " + r.byteCount)
+		return &r, err
+	}
+	r.addressField = line[4 : 4+2*addressLength]
+	for i := 4 + 2*addressLength; i < len(line)-3; i += 2 {
+		r.data = append(r.data, line[i:i+2])
+	}
+	r.checksum = line[len(line)-2:]
+	if !r.isDataRecord() && r.recordType != headerRecordToken && len(r.data) != 0 {
+		err = errors.New("record of type S" + r.recordType + " must not contain data")
+		return &r, err
+	}
+	bs, err := hexToByteSlice(r.addressField)
+	if err != nil {
+		return &r, err
+	}
+	for _, b := range bs {
+		r.address = r.address<<8 | uint32(b)
+	}
+	return &r, nil
+}
 
+// isDataRecord returns true for S1, S2 and S3 records.
+func (r *record) isDataRecord() bool {
+	return r.recordType == data16RecordToken || r.recordType == data24RecordToken || r.recordType == dataRecordToken
+}
+
+// isTerminationRecord returns true for S7, S8 and S9 records.
+func (r *record) isTerminationRecord() bool {
+	return r.recordType == startAddress32RecordToken || r.recordType == startAddress24RecordToken || r.recordType == startAddress16RecordToken
 }
 
 // validateChecksumsRoutine calls the validateChecksum Method for each record
@@ -72,30 +106,14 @@ func (r *record) validateChecksum() (bool, error) {
 	}
 	//and add to sum
 	sum = sum + buf
-	//convert first byte of line address to uint8
-	buf, err = hexToByte(r.addressField[0:2])
-	if err != nil {
-		return false, err
-	}
-	sum = sum + buf
-	//convert second byte of line address to uint8
-	buf, err = hexToByte(r.addressField[2:4])
-	if err != nil {
-		return false, err
-	}
-	sum = sum + buf
-	//convert second byte of line address to uint8
-	buf, err = hexToByte(r.addressField[4:6])
-	if err != nil {
-		return false, err
-	}
-	sum = sum + buf
-	//convert second byte of line address to uint8
-	buf, err = hexToByte(r.addressField[6:8])
-	if err != nil {
-		return false, err
+	//convert every byte of the address to uint8
+	for i := 0; i < len(r.addressField); i += 2 {
+		buf, err = hexToByte(r.addressField[i : i+2])
+		if err != nil {
+			return false, err
+		}
+		sum = sum + buf
 	}
-	sum = sum + buf
 	//convert every data byte
 	for _, d := range r.data {
 		buf, err = hexToByte(d)
@@ -123,7 +141,7 @@ func calcDataRoutine(c chan []dataByte, recs []*record) {
 	var ds []dataByte
 	var err error
 	for _, r := range recs {
-		if r.recordType == dataRecordToken {
+		if r.isDataRecord() {
 			d, err = r.calcDataEntries()
 			if err != nil {
 				c <- []dataByte{}
@@ -140,8 +158,6 @@ func (r *record) calcDataEntries() ([]dataByte, error) {
 	var dataBytes []dataByte
 	var err error
 	var val byte
-	var bs []byte
-	var lineAddress uint32
 
 	for i, s := range r.data {
 		//convert hexString to byte
@@ -150,15 +166,7 @@ func (r *record) calcDataEntries() ([]dataByte, error) {
 			return dataBytes, err
 		}
 		//add index position to the address of the line to get the individual byte position
-		bs, err = hexToByteSlice(r.addressField)
-		if err != nil {
-			return dataBytes, err
-		}
-		//convert bytes to uint32, add index-value
-		lineAddress = binary.BigEndian.Uint32(bs)
-		lineAddress = lineAddress + uint32(i)
-		//construct data with final uint32 address and a byte as value
-		dataBytes = append(dataBytes, dataByte{address: lineAddress, value: val})
+		dataBytes = append(dataBytes, dataByte{address: r.address + uint32(i), value: val})
 	}
 	return dataBytes, nil
 }
@@ -2,4 +2,25 @@ package srec19
 
 //const emptyToken = ""
 const beginLineToken = "S"
+const headerRecordToken = "0"
+const data16RecordToken = "1"
+const data24RecordToken = "2"
 const dataRecordToken = "3"
+const count16RecordToken = "5"
+const count24RecordToken = "6"
+const startAddress32RecordToken = "7"
+const startAddress24RecordToken = "8"
+const startAddress16RecordToken = "9"
+
+// addressLengths maps the record types to the number of address bytes they contain.
+var addressLengths = map[string]int{
+	headerRecordToken:         2,
+	data16RecordToken:         2,
+	data24RecordToken:         3,
+	dataRecordToken:           4,
+	count16RecordToken:        2,
+	count24RecordToken:        3,
+	startAddress32RecordToken: 4,
+	startAddress24RecordToken: 3,
+	startAddress16RecordToken: 2,
+}
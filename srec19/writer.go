@@ -0,0 +1,171 @@
+package srec19
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// WriteOptions configures how a memory image is serialised to Motorola S-records.
+type WriteOptions struct {
+	//ModuleName and Version are written as text of the S0 header record.
+	ModuleName string
+	Version    string
+	//RecordType forces the data records to "1" (S1, 16 bit), "2" (S2, 24 bit) or "3" (S3, 32 bit) addresses.
+	//if empty the smallest type that can address the highest address of the image is chosen.
+	RecordType string
+	//RecordLength is the number of data bytes per data record. Valid values are 16, 32 and 64. Defaults to 32.
+	RecordLength int
+	//StartAddress is written into the S7/S8/S9 termination record matching the data record type.
+	StartAddress uint32
+	//LineEnding terminates each record. Either "\r\n" or "\n". Defaults to "\r\n".
+	LineEnding string
+}
+
+// terminationTokens maps the data record types to their matching termination record type.
+var terminationTokens = map[string]string{
+	data16RecordToken: startAddress16RecordToken,
+	data24RecordToken: startAddress24RecordToken,
+	dataRecordToken:   startAddress32RecordToken,
+}
+
+// WriteToFile serialises the memory image to an S-record file at the given filepath.
+func WriteToFile(filepath string, h map[uint32]byte, opts WriteOptions) error {
+	file, err := os.Create(filepath)
+	if err != nil {
+		log.Err(err).Msg("could not create s-record file")
+		return err
+	}
+	defer file.Close()
+	err = Write(file, h, opts)
+	if err != nil {
+		log.Err(err).Msg("could not write s-record file")
+		return err
+	}
+	return nil
+}
+
+// Write serialises the memory image to Motorola S-records.
+// the file starts with an S0 header containing module name and version followed by the data records.
+// contiguous bytes are grouped into data records of opts.RecordLength bytes which are aligned to multiples of the record length.
+// the number of data records is written as S5 record, or S6 record if it exceeds 16 bit,
+// and the file is terminated with the S7/S8/S9 record matching the data record type.
+func Write(w io.Writer, h map[uint32]byte, opts WriteOptions) error {
+	if opts.RecordLength == 0 {
+		opts.RecordLength = 32
+	}
+	if opts.RecordLength != 16 && opts.RecordLength != 32 && opts.RecordLength != 64 {
+		err := errors.New("invalid record length " + fmt.Sprint(opts.RecordLength) + ". Expected 16, 32 or 64")
+		log.Err(err).Msg("could not write s-records")
+		return err
+	}
+	if opts.LineEnding == "" {
+		opts.LineEnding = "\r\n"
+	}
+	if opts.LineEnding != "\r\n" && opts.LineEnding != "\n" {
+		err := errors.New("invalid line ending " + fmt.Sprintf("%q", opts.LineEnding))
+		log.Err(err).Msg("could not write s-records")
+		return err
+	}
+	addresses := make([]uint32, 0, len(h))
+	for a := range h {
+		addresses = append(addresses, a)
+	}
+	sort.Slice(addresses, func(i, j int) bool { return addresses[i] < addresses[j] })
+	highest := opts.StartAddress
+	if len(addresses) > 0 && addresses[len(addresses)-1] > highest {
+		highest = addresses[len(addresses)-1]
+	}
+
+	recordType := opts.RecordType
+	switch recordType {
+	case "":
+		if highest <= 0xFFFF {
+			recordType = data16RecordToken
+		} else if highest <= 0xFFFFFF {
+			recordType = data24RecordToken
+		} else {
+			recordType = dataRecordToken
+		}
+	case data16RecordToken, data24RecordToken, dataRecordToken:
+		if uint64(highest) >= uint64(1)<<(8*addressLengths[recordType]) {
+			err := errors.New("highest address " + fmt.Sprintf("0x%X", highest) + " cannot be represented by S" + recordType + " records")
+			log.Err(err).Msg("could not write s-records")
+			return err
+		}
+	default:
+		err := errors.New("invalid data record type S" + recordType + ". Expected S1, S2 or S3")
+		log.Err(err).Msg("could not write s-records")
+		return err
+	}
+
+	header := opts.ModuleName
+	if opts.Version != "" {
+		header += " " + opts.Version
+	}
+	if len(header) > 252 {
+		err := errors.New("header " + header + " exceeds the maximum length of 252 characters")
+		log.Err(err).Msg("could not write s-records")
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	writeRecord(bw, headerRecordToken, 0, []byte(header), opts.LineEnding)
+	length := uint32(opts.RecordLength)
+	count := uint32(0)
+	for i := 0; i < len(addresses); {
+		start := addresses[i]
+		//a record ends at a gap or at the next multiple of the record length
+		data := []byte{h[start]}
+		i++
+		for i < len(addresses) && addresses[i] == start+uint32(len(data)) && addresses[i]%length != 0 {
+			data = append(data, h[addresses[i]])
+			i++
+		}
+		writeRecord(bw, recordType, start, data, opts.LineEnding)
+		count++
+	}
+	//the count record is optional and omitted if the number of records cannot be represented in 24 bit
+	if count <= 0xFFFF {
+		writeRecord(bw, count16RecordToken, count, nil, opts.LineEnding)
+	} else if count <= 0xFFFFFF {
+		writeRecord(bw, count24RecordToken, count, nil, opts.LineEnding)
+	}
+	writeRecord(bw, terminationTokens[recordType], opts.StartAddress, nil, opts.LineEnding)
+	return bw.Flush()
+}
+
+// writeRecord writes a single record of the given type with the address and data.
+func writeRecord(w *bufio.Writer, recordType string, address uint32, data []byte, lineEnding string) {
+	w.WriteString(formatRecord(recordType, address, data))
+	w.WriteString(lineEnding)
+}
+
+// formatRecord formats a record as upper case hex string including byte count and checksum.
+// the byte count covers address, data and checksum, the checksum is the ones' complement of the sum of all these bytes and the byte count.
+func formatRecord(recordType string, address uint32, data []byte) string {
+	addressLength := addressLengths[recordType]
+	byteCount := byte(addressLength + len(data) + 1)
+	sum := byteCount
+	var sb strings.Builder
+	sb.Grow(4 + 2*(addressLength+len(data)+1))
+	sb.WriteString(beginLineToken)
+	sb.WriteString(recordType)
+	fmt.Fprintf(&sb, "%02X", byteCount)
+	for i := addressLength - 1; i >= 0; i-- {
+		b := byte(address >> (8 * i))
+		fmt.Fprintf(&sb, "%02X", b)
+		sum += b
+	}
+	for _, b := range data {
+		fmt.Fprintf(&sb, "%02X", b)
+		sum += b
+	}
+	fmt.Fprintf(&sb, "%02X", ^sum)
+	return sb.String()
+}
@@ -0,0 +1,116 @@
+package calibrationReader
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
+)
+
+// isStaticRecordLayout reports whether the record layout reserves memory for the maximum number of axis points
+// as defined by STATIC_RECORD_LAYOUT or STATIC_ADDRESS_OFFSETS.
+// in that case the addresses of the record layout elements do not depend on the actual number of axis points.
+func isStaticRecordLayout(rl *a2l.RecordLayout) bool {
+	return rl.StaticRecordLayout.Value || rl.StaticAddressOffsets.Value
+}
+
+// readReservedAxisPoints reads noAxisPts axis points from a memory area that is reserved for maxAxisPts axis points.
+// with STATIC_RECORD_LAYOUT the actual axis points are stored at the beginning of the reserved area,
+// with STATIC_ADDRESS_OFFSETS at its end. In both cases curPos is moved behind the whole reserved area.
+// without static record layout or for indirect addressing the axis points are read compacted.
+func (cd *CalibrationData) readReservedAxisPoints(rl *a2l.RecordLayout, curPos *uint32, dte a2l.DataTypeEnum, at a2l.AddrTypeEnum, noAxisPts int64, maxAxisPts int64, bo a2l.ByteOrderEnum) ([]float64, []uint32, error) {
+	if !isStaticRecordLayout(rl) || (at != a2l.DIRECT && at != "") || maxAxisPts <= noAxisPts {
+		return cd.readAxisPoints(rl, curPos, dte, at, noAxisPts, bo)
+	}
+	gap := uint32(maxAxisPts-noAxisPts) * uint32(dte.GetDatatypeLength()/8)
+	if rl.StaticAddressOffsets.Value {
+		*curPos += gap
+	}
+	val, addrs, err := cd.readAxisPoints(rl, curPos, dte, at, noAxisPts, bo)
+	if err != nil {
+		log.Err(err).Msg("could not retrieve axis points from reserved memory")
+		return nil, nil, err
+	}
+	if !rl.StaticAddressOffsets.Value {
+		*curPos += gap
+	}
+	return val, addrs, nil
+}
+
+// getMaxAxisPts returns the maximum number of axis points of the axis with the given index (0 = x ... 4 = 5)
+// as defined within the axis description of the characteristic. 0 is returned if it is not defined.
+func (cv *CharacteristicValues) getMaxAxisPts(axisIndex int) int64 {
+	if axisIndex < 0 || axisIndex >= len(cv.characteristic.AxisDescr) || !cv.characteristic.AxisDescr[axisIndex].MaxAxisPointsSet {
+		return 0
+	}
+	return int64(cv.characteristic.AxisDescr[axisIndex].MaxAxisPoints)
+}
+
+// getReservedFncValues reads function values from a memory area that is reserved for the maximum number of axis points of each axis.
+// the values are stored as if every axis had its maximum number of axis points.
+// with STATIC_RECORD_LAYOUT the actual values occupy the lowest indices in each direction,
+// with STATIC_ADDRESS_OFFSETS the highest.
+// the function values are returned in ROW_DIR order with the actual dimensions.
+func (cv *CharacteristicValues) getReservedFncValues(cd *CalibrationData, rl *a2l.RecordLayout, curPos *uint32, dims []int) ([]float64, error) {
+	maxDims := make([]int, len(dims))
+	offsets := make([]int, len(dims))
+	noReserved := 1
+	for i, d := range dims {
+		maxDims[i] = d
+		if m := int(cv.getMaxAxisPts(i)); m > d {
+			maxDims[i] = m
+		}
+		if rl.StaticAddressOffsets.Value {
+			offsets[i] = maxDims[i] - d
+		}
+		noReserved *= maxDims[i]
+	}
+	mem, err := cv.getFncValuesDirect(cd, rl, curPos, noReserved)
+	if err != nil {
+		log.Err(err).Msg("could not retrieve fncValues from reserved memory of characteristic '" + cv.characteristic.Name + "'")
+		return nil, err
+	}
+	order := getIndexModeOrder(rl.FncValues.IndexMode, len(maxDims))
+	cv.fncAddresses, err = extractSubArray(reorderToRowDir(cv.fncAddresses, maxDims, order), maxDims, dims, offsets)
+	if err != nil {
+		log.Err(err).Msg("could not retrieve fncValues from reserved memory of characteristic '" + cv.characteristic.Name + "'")
+		return nil, err
+	}
+	reserved := reorderToRowDir(mem, maxDims, order)
+	return extractSubArray(reserved, maxDims, dims, offsets)
+}
+
+// extractSubArray returns the values of the sub array with the dimensions dims that starts at the given offsets
+// within the array of dimensions maxDims. Both arrays are in ROW_DIR order.
+func extractSubArray[T any](val []T, maxDims []int, dims []int, offsets []int) ([]T, error) {
+	noValues := 1
+	for i, d := range dims {
+		if offsets[i]+d > maxDims[i] {
+			err := errors.New("sub array exceeds dimension " + strconv.Itoa(i) + " of reserved array")
+			log.Err(err).Msg("could not extract values")
+			return nil, err
+		}
+		noValues *= d
+	}
+	sub := make([]T, 0, noValues)
+	coords := make([]int, len(dims))
+	for c := 0; c < noValues; c++ {
+		m := 0
+		stride := 1
+		for i := range coords {
+			m += (coords[i] + offsets[i]) * stride
+			stride *= maxDims[i]
+		}
+		sub = append(sub, val[m])
+		//increment coordinates with x being the fastest changing one
+		for i := range coords {
+			coords[i]++
+			if coords[i] < dims[i] {
+				break
+			}
+			coords[i] = 0
+		}
+	}
+	return sub, nil
+}
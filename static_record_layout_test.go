@@ -0,0 +1,75 @@
+package calibrationReader
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestStaticRecordLayout(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	//curve with 3 of 5 axis points followed by an identification at a static address
+	tests := []struct {
+		name    string
+		srl     bool
+		sao     bool
+		data    []byte
+		axis    string
+		values  string
+		nextPos uint32
+	}{
+		{name: "compact", data: []byte{3, 1, 2, 3, 10, 20, 30}, axis: "[1 2 3]", values: "[10 20 30]", nextPos: 7},
+		{name: "STATIC_RECORD_LAYOUT", srl: true, data: []byte{3, 1, 2, 3, 0, 0, 10, 20, 30, 0, 0}, axis: "[1 2 3]", values: "[10 20 30]", nextPos: 11},
+		{name: "STATIC_ADDRESS_OFFSETS", srl: true, sao: true, data: []byte{3, 0, 0, 1, 2, 3, 0, 0, 10, 20, 30}, axis: "[1 2 3]", values: "[10 20 30]", nextPos: 11},
+	}
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	for i, tt := range tests {
+		rl := a2l.RecordLayout{Name: "RL.TEST.STATIC." + tt.name, NameSet: true}
+		rl.NoAxisPtsX = a2l.NoAxisPtsX{Position: 1, PositionSet: true, Datatype: a2l.UBYTE, DatatypeSet: true}
+		rl.AxisPtsX = a2l.AxisPtsX{Position: 2, PositionSet: true, Datatype: a2l.UBYTE, DatatypeSet: true}
+		rl.FncValues = a2l.FncValues{Position: 3, PositionSet: true, Datatype: a2l.UBYTE, DatatypeSet: true}
+		rl.StaticRecordLayout.Value = tt.srl
+		rl.StaticAddressOffsets.Value = tt.sao
+		m.RecordLayouts[rl.Name] = rl
+		c := m.Characteristics["ASAM.C.CURVE.STD_AXIS"]
+		c.Name = "TEST.CURVE.STATIC." + tt.name
+		address := uint32(0xF00000 + 0x100*i)
+		c.Address = "0x" + strconv.FormatUint(uint64(address), 16)
+		c.Deposit = rl.Name
+		c.Conversion = "NO_COMPU_METHOD"
+		c.AxisDescr = append([]a2l.AxisDescr{}, c.AxisDescr...)
+		c.AxisDescr[0].Conversion = "NO_COMPU_METHOD"
+		c.AxisDescr[0].MaxAxisPoints = 5
+		c.AxisDescr[0].MaxAxisPointsSet = true
+		m.Characteristics[c.Name] = c
+		for j, b := range tt.data {
+			cd.Hex[address+uint32(j)] = b
+		}
+		cv, err := cd.GetCharacteristicValues(c.Name)
+		if err != nil {
+			t.Fatalf("%s: could not get values: %s", tt.name, err)
+		}
+		if fmt.Sprint(cv.AxisXRawValues) != tt.axis || fmt.Sprint(cv.RawValues) != tt.values {
+			t.Errorf("%s: unexpected axis %v or values %v", tt.name, cv.AxisXRawValues, cv.RawValues)
+		}
+		//the end of the deposit must not depend on the actual number of axis points
+		curPos := address
+		cv2 := NewCharacteristicValues(&c, &rl)
+		cv2.noAxisPtsXValue = 3
+		curPos++
+		if _, err = cv2.getAxisPointsX(&cd, &rl, &curPos); err != nil {
+			t.Fatalf("%s: could not get axis points: %s", tt.name, err)
+		}
+		if _, err = cv2.getFncValues(&cd, &rl, &curPos); err != nil {
+			t.Fatalf("%s: could not get fnc values: %s", tt.name, err)
+		}
+		if curPos != address+tt.nextPos {
+			t.Errorf("%s: expected deposit to end at offset %d, got %d", tt.name, tt.nextPos, curPos-address)
+		}
+	}
+}
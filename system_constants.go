@@ -0,0 +1,175 @@
+package calibrationReader
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
+)
+
+// SystemConstant is a SYSTEM_CONSTANT of MOD_PAR together with its parsed value.
+// system constants are either numbers (decimal or hexadecimal), formulas that may reference other system constants
+// via sysc(NAME) or plain text.
+type SystemConstant struct {
+	//Name of the system constant without quotes
+	Name string
+	//Text is the value as defined within the a2l without quotes
+	Text string
+	//Value is the numeric value, it is only valid if IsNumeric is true
+	Value     float64
+	IsNumeric bool
+}
+
+// GetSystemConstant returns the system constant with the given name.
+// numeric and formula values are evaluated, all other values are kept as text.
+// an error is returned if the system constant does not exist or a formula references itself or an undefined system constant.
+func (cd *CalibrationData) GetSystemConstant(name string) (SystemConstant, error) {
+	return cd.resolveSystemConstant(name, nil)
+}
+
+// GetSystemConstantValue returns the numeric value of the system constant with the given name.
+// an error is returned if the value of the system constant is text.
+func (cd *CalibrationData) GetSystemConstantValue(name string) (float64, error) {
+	sc, err := cd.GetSystemConstant(name)
+	if err != nil {
+		log.Err(err).Msg("could not get value of system constant")
+		return 0, err
+	}
+	if !sc.IsNumeric {
+		err = errors.New("system constant " + sc.Name + " with value '" + sc.Text + "' is not numeric")
+		log.Err(err).Msg("could not get value of system constant")
+		return 0, err
+	}
+	return sc.Value, nil
+}
+
+// GetSystemConstants returns all system constants of MOD_PAR sorted by their name.
+func (cd *CalibrationData) GetSystemConstants() ([]SystemConstant, error) {
+	constants := cd.A2l.Project.Modules[cd.ModuleIndex].ModPar.SystemConstants
+	scs := make([]SystemConstant, 0, len(constants))
+	for _, c := range constants {
+		sc, err := cd.GetSystemConstant(c.Name)
+		if err != nil {
+			log.Err(err).Msg("could not get system constants")
+			return nil, err
+		}
+		scs = append(scs, sc)
+	}
+	sort.Slice(scs, func(i, j int) bool { return scs[i].Name < scs[j].Name })
+	return scs, nil
+}
+
+// getSystemConstant looks up the system constant with the given name.
+// names of system constants are quoted strings within the a2l, so the name is looked up with and without quotes.
+func (cd *CalibrationData) getSystemConstant(name string) (a2l.SystemConstant, error) {
+	constants := cd.A2l.Project.Modules[cd.ModuleIndex].ModPar.SystemConstants
+	name = trimQuotes(strings.TrimSpace(name))
+	if s, exists := constants[name]; exists {
+		return s, nil
+	}
+	if s, exists := constants["\""+name+"\""]; exists {
+		return s, nil
+	}
+	err := errors.New("no system constant with name " + name)
+	log.Err(err).Msg("system constant not found")
+	return a2l.SystemConstant{}, err
+}
+
+// resolveSystemConstant parses the value of the system constant with the given name.
+// resolving holds the names of the system constants whose formulas are currently evaluated in order to detect cyclic references.
+func (cd *CalibrationData) resolveSystemConstant(name string, resolving []string) (SystemConstant, error) {
+	s, err := cd.getSystemConstant(name)
+	if err != nil {
+		log.Err(err).Msg("could not resolve system constant")
+		return SystemConstant{}, err
+	}
+	if !s.ValueSet {
+		err = errors.New("no value defined in system constant " + s.Name)
+		log.Err(err).Msg("could not resolve system constant")
+		return SystemConstant{}, err
+	}
+	sc := SystemConstant{Name: trimQuotes(s.Name), Text: trimQuotes(s.Value)}
+	for _, r := range resolving {
+		if r == sc.Name {
+			err = errors.New("cyclic reference of system constant " + sc.Name + " via " + strings.Join(resolving, " -> "))
+			log.Err(err).Msg("could not resolve system constant")
+			return SystemConstant{}, err
+		}
+	}
+	text := strings.TrimSpace(sc.Text)
+	if v, err := strconv.ParseInt(text, 0, 64); err == nil {
+		sc.Value, sc.IsNumeric = float64(v), true
+		return sc, nil
+	}
+	if v, err := strconv.ParseUint(text, 0, 64); err == nil {
+		sc.Value, sc.IsNumeric = float64(v), true
+		return sc, nil
+	}
+	if v, err := strconv.ParseFloat(text, 64); err == nil {
+		sc.Value, sc.IsNumeric = v, true
+		return sc, nil
+	}
+	if !isFormulaLike(text) {
+		return sc, nil
+	}
+	f, err := getFormula(text)
+	if err != nil {
+		//text that merely looks like a formula
+		return sc, nil
+	}
+	sc.Value, err = f.evaluate(&formulaEnv{cd: cd, sysc: append(resolving, sc.Name)})
+	if err != nil {
+		log.Err(err).Msg("could not evaluate formula of system constant " + sc.Name)
+		return SystemConstant{}, err
+	}
+	sc.IsNumeric = true
+	return sc, nil
+}
+
+// isFormulaLike reports whether the value of a system constant might be a formula,
+// i.e. it contains a digit, an operator or a reference to another system constant.
+// this avoids parsing plain text like "Text in System Constant" as formula.
+func isFormulaLike(text string) bool {
+	return strings.ContainsAny(text, "0123456789+-*/%&|^~<>()") || strings.Contains(strings.ToLower(text), "sysc")
+}
+
+// resolveFixAxisParameter returns the value of a FIX_AXIS_PAR, FIX_AXIS_PAR_DIST or FIX_AXIS_PAR_LIST parameter.
+// parameters that are defined by a system constant are resolved, all others are returned as they are.
+func (cd *CalibrationData) resolveFixAxisParameter(val float64, ref string) (float64, error) {
+	if ref == "" {
+		return val, nil
+	}
+	v, err := cd.GetSystemConstantValue(ref)
+	if err != nil {
+		log.Err(err).Msg("could not resolve fix axis parameter " + ref)
+		return 0, err
+	}
+	return v, nil
+}
+
+// resolveFixAxisParameters resolves the offset, the shift or distance and the number of axis points of a fix axis.
+// the number of axis points has to be a non-negative integer.
+func (cd *CalibrationData) resolveFixAxisParameters(offset float64, offsetRef string, step float64, stepRef string, numberapo float64, numberapoRef string) (float64, float64, int, error) {
+	offset, err := cd.resolveFixAxisParameter(offset, offsetRef)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	step, err = cd.resolveFixAxisParameter(step, stepRef)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	numberapo, err = cd.resolveFixAxisParameter(numberapo, numberapoRef)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if numberapo < 0 || numberapo > math.MaxUint16 || numberapo != math.Trunc(numberapo) {
+		err = errors.New("invalid number of axis points " + strconv.FormatFloat(numberapo, 'f', -1, 64) + " for fix axis")
+		log.Err(err).Msg("could not resolve fix axis parameters")
+		return 0, 0, 0, err
+	}
+	return offset, step, int(numberapo), nil
+}
@@ -0,0 +1,70 @@
+package calibrationReader
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestSystemConstants(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	for name, value := range map[string]string{
+		"HEX":       "\"0x10\"",
+		"FORMULA":   "\"sysc(HEX) * 2 + sysc('CONTROLLERx constant1')\"",
+		"TEXT":      "\"Text in System Constant\"",
+		"CYCLE_A":   "\"sysc(CYCLE_B) + 1\"",
+		"CYCLE_B":   "\"sysc(CYCLE_A) + 1\"",
+		"NO_POINTS": "\"3\"",
+	} {
+		m.ModPar.SystemConstants["\""+name+"\""] = a2l.SystemConstant{Name: name, NameSet: true, Value: value, ValueSet: true}
+	}
+
+	tests := []struct {
+		name      string
+		isNumeric bool
+		value     float64
+	}{
+		{name: "CONTROLLERx constant1", isNumeric: true, value: 0.33},
+		{name: "\"CONTROLLERx constant2\"", isNumeric: true, value: 2.79},
+		{name: "HEX", isNumeric: true, value: 16},
+		{name: "FORMULA", isNumeric: true, value: 32.33},
+		{name: "TEXT", isNumeric: false},
+	}
+	for _, tt := range tests {
+		sc, err := cd.GetSystemConstant(tt.name)
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+		} else if sc.IsNumeric != tt.isNumeric || (tt.isNumeric && math.Abs(sc.Value-tt.value) > 1e-9) {
+			t.Errorf("%s: expected %v, got %+v", tt.name, tt.value, sc)
+		}
+	}
+	if sc, _ := cd.GetSystemConstant("TEXT"); sc.Text != "Text in System Constant" {
+		t.Errorf("expected text without quotes, got %q", sc.Text)
+	}
+	for _, name := range []string{"CYCLE_A", "missing", "TEXT"} {
+		if _, err := cd.GetSystemConstantValue(name); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if v, err := cd.evalFormula("X1 * sysc(FORMULA)", 2); err != nil || math.Abs(v-64.66) > 1e-9 {
+		t.Errorf("expected sysc within formula to be resolved, got %v, %v", v, err)
+	}
+
+	//fix axis with the number of axis points defined by a system constant
+	c := m.Characteristics["ASAM.C.CURVE.FIX_AXIS.PAR"]
+	c.AxisDescr[0].FixAxisParDist.NumberapoRef = "NO_POINTS"
+	m.Characteristics[c.Name] = c
+	cv, err := cd.GetCharacteristicValues(c.Name)
+	if err != nil {
+		t.Fatalf("could not get values of %s: %s", c.Name, err)
+	}
+	if fmt.Sprint(cv.AxisXRawValues) != "[0 4 8]" {
+		t.Errorf("expected fix axis points [0 4 8], got %v", cv.AxisXRawValues)
+	}
+}
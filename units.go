@@ -0,0 +1,226 @@
+package calibrationReader
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
+)
+
+// siBaseUnits are the symbols of the seven SI base units in the order of SI_EXPONENTS.
+var siBaseUnits = [7]string{"m", "kg", "s", "A", "K", "mol", "cd"}
+
+// DimensionError is returned if values are converted between two units that describe different physical quantities,
+// e.g. from a velocity into a temperature.
+type DimensionError struct {
+	From string
+	To   string
+	//FromDimension and ToDimension are the dimensions of both units as product of SI base units, e.g. "m s^-1"
+	FromDimension string
+	ToDimension   string
+}
+
+func (e *DimensionError) Error() string {
+	if e.FromDimension == e.ToDimension {
+		return "dimensional analysis failed: unit " + e.From + " and unit " + e.To + " share the dimension [" + e.FromDimension + "] but are based on different units without a UNIT_CONVERSION between them"
+	}
+	return "dimensional analysis failed: unit " + e.From + " [" + e.FromDimension + "] cannot be converted into unit " + e.To + " [" + e.ToDimension + "]"
+}
+
+// unitScale describes how values of a unit are converted into the unit at the end of its REF_UNIT chain:
+// value in base unit = gradient * value + offset
+type unitScale struct {
+	name     string
+	base     string
+	gradient float64
+	offset   float64
+	//exponents are the SI_EXPONENTS of the base unit, only valid if hasExponents is true
+	exponents    [7]int16
+	hasExponents bool
+}
+
+// ConvertUnit converts physical values given in unit from into unit to.
+// units are identified by the name of their UNIT or by its display string (e.g. "km/h" or "[km/h]").
+// both units are traced back along their REF_UNIT chains. They are compatible if the chains end in the same unit,
+// otherwise a *DimensionError is returned. units with identical SI_EXPONENTS but different base units (e.g. rad/s and Hz)
+// are not converted 1:1 as they may still differ by a constant factor. they require a UNIT_CONVERSION linking both chains.
+func (cd *CalibrationData) ConvertUnit(phy []float64, from string, to string) ([]float64, error) {
+	fs, err := cd.getUnitScale(from)
+	if err != nil {
+		log.Err(err).Msg("could not convert values from unit " + from)
+		return nil, err
+	}
+	ts, err := cd.getUnitScale(to)
+	if err != nil {
+		log.Err(err).Msg("could not convert values into unit " + to)
+		return nil, err
+	}
+	if !isCompatibleUnit(fs, ts) {
+		err = &DimensionError{From: fs.name, To: ts.name, FromDimension: fs.dimension(), ToDimension: ts.dimension()}
+		log.Err(err).Msg("could not convert values")
+		return nil, err
+	}
+	converted := make([]float64, len(phy))
+	for i, v := range phy {
+		converted[i] = (fs.gradient*v + fs.offset - ts.offset) / ts.gradient
+	}
+	return converted, nil
+}
+
+// GetCharacteristicUnit returns the unit of the physical values of the characteristic with the given name.
+// the PHYS_UNIT of the characteristic takes precedence over the REF_UNIT and the unit of its compu method.
+func (cd *CalibrationData) GetCharacteristicUnit(name string) (string, error) {
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	c, exists := m.Characteristics[name]
+	if !exists {
+		err := errors.New("characteristic " + name + " not found")
+		log.Err(err).Msg("could not get unit of characteristic")
+		return "", err
+	}
+	if c.PhysUnit.UnitSet && trimQuotes(c.PhysUnit.Unit) != "" {
+		return trimQuotes(c.PhysUnit.Unit), nil
+	}
+	if cm, exists := m.CompuMethods[c.Conversion]; exists {
+		if cm.RefUnit.UnitSet {
+			return cm.RefUnit.Unit, nil
+		}
+		if cm.UnitSet && trimQuotes(cm.Unit) != "" {
+			return trimQuotes(cm.Unit), nil
+		}
+	}
+	err := errors.New("no unit defined for characteristic " + name)
+	log.Err(err).Msg("could not get unit of characteristic")
+	return "", err
+}
+
+// GetCharacteristicValuesInUnit reads the characteristic with the given name and converts its physical values
+// from the unit of the characteristic into the given unit. axis points keep their units.
+// characteristics with verbal values cannot be converted.
+func (cd *CalibrationData) GetCharacteristicValuesInUnit(name string, unit string) (CharacteristicValues, error) {
+	from, err := cd.GetCharacteristicUnit(name)
+	if err != nil {
+		log.Err(err).Msg("could not convert characteristic values into unit " + unit)
+		return CharacteristicValues{}, err
+	}
+	cv, err := cd.GetCharacteristicValues(name)
+	if err != nil {
+		log.Err(err).Msg("could not convert characteristic values into unit " + unit)
+		return cv, err
+	}
+	numeric, err := cd.getNumericPhyValues(&cv)
+	if err != nil {
+		log.Err(err).Msg("could not convert characteristic values into unit " + unit)
+		return cv, err
+	}
+	converted, err := cd.ConvertUnit(numeric, from, unit)
+	if err != nil {
+		log.Err(err).Msg("could not convert characteristic values into unit " + unit)
+		return cv, err
+	}
+	cv.PhyValues, err = shapeValues(converted, cv.Dimensions)
+	if err != nil {
+		log.Err(err).Msg("could not shape physical values of characteristic '" + name + "'")
+		return cv, err
+	}
+	return cv, nil
+}
+
+// getUnit looks up a UNIT by its name or by its display string.
+// display strings are compared without quotes and surrounding brackets, so "km/h" matches "[km/h]".
+func (cd *CalibrationData) getUnit(ident string) (a2l.Unit, error) {
+	units := cd.A2l.Project.Modules[cd.ModuleIndex].Units
+	ident = trimQuotes(strings.TrimSpace(ident))
+	if u, exists := units[ident]; exists {
+		return u, nil
+	}
+	for _, u := range units {
+		if u.DisplaySet && trimUnitDisplay(u.Display) == trimUnitDisplay(ident) {
+			return u, nil
+		}
+	}
+	err := errors.New("unit " + ident + " not found")
+	log.Err(err).Msg("could not get unit")
+	return a2l.Unit{}, err
+}
+
+// trimUnitDisplay removes quotes and surrounding brackets from the display string of a unit.
+func trimUnitDisplay(display string) string {
+	display = strings.TrimSpace(trimQuotes(display))
+	if strings.HasPrefix(display, "[") && strings.HasSuffix(display, "]") {
+		display = strings.TrimSpace(display[1 : len(display)-1])
+	}
+	return display
+}
+
+// getUnitScale follows the REF_UNIT chain of the given unit and composes the UNIT_CONVERSIONs along it.
+// a DERIVED unit converts to its referenced unit with: derived = gradient * referenced + offset
+func (cd *CalibrationData) getUnitScale(ident string) (unitScale, error) {
+	u, err := cd.getUnit(ident)
+	if err != nil {
+		log.Err(err).Msg("could not resolve unit")
+		return unitScale{}, err
+	}
+	us := unitScale{name: u.Name, gradient: 1}
+	visited := map[string]bool{}
+	for u.RefUnit.UnitSet {
+		if visited[u.Name] {
+			err = errors.New("cyclic REF_UNIT chain of unit " + us.name + " at unit " + u.Name)
+			log.Err(err).Msg("could not resolve unit")
+			return unitScale{}, err
+		}
+		visited[u.Name] = true
+		uc := u.UnitConversion
+		if !uc.GradientSet || !uc.OffsetSet {
+			err = errors.New("no UNIT_CONVERSION defined for unit " + u.Name + " with REF_UNIT " + u.RefUnit.Unit)
+			log.Err(err).Msg("could not resolve unit")
+			return unitScale{}, err
+		}
+		if uc.Gradient == 0 {
+			err = errors.New("UNIT_CONVERSION of unit " + u.Name + " has a gradient of zero")
+			log.Err(err).Msg("could not resolve unit")
+			return unitScale{}, err
+		}
+		us.gradient, us.offset = us.gradient/uc.Gradient, (us.offset-uc.Offset)/uc.Gradient
+		u, err = cd.getUnit(u.RefUnit.Unit)
+		if err != nil {
+			log.Err(err).Msg("could not resolve reference unit of unit " + us.name)
+			return unitScale{}, err
+		}
+	}
+	us.base = u.Name
+	se := u.SiExponents
+	us.hasExponents = se.LengthSet || se.MassSet || se.TimeSet || se.ElectricCurrentSet || se.TemperatureSet || se.AmountOfSubstanceSet || se.LuminousIntensitySet
+	us.exponents = [7]int16{se.Length, se.Mass, se.Time, se.ElectricCurrent, se.Temperature, se.AmountOfSubstance, se.LuminousIntensity}
+	return us, nil
+}
+
+// isCompatibleUnit reports whether values can be converted between both units.
+// only units whose REF_UNIT chains end in the same unit are compatible.
+// matching SI_EXPONENTS are not sufficient, e.g. rad/s and Hz are both s^-1 but differ by a factor of 2π.
+func isCompatibleUnit(a unitScale, b unitScale) bool {
+	return a.base == b.base
+}
+
+// dimension returns the dimension of the base unit as product of SI base units, e.g. "m s^-1".
+// dimensionless units yield "1", units without SI_EXPONENTS their base unit.
+func (us unitScale) dimension() string {
+	if !us.hasExponents {
+		return us.base
+	}
+	var parts []string
+	for i, e := range us.exponents {
+		switch e {
+		case 0:
+		case 1:
+			parts = append(parts, siBaseUnits[i])
+		default:
+			parts = append(parts, fmt.Sprintf("%s^%d", siBaseUnits[i], e))
+		}
+	}
+	if len(parts) == 0 {
+		return "1"
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,98 @@
+package calibrationReader
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestConvertUnit(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	si := func(name string, display string, exponents ...int16) {
+		se := a2l.SiExponents{Length: exponents[0], LengthSet: true, Mass: exponents[1], MassSet: true, Time: exponents[2], TimeSet: true,
+			ElectricCurrent: exponents[3], ElectricCurrentSet: true, Temperature: exponents[4], TemperatureSet: true,
+			AmountOfSubstance: exponents[5], AmountOfSubstanceSet: true, LuminousIntensity: exponents[6], LuminousIntensitySet: true}
+		m.Units[name] = a2l.Unit{Name: name, NameSet: true, Display: display, DisplaySet: true, Type: a2l.ExtendedSi, TypeSet: true, SiExponents: se}
+	}
+	derived := func(name string, display string, ref string, gradient float64, offset float64) {
+		m.Units[name] = a2l.Unit{Name: name, NameSet: true, Display: display, DisplaySet: true, Type: a2l.Derived, TypeSet: true,
+			RefUnit:        a2l.RefUnit{Unit: ref, UnitSet: true},
+			UnitConversion: a2l.UnitConversion{Gradient: gradient, GradientSet: true, Offset: offset, OffsetSet: true}}
+	}
+	si("metres_per_second", "\"[m/s]\"", 1, 0, -1, 0, 0, 0, 0)
+	si("radians_per_second", "\"[rad/s]\"", 0, 0, -1, 0, 0, 0, 0)
+	si("hertz", "\"[Hz]\"", 0, 0, -1, 0, 0, 0, 0)
+	si("kelvin", "\"[K]\"", 0, 0, 0, 0, 1, 0, 0)
+	derived("rpm", "\"[rpm]\"", "radians_per_second", 60/(2*math.Pi), 0)
+	derived("degree_celsius", "\"[°C]\"", "kelvin", 1, -273.15)
+	derived("degree_fahrenheit", "\"[°F]\"", "degree_celsius", 1.8, 32)
+	derived("loop_a", "\"[a]\"", "loop_b", 1, 0)
+	derived("loop_b", "\"[b]\"", "loop_a", 1, 0)
+
+	tests := []struct {
+		from     string
+		to       string
+		value    float64
+		expected float64
+	}{
+		{from: "metres_per_second", to: "kms_per_hour", value: 10, expected: 36},
+		{from: "km/h", to: "[m/s]", value: 36, expected: 10},
+		{from: "rpm", to: "radians_per_second", value: 60, expected: 2 * math.Pi},
+		{from: "degree_celsius", to: "kelvin", value: 25, expected: 298.15},
+		{from: "kelvin", to: "°C", value: 0, expected: -273.15},
+		{from: "degree_fahrenheit", to: "kelvin", value: 32, expected: 273.15},
+	}
+	for _, tt := range tests {
+		v, err := cd.ConvertUnit([]float64{tt.value}, tt.from, tt.to)
+		if err != nil {
+			t.Errorf("%s -> %s: %s", tt.from, tt.to, err)
+		} else if math.Abs(v[0]-tt.expected) > 1e-9 {
+			t.Errorf("%s -> %s: expected %v, got %v", tt.from, tt.to, tt.expected, v[0])
+		}
+	}
+	_, err := cd.ConvertUnit([]float64{1}, "kms_per_hour", "degree_celsius")
+	var de *DimensionError
+	if !errors.As(err, &de) || de.FromDimension != "m s^-1" || de.ToDimension != "K" {
+		t.Errorf("expected dimension error, got %v", err)
+	}
+	//same dimension, different base units: 60 rpm are 1 Hz, not 2π Hz, so no 1:1 conversion is done
+	_, err = cd.ConvertUnit([]float64{60}, "rpm", "hertz")
+	if !errors.As(err, &de) || de.FromDimension != "s^-1" || de.ToDimension != "s^-1" {
+		t.Errorf("expected dimension error for rpm -> hertz, got %v", err)
+	}
+	//SI_EXPONENTS are taken into account even if the exponent of the length is not set
+	m.Units["per_second"] = a2l.Unit{Name: "per_second", NameSet: true, Display: "\"[1/s]\"", DisplaySet: true, Type: a2l.ExtendedSi, TypeSet: true,
+		SiExponents: a2l.SiExponents{Time: -1, TimeSet: true}}
+	_, err = cd.ConvertUnit([]float64{1}, "per_second", "kelvin")
+	if !errors.As(err, &de) || de.FromDimension != "s^-1" {
+		t.Errorf("expected dimension s^-1, got %v", err)
+	}
+	for _, units := range [][2]string{{"loop_a", "kelvin"}, {"kelvin", "missing"}, {"newton", "kms_per_hour"}} {
+		if _, err := cd.ConvertUnit([]float64{1}, units[0], units[1]); err == nil {
+			t.Errorf("%s -> %s: expected error", units[0], units[1])
+		}
+	}
+
+	//the unit of the characteristic is taken from its compu method
+	name := "ASAM.C.SCALAR.SWORD.LINEAR_MUL_2"
+	if unit, err := cd.GetCharacteristicUnit(name); err != nil || unit != "m/s" {
+		t.Fatalf("expected unit m/s, got %s, %v", unit, err)
+	}
+	cv, err := cd.GetCharacteristicValues(name)
+	if err != nil {
+		t.Fatalf("could not get values of %s: %s", name, err)
+	}
+	converted, err := cd.GetCharacteristicValuesInUnit(name, "kms_per_hour")
+	if err != nil {
+		t.Fatalf("could not convert values of %s: %s", name, err)
+	}
+	if math.Abs(converted.PhyValues.(float64)-cv.PhyValues.(float64)*3.6) > 1e-9 {
+		t.Errorf("expected %v km/h, got %v", cv.PhyValues.(float64)*3.6, converted.PhyValues)
+	}
+}
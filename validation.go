@@ -0,0 +1,468 @@
+package calibrationReader
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
+)
+
+// ViolationSeverity rates how critical a violation is for the release of a dataset.
+type ViolationSeverity string
+
+const (
+	//SeverityWarning is used for values that are outside of the limits but within the extended limits
+	SeverityWarning ViolationSeverity = "WARNING"
+	//SeverityError is used for all values that must not be released
+	SeverityError ViolationSeverity = "ERROR"
+)
+
+// ViolationKind names the a2l rule that has been violated.
+type ViolationKind string
+
+const (
+	//ViolationLimits is reported for values outside of LOWER_LIMIT and UPPER_LIMIT
+	ViolationLimits ViolationKind = "LIMITS"
+	//ViolationExtendedLimits is reported for values outside of EXTENDED_LIMITS
+	ViolationExtendedLimits ViolationKind = "EXTENDED_LIMITS"
+	//ViolationMonotony is reported for axis points that do not follow the MONOTONY of the axis
+	ViolationMonotony ViolationKind = "MONOTONY"
+	//ViolationMaxGrad is reported for function values whose gradient along an axis exceeds MAX_GRAD
+	ViolationMaxGrad ViolationKind = "MAX_GRAD"
+	//ViolationMaxDiff is reported for values that differ from the reference dataset by more than MAX_DIFF
+	ViolationMaxDiff ViolationKind = "MAX_DIFF"
+	//ViolationGuardRails is reported for outermost values that differ from the values the guard rails demand
+	ViolationGuardRails ViolationKind = "GUARD_RAILS"
+	//ViolationUnreadable is reported for objects whose values could not be read and therefore not be validated
+	ViolationUnreadable ViolationKind = "UNREADABLE"
+)
+
+// limitTolerance is the relative tolerance used when comparing physical values against limits
+// to account for the rounding errors of the conversion.
+const limitTolerance = 1e-9
+
+// limits holds the plausible range of physical values and the extended range that must never be exceeded.
+type limits struct {
+	lower    float64
+	upper    float64
+	extended bool
+	extLower float64
+	extUpper float64
+}
+
+// Violation describes a single value of a characteristic or AXIS_PTS object that violates its a2l description.
+type Violation struct {
+	//Name is the identifier of the characteristic or AXIS_PTS object
+	Name string
+	//Field is the record layout field the value belongs to, i.e. "FncValues" or "AxisPtsX" to "AxisPts5"
+	Field string
+	//Index contains the coordinates of the value within the field, [x][y][z][4][5] for function values and [i] for axis points.
+	//empty for VALUE characteristics and unreadable objects
+	Index    []int
+	Kind     ViolationKind
+	Severity ViolationSeverity
+	//Value is the physical value that violates the rule
+	Value   float64
+	Message string
+}
+
+// Validate checks all characteristics and AXIS_PTS objects of the module against their limits, extended limits,
+// monotony, maximum gradients and guard rails. characteristics are validated before AXIS_PTS objects, both sorted by name.
+// objects that cannot be read are reported as ViolationUnreadable.
+// MAX_DIFF limits the change of a value with respect to a reference dataset and is checked by ValidateMaxDiff.
+func (cd *CalibrationData) Validate() []Violation {
+	module := cd.A2l.Project.Modules[cd.ModuleIndex]
+	var violations []Violation
+	names := make([]string, 0, len(module.Characteristics))
+	for name := range module.Characteristics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v, err := cd.ValidateCharacteristic(name)
+		if err != nil {
+			v = append(v, Violation{Name: name, Kind: ViolationUnreadable, Severity: SeverityError, Value: math.NaN(), Message: err.Error()})
+		}
+		violations = append(violations, v...)
+	}
+	names = names[:0]
+	for name := range module.AxisPts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v, err := cd.ValidateAxisPts(name)
+		if err != nil {
+			v = append(v, Violation{Name: name, Kind: ViolationUnreadable, Severity: SeverityError, Value: math.NaN(), Message: err.Error()})
+		}
+		violations = append(violations, v...)
+	}
+	return violations
+}
+
+// ValidateCharacteristic checks the values of the characteristic with the given name.
+// function values are checked against the limits of the characteristic,
+// axis points of standard axes against the limits and the monotony of their axis description.
+// the gradient of the function values along each axis is limited by MAX_GRAD of the axis description.
+// with GUARD_RAILS the outermost axis points must equal the axis limits
+// and the outermost function values the adjacent inner function values.
+// verbal and ASCII values are not validated.
+func (cd *CalibrationData) ValidateCharacteristic(name string) ([]Violation, error) {
+	cv, err := cd.GetCharacteristicValues(name)
+	if err != nil {
+		log.Err(err).Msg("could not validate characteristic " + name)
+		return nil, err
+	}
+	c := cv.characteristic
+	if c.Type == a2l.ASCII {
+		return nil, nil
+	}
+	var violations []Violation
+	phy, err := cd.convertValuesToPhysical(cv.fncValues, c.Conversion, cv.recordLayout.FncValues.Datatype)
+	if err != nil {
+		log.Err(err).Msg("could not validate characteristic " + name)
+		return nil, err
+	}
+	fnc, isNumeric := phy.([]float64)
+	if isNumeric {
+		fncLimits := limits{lower: c.LowerLimit, upper: c.UpperLimit, extended: c.ExtendedLimits.LowerLimitSet && c.ExtendedLimits.UpperLimitSet,
+			extLower: c.ExtendedLimits.LowerLimit, extUpper: c.ExtendedLimits.UpperLimit}
+		for i, v := range fnc {
+			violation, ok := checkLimits(v, fncLimits)
+			if !ok {
+				violation.Name, violation.Field, violation.Index = name, "FncValues", coordinates(cv.Dimensions, i)
+				violations = append(violations, violation)
+			}
+		}
+	}
+	for i, ad := range c.AxisDescr {
+		if i >= len(cv.Dimensions) {
+			break
+		}
+		_, axisPhy := cv.getAxisValuesRef(i)
+		axis, isNumericAxis := (*axisPhy).([]float64)
+		if !isNumericAxis {
+			continue
+		}
+		field := axisFieldNames[i]
+		if ad.Attribute == a2l.StdAxis {
+			l := limits{lower: ad.LowerLimit, upper: ad.UpperLimit, extended: ad.ExtendedLimits.LowerLimitSet && ad.ExtendedLimits.UpperLimitSet,
+				extLower: ad.ExtendedLimits.LowerLimit, extUpper: ad.ExtendedLimits.UpperLimit}
+			violations = append(violations, validateAxis(name, field, axis, l, ad.Monotony, c.GuardRails.Value)...)
+		}
+		if isNumeric && ad.MaxGrad.MaxGradientSet {
+			violations = append(violations, checkMaxGrad(name, fnc, cv.Dimensions, i, axis, ad.MaxGrad.MaxGradient)...)
+		}
+		if isNumeric && c.GuardRails.Value {
+			violations = append(violations, checkFncGuardRails(name, fnc, cv.Dimensions, i)...)
+		}
+	}
+	return violations, nil
+}
+
+// ValidateAxisPts checks the axis points of the AXIS_PTS object with the given name
+// against its limits, extended limits, monotony and guard rails.
+func (cd *CalibrationData) ValidateAxisPts(name string) ([]Violation, error) {
+	av, err := cd.GetAxisPtsValues(name)
+	if err != nil {
+		log.Err(err).Msg("could not validate axis points " + name)
+		return nil, err
+	}
+	axis, isNumeric := av.PhyValues.([]float64)
+	if !isNumeric {
+		return nil, nil
+	}
+	ap := av.axisPts
+	l := limits{lower: ap.LowerLimit, upper: ap.UpperLimit, extended: ap.ExtendedLimits.LowerLimitSet && ap.ExtendedLimits.UpperLimitSet,
+		extLower: ap.ExtendedLimits.LowerLimit, extUpper: ap.ExtendedLimits.UpperLimit}
+	return validateAxis(name, "AxisPtsX", axis, l, ap.Monotony, ap.GuardRails.Value), nil
+}
+
+// ValidateMaxDiff checks all characteristics and AXIS_PTS objects that define MAX_DIFF against the reference dataset,
+// which usually is the dataset the values have been adjusted from. every physical value may differ from the value
+// at the same position within the reference by at most MAX_DIFF. the objects are looked up by name within the module of the reference.
+// characteristics are checked before AXIS_PTS objects, both sorted by name.
+// objects that cannot be read in either dataset are reported as ViolationUnreadable.
+func (cd *CalibrationData) ValidateMaxDiff(ref *CalibrationData) []Violation {
+	module := cd.A2l.Project.Modules[cd.ModuleIndex]
+	var violations []Violation
+	var names []string
+	for name, c := range module.Characteristics {
+		if c.MaxDiffSet {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v, err := cd.validateCharacteristicMaxDiff(name, ref)
+		if err != nil {
+			v = append(v, Violation{Name: name, Kind: ViolationUnreadable, Severity: SeverityError, Value: math.NaN(), Message: err.Error()})
+		}
+		violations = append(violations, v...)
+	}
+	names = names[:0]
+	for name, ap := range module.AxisPts {
+		if ap.MaxDiffSet {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v, err := cd.validateAxisPtsMaxDiff(name, ref)
+		if err != nil {
+			v = append(v, Violation{Name: name, Kind: ViolationUnreadable, Severity: SeverityError, Value: math.NaN(), Message: err.Error()})
+		}
+		violations = append(violations, v...)
+	}
+	return violations
+}
+
+// validateCharacteristicMaxDiff compares the function values of the characteristic with those of the reference.
+// verbal and ASCII values are not validated.
+func (cd *CalibrationData) validateCharacteristicMaxDiff(name string, ref *CalibrationData) ([]Violation, error) {
+	cv, err := cd.GetCharacteristicValues(name)
+	if err != nil {
+		log.Err(err).Msg("could not validate characteristic " + name)
+		return nil, err
+	}
+	refCv, err := ref.GetCharacteristicValues(name)
+	if err != nil {
+		log.Err(err).Msg("could not read characteristic " + name + " of reference dataset")
+		return nil, err
+	}
+	if cv.characteristic.Type == a2l.ASCII {
+		return nil, nil
+	}
+	phy, err := cd.convertValuesToPhysical(cv.fncValues, cv.characteristic.Conversion, cv.recordLayout.FncValues.Datatype)
+	if err != nil {
+		log.Err(err).Msg("could not validate characteristic " + name)
+		return nil, err
+	}
+	refPhy, err := ref.convertValuesToPhysical(refCv.fncValues, refCv.characteristic.Conversion, refCv.recordLayout.FncValues.Datatype)
+	if err != nil {
+		log.Err(err).Msg("could not read characteristic " + name + " of reference dataset")
+		return nil, err
+	}
+	fnc, isNumeric := phy.([]float64)
+	refFnc, isNumericRef := refPhy.([]float64)
+	if !isNumeric || !isNumericRef {
+		return nil, nil
+	}
+	return checkMaxDiff(name, "FncValues", fnc, refFnc, cv.Dimensions, cv.characteristic.MaxDiff), nil
+}
+
+// validateAxisPtsMaxDiff compares the axis points of the AXIS_PTS object with those of the reference.
+func (cd *CalibrationData) validateAxisPtsMaxDiff(name string, ref *CalibrationData) ([]Violation, error) {
+	av, err := cd.GetAxisPtsValues(name)
+	if err != nil {
+		log.Err(err).Msg("could not validate axis points " + name)
+		return nil, err
+	}
+	refAv, err := ref.GetAxisPtsValues(name)
+	if err != nil {
+		log.Err(err).Msg("could not read axis points " + name + " of reference dataset")
+		return nil, err
+	}
+	axis, isNumeric := av.PhyValues.([]float64)
+	refAxis, isNumericRef := refAv.PhyValues.([]float64)
+	if !isNumeric || !isNumericRef {
+		return nil, nil
+	}
+	return checkMaxDiff(name, "AxisPtsX", axis, refAxis, []int{len(axis)}, av.axisPts.MaxDiff), nil
+}
+
+var axisFieldNames = []string{"AxisPtsX", "AxisPtsY", "AxisPtsZ", "AxisPts4", "AxisPts5"}
+
+// validateAxis checks limits, monotony and guard rails of a single axis.
+func validateAxis(name string, field string, axis []float64, l limits, m a2l.Monotony, guardRails bool) []Violation {
+	var violations []Violation
+	for i, v := range axis {
+		violation, ok := checkLimits(v, l)
+		if !ok {
+			violation.Name, violation.Field, violation.Index = name, field, []int{i}
+			violations = append(violations, violation)
+		}
+	}
+	if m.MonotonySet {
+		violations = append(violations, checkMonotony(name, field, axis, m.Monotony)...)
+	}
+	if guardRails && len(axis) > 0 {
+		edges := []int{0, len(axis) - 1}
+		//decreasing axes start at the upper limit
+		expected := []float64{l.lower, l.upper}
+		if axis[0] > axis[len(axis)-1] {
+			expected[0], expected[1] = l.upper, l.lower
+		}
+		for i, e := range edges {
+			if !equalWithinTolerance(axis[e], expected[i]) {
+				violations = append(violations, Violation{Name: name, Field: field, Index: []int{e}, Kind: ViolationGuardRails, Severity: SeverityError, Value: axis[e],
+					Message: "outermost axis point " + formatFloat(axis[e]) + " differs from axis limit " + formatFloat(expected[i])})
+			}
+		}
+	}
+	return violations
+}
+
+// checkLimits checks a physical value against the limits and extended limits.
+// values outside of the limits are warnings as long as they are within the extended limits.
+// without extended limits the limits are treated as hard limits.
+func checkLimits(v float64, l limits) (Violation, bool) {
+	outside := belowLimit(v, l.lower) || aboveLimit(v, l.upper)
+	if l.extended && (belowLimit(v, l.extLower) || aboveLimit(v, l.extUpper)) {
+		return Violation{Kind: ViolationExtendedLimits, Severity: SeverityError, Value: v,
+			Message: "value " + formatFloat(v) + " outside of extended limits [" + formatFloat(l.extLower) + ", " + formatFloat(l.extUpper) + "]"}, false
+	}
+	if !outside {
+		return Violation{}, true
+	}
+	severity := SeverityError
+	if l.extended {
+		severity = SeverityWarning
+	}
+	return Violation{Kind: ViolationLimits, Severity: severity, Value: v,
+		Message: "value " + formatFloat(v) + " outside of limits [" + formatFloat(l.lower) + ", " + formatFloat(l.upper) + "]"}, false
+}
+
+// checkMonotony reports every axis point that breaks the monotony with respect to its predecessor.
+// MONOTONOUS and STRICT_MON accept either direction as long as the whole axis follows it.
+func checkMonotony(name string, field string, axis []float64, mt a2l.MonotonyTypeEnum) []Violation {
+	if len(axis) < 2 {
+		return nil
+	}
+	switch mt {
+	case a2l.Monotonous:
+		mt = a2l.MonIncrease
+		if axis[0] > axis[len(axis)-1] {
+			mt = a2l.MonDecrease
+		}
+	case a2l.StrictMon:
+		mt = a2l.StrictIncrease
+		if axis[0] > axis[len(axis)-1] {
+			mt = a2l.StrictDecrease
+		}
+	}
+	var violations []Violation
+	for i := 1; i < len(axis); i++ {
+		var ok bool
+		switch mt {
+		case a2l.MonIncrease:
+			ok = axis[i] >= axis[i-1]
+		case a2l.MonDecrease:
+			ok = axis[i] <= axis[i-1]
+		case a2l.StrictIncrease:
+			ok = axis[i] > axis[i-1]
+		case a2l.StrictDecrease:
+			ok = axis[i] < axis[i-1]
+		default:
+			return nil
+		}
+		if !ok {
+			violations = append(violations, Violation{Name: name, Field: field, Index: []int{i}, Kind: ViolationMonotony, Severity: SeverityError, Value: axis[i],
+				Message: "axis point " + formatFloat(axis[i]) + " after " + formatFloat(axis[i-1]) + " violates monotony " + string(mt)})
+		}
+	}
+	return violations
+}
+
+// checkMaxGrad reports every pair of neighbouring function values along the given axis
+// whose gradient exceeds the maximum gradient. the violation is reported at the second value of the pair.
+func checkMaxGrad(name string, fnc []float64, dims []int, axisIndex int, axis []float64, maxGrad float64) []Violation {
+	var violations []Violation
+	for i := range fnc {
+		coords := coordinates(dims, i)
+		k := coords[axisIndex]
+		if k == 0 || k >= len(axis) {
+			continue
+		}
+		dx := axis[k] - axis[k-1]
+		if dx == 0 {
+			//equal axis points are a monotony violation
+			continue
+		}
+		prev := append([]int(nil), coords...)
+		prev[axisIndex]--
+		grad := (fnc[i] - fnc[flatIndex(dims, prev...)]) / dx
+		if math.Abs(grad) > maxGrad*(1+limitTolerance) {
+			violations = append(violations, Violation{Name: name, Field: "FncValues", Index: coords, Kind: ViolationMaxGrad, Severity: SeverityError, Value: fnc[i],
+				Message: "gradient " + formatFloat(grad) + " along axis " + axisFieldNames[axisIndex] + " exceeds MAX_GRAD " + formatFloat(maxGrad)})
+		}
+	}
+	return violations
+}
+
+// checkMaxDiff reports every value that differs from the value at the same position within the reference by more than maxDiff.
+// values can only be compared position by position, so a changed number of values is reported once for the whole field.
+func checkMaxDiff(name string, field string, vals []float64, ref []float64, dims []int, maxDiff float64) []Violation {
+	if len(vals) != len(ref) {
+		return []Violation{{Name: name, Field: field, Kind: ViolationMaxDiff, Severity: SeverityError, Value: math.NaN(),
+			Message: "number of values changed from " + strconv.Itoa(len(ref)) + " to " + strconv.Itoa(len(vals)) + " and cannot be compared with MAX_DIFF " + formatFloat(maxDiff)}}
+	}
+	var violations []Violation
+	for i, v := range vals {
+		diff := v - ref[i]
+		if math.Abs(diff) > maxDiff*(1+limitTolerance) {
+			violations = append(violations, Violation{Name: name, Field: field, Index: coordinates(dims, i), Kind: ViolationMaxDiff, Severity: SeverityError, Value: v,
+				Message: "difference " + formatFloat(diff) + " to reference value " + formatFloat(ref[i]) + " exceeds MAX_DIFF " + formatFloat(maxDiff)})
+		}
+	}
+	return violations
+}
+
+// checkFncGuardRails reports every outermost function value along the given axis
+// that differs from its adjacent inner function value.
+func checkFncGuardRails(name string, fnc []float64, dims []int, axisIndex int) []Violation {
+	n := dims[axisIndex]
+	if n < 3 {
+		return nil
+	}
+	var violations []Violation
+	for i := range fnc {
+		coords := coordinates(dims, i)
+		inner := append([]int(nil), coords...)
+		switch coords[axisIndex] {
+		case 0:
+			inner[axisIndex] = 1
+		case n - 1:
+			inner[axisIndex] = n - 2
+		default:
+			continue
+		}
+		expected := fnc[flatIndex(dims, inner...)]
+		if !equalWithinTolerance(fnc[i], expected) {
+			violations = append(violations, Violation{Name: name, Field: "FncValues", Index: coords, Kind: ViolationGuardRails, Severity: SeverityError, Value: fnc[i],
+				Message: "outermost function value " + formatFloat(fnc[i]) + " along axis " + axisFieldNames[axisIndex] + " differs from adjacent value " + formatFloat(expected)})
+		}
+	}
+	return violations
+}
+
+// coordinates is the inverse of flatIndex and computes the coordinates (x, y, z, 4, 5) of the value at the given position.
+func coordinates(dims []int, idx int) []int {
+	if len(dims) == 0 {
+		return nil
+	}
+	coords := make([]int, len(dims))
+	for i, d := range dims {
+		coords[i] = idx % d
+		idx /= d
+	}
+	return coords
+}
+
+func belowLimit(v float64, limit float64) bool {
+	return v < limit-math.Abs(limit)*limitTolerance
+}
+
+func aboveLimit(v float64, limit float64) bool {
+	return v > limit+math.Abs(limit)*limitTolerance
+}
+
+func equalWithinTolerance(a float64, b float64) bool {
+	return math.Abs(a-b) <= math.Max(math.Abs(a), math.Abs(b))*limitTolerance
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
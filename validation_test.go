@@ -0,0 +1,121 @@
+package calibrationReader
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestValidate(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+
+	//the demo dataset contains characteristics without record layout and a virtual characteristic outside of its limits
+	kinds := make(map[string]ViolationKind)
+	for _, v := range cd.Validate() {
+		kinds[v.Name] = v.Kind
+	}
+	if kinds["F_INJ_COR"] != ViolationUnreadable || kinds["ASAM.C.VIRTUAL.REF_1.SWORD"] != ViolationLimits {
+		t.Errorf("expected F_INJ_COR to be unreadable and ASAM.C.VIRTUAL.REF_1.SWORD to violate its limits, got %v", kinds)
+	}
+	for _, name := range []string{"ASAM.C.CURVE.STD_AXIS", "ASAM.C.CURVE.STD_AXIS.MONOTONY_STRICT_INCREASE", "ASAM.C.CURVE.STD_AXIS.MONOTONY_STRICT_DECREASE"} {
+		if _, exists := kinds[name]; exists {
+			t.Errorf("expected %s to be valid", name)
+		}
+	}
+
+	//axis [-5 -1 2 4 5 8 14 22], values [9 13 7 15 71 6 -1 -3]
+	name := "ASAM.C.CURVE.STD_AXIS"
+	c := m.Characteristics[name]
+	c.UpperLimit = 50
+	c.AxisDescr[0].MaxGrad.MaxGradient = 20
+	c.GuardRails.Value = true
+	m.Characteristics[name] = c
+	violations, err := cd.ValidateCharacteristic(name)
+	if err != nil {
+		t.Fatalf("could not validate %s: %s", name, err)
+	}
+	var found []string
+	for _, v := range violations {
+		found = append(found, fmt.Sprint(v.Field, v.Index, v.Kind, v.Severity))
+	}
+	expected := []string{
+		"FncValues[4]LIMITSWARNING",
+		"AxisPtsX[0]GUARD_RAILSERROR",
+		"AxisPtsX[7]GUARD_RAILSERROR",
+		"FncValues[4]MAX_GRADERROR",
+		"FncValues[5]MAX_GRADERROR",
+		"FncValues[0]GUARD_RAILSERROR",
+		"FncValues[7]GUARD_RAILSERROR",
+	}
+	if strings.Join(found, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected %v, got %v", expected, found)
+	}
+
+	//axis [1 2 3 4 5 6 7 8]
+	name = "ASAM.C.CURVE.STD_AXIS.MONOTONY_STRICT_INCREASE"
+	c = m.Characteristics[name]
+	c.AxisDescr[0].Monotony = a2l.Monotony{Monotony: a2l.StrictDecrease, MonotonySet: true}
+	m.Characteristics[name] = c
+	violations, err = cd.ValidateCharacteristic(name)
+	if err != nil || len(violations) != 7 || violations[0].Kind != ViolationMonotony || fmt.Sprint(violations[0].Index) != "[1]" {
+		t.Errorf("expected 7 monotony violations, got %v, %v", violations, err)
+	}
+	c.AxisDescr[0].Monotony = a2l.Monotony{Monotony: a2l.StrictMon, MonotonySet: true}
+	m.Characteristics[name] = c
+	if violations, err = cd.ValidateCharacteristic(name); err != nil || len(violations) != 0 {
+		t.Errorf("expected strictly increasing axis to be strictly monotonous, got %v, %v", violations, err)
+	}
+
+	//limits are warnings as long as the extended limits are respected
+	l := limits{lower: 0, upper: 10, extended: true, extLower: -10, extUpper: 20}
+	for v, kind := range map[float64]string{5: "", 10.000000000001: "", 15: "LIMITSWARNING", -11: "EXTENDED_LIMITSERROR"} {
+		violation, ok := checkLimits(v, l)
+		if ok != (kind == "") || (!ok && fmt.Sprint(violation.Kind, violation.Severity) != kind) {
+			t.Errorf("%v: expected %q, got %v", v, kind, violation)
+		}
+	}
+	if coords := coordinates([]int{2, 3}, flatIndex([]int{2, 3}, 1, 2)); fmt.Sprint(coords) != "[1 2]" {
+		t.Errorf("expected coordinates [1 2], got %v", coords)
+	}
+}
+
+func TestValidateMaxDiff(t *testing.T) {
+	cd := readDemoCalibration(t)
+	ref := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+
+	//values [9 13 7 15 71 6 -1 -3]
+	name := "ASAM.C.CURVE.STD_AXIS"
+	c := m.Characteristics[name]
+	c.MaxDiff, c.MaxDiffSet = 5, true
+	m.Characteristics[name] = c
+	//the demo dataset contains characteristics with MAX_DIFF that cannot be read
+	for _, v := range cd.ValidateMaxDiff(&ref) {
+		if v.Kind != ViolationUnreadable {
+			t.Errorf("expected unchanged dataset to be valid, got %v", v)
+		}
+	}
+	err := cd.SetCharacteristicPhysical(name, CharacteristicValues{PhyValues: []float64{9, 13, 12, 15, 71, 6, -1, -9}})
+	if err != nil {
+		t.Fatalf("could not set %s: %s", name, err)
+	}
+	var found []string
+	for _, v := range cd.ValidateMaxDiff(&ref) {
+		if v.Name != name {
+			continue
+		}
+		found = append(found, fmt.Sprint(v.Name, v.Field, v.Index, v.Kind, v.Value))
+	}
+	if fmt.Sprint(found) != "[ASAM.C.CURVE.STD_AXISFncValues[7]MAX_DIFF-9]" {
+		t.Errorf("expected MAX_DIFF violation at index 7, got %v", found)
+	}
+	if v := checkMaxDiff(name, "FncValues", []float64{1, 2}, []float64{1}, []int{2}, 5); len(v) != 1 || v[0].Kind != ViolationMaxDiff {
+		t.Errorf("expected changed number of values to be reported, got %v", v)
+	}
+}
@@ -0,0 +1,104 @@
+package calibrationReader
+
+import (
+	"errors"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog/log"
+)
+
+// computeVirtualValues computes the values of a characteristic with VIRTUAL_CHARACTERISTIC
+// from the physical values of its referenced characteristics X1..Xn.
+// virtual characteristics have no memory within the ecu, so their values are read-only.
+// curves and maps take their dimensions and axis points from the first referenced characteristic of the same type.
+// resolving holds the names of the characteristics whose values are currently computed including this one.
+func (cv *CharacteristicValues) computeVirtualValues(cd *CalibrationData, resolving []string) error {
+	cv.IsVirtual = true
+	cv.ReadOnly = true
+	vc := cv.characteristic.VirtualCharacteristic[0]
+	if !vc.FormulaSet {
+		err := errors.New("virtual characteristic " + cv.Name + " has no formula")
+		log.Err(err).Msg("could not compute virtual characteristic")
+		return err
+	}
+	refCvs, refs, err := cd.readReferences(cv.Name, vc.Characteristic, resolving)
+	if err != nil {
+		log.Err(err).Msg("could not compute virtual characteristic " + cv.Name)
+		return err
+	}
+	switch cv.Type {
+	case a2l.ASCII:
+		err = errors.New("ASCII characteristic " + cv.Name + " cannot be virtual")
+		log.Err(err).Msg("could not compute virtual characteristic")
+		return err
+	case a2l.Value:
+		cv.Dimensions = nil
+	case a2l.ValBlk:
+		cv.Dimensions, err = cv.getDimensions()
+		if err != nil {
+			log.Err(err).Msg("could not determine dimensions of virtual characteristic " + cv.Name)
+			return err
+		}
+	default:
+		err = cv.copyShapeOfReference(refCvs)
+		if err != nil {
+			log.Err(err).Msg("could not determine dimensions of virtual characteristic " + cv.Name)
+			return err
+		}
+	}
+	n := 1
+	for _, d := range cv.Dimensions {
+		n *= d
+	}
+	phy, err := cd.evalElementWise(cv.Name, trimQuotes(vc.Formula), refs, n)
+	if err != nil {
+		log.Err(err).Msg("could not compute virtual characteristic " + cv.Name)
+		return err
+	}
+	//values exceeding the datatype are saturated just like the ecu would store them
+	dec, err := cd.ConvertPhysicalToDecimal(phy, cv.characteristic.Conversion, cv.recordLayout.FncValues.Datatype)
+	var rangeErr *RangeError
+	if errors.As(err, &rangeErr) {
+		cv.Saturated = true
+	} else if err != nil {
+		log.Err(err).Msg("could not compute virtual characteristic " + cv.Name)
+		return err
+	}
+	cv.fncValues = dec
+	cv.RawValues, err = shapeValues(cv.fncValues, cv.Dimensions)
+	if err != nil {
+		log.Err(err).Msg("could not shape raw values of virtual characteristic " + cv.Name)
+		return err
+	}
+	conv, err := cd.convertValuesToPhysical(cv.fncValues, cv.characteristic.Conversion, cv.recordLayout.FncValues.Datatype)
+	if err != nil {
+		log.Err(err).Msg("could not convert values of virtual characteristic " + cv.Name)
+		return err
+	}
+	cv.PhyValues, err = shapeValues(conv, cv.Dimensions)
+	if err != nil {
+		log.Err(err).Msg("could not shape physical values of virtual characteristic " + cv.Name)
+		return err
+	}
+	return nil
+}
+
+// copyShapeOfReference takes the dimensions and axis points of a virtual curve, map or cuboid
+// from the first referenced characteristic of the same type.
+func (cv *CharacteristicValues) copyShapeOfReference(refs []CharacteristicValues) error {
+	for _, r := range refs {
+		if r.Type != cv.Type {
+			continue
+		}
+		cv.Dimensions = append([]int(nil), r.Dimensions...)
+		cv.AxisXRawValues, cv.AxisXPhyValues = r.AxisXRawValues, r.AxisXPhyValues
+		cv.AxisYRawValues, cv.AxisYPhyValues = r.AxisYRawValues, r.AxisYPhyValues
+		cv.AxisZRawValues, cv.AxisZPhyValues = r.AxisZRawValues, r.AxisZPhyValues
+		cv.Axis4RawValues, cv.Axis4PhyValues = r.Axis4RawValues, r.Axis4PhyValues
+		cv.Axis5RawValues, cv.Axis5PhyValues = r.Axis5RawValues, r.Axis5PhyValues
+		return nil
+	}
+	err := errors.New("virtual characteristic " + cv.Name + " references no characteristic of type " + string(cv.Type))
+	log.Err(err).Msg("could not determine dimensions")
+	return err
+}
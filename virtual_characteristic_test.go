@@ -0,0 +1,92 @@
+package calibrationReader
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/JustinasPuzas/calibrationReader/a2l"
+	"github.com/rs/zerolog"
+)
+
+func TestVirtualCharacteristics(t *testing.T) {
+	cd := readDemoCalibration(t)
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+	m := &cd.A2l.Project.Modules[cd.ModuleIndex]
+	m.ModPar.SystemConstants["\"System_Constant_1\""] = a2l.SystemConstant{Name: "\"System_Constant_1\"", NameSet: true, Value: "\"-3.45\"", ValueSet: true}
+
+	x1, err := cd.GetCharacteristicValues("ASAM.C.SCALAR.SBYTE.IDENTICAL")
+	if err != nil {
+		t.Fatalf("could not read reference: %s", err)
+	}
+	x2, err := cd.GetCharacteristicValues("ASAM.C.SCALAR.UBYTE.IDENTICAL")
+	if err != nil {
+		t.Fatalf("could not read reference: %s", err)
+	}
+	sbyte, ubyte := x1.PhyValues.(float64), x2.PhyValues.(float64)
+	for name, expected := range map[string]float64{
+		"ASAM.C.VIRTUAL.REF_1.SWORD":       sbyte - 9,
+		"ASAM.C.VIRTUAL.REF_2.UWORD":       ubyte + 19,
+		"ASAM.C.VIRTUAL.REF_3.SWORD":       sbyte - 9 + ubyte + 19,
+		"ASAM.C.VIRTUAL.SYSTEM_CONSTANT_1": ubyte - 3.45,
+	} {
+		cv, err := cd.GetCharacteristicValues(name)
+		if err != nil {
+			t.Errorf("could not compute %s: %s", name, err)
+			continue
+		}
+		if phy, ok := cv.PhyValues.(float64); !ok || math.Abs(phy-expected) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", name, expected, cv.PhyValues)
+		}
+		if !cv.IsVirtual || !cv.ReadOnly {
+			t.Errorf("%s: expected to be virtual and read-only", name)
+		}
+		if err = cd.SetCharacteristicRaw(name, CharacteristicValues{RawValues: 0.0}); err == nil {
+			t.Errorf("%s: expected writing a virtual characteristic to fail", name)
+		}
+		if _, err = cd.GetRecordLayoutMap(name); err == nil {
+			t.Errorf("%s: expected virtual characteristic to have no record layout map", name)
+		}
+	}
+	cv, err := cd.GetCharacteristicValues("ASAM.C.SCALAR.UBYTE.IDENTICAL")
+	if err != nil || cv.IsVirtual {
+		t.Errorf("expected a normal characteristic, got %+v, %v", cv, err)
+	}
+
+	if cv.Saturated {
+		t.Errorf("expected values of %s not to be saturated", cv.Name)
+	}
+
+	//values exceeding the datatype are saturated
+	name := "ASAM.C.VIRTUAL.REF_1.SWORD"
+	c := m.Characteristics[name]
+	vc := c.VirtualCharacteristic[0]
+	c.VirtualCharacteristic = []a2l.VirtualCharacteristic{{Formula: "\"X1 * 1000000\"", FormulaSet: true, Characteristic: vc.Characteristic, CharacteristicSet: true}}
+	m.Characteristics[name] = c
+	cv, err = cd.GetCharacteristicValues(name)
+	if err != nil || !cv.Saturated || (cv.RawValues != 32767.0 && cv.RawValues != -32768.0) {
+		t.Errorf("expected saturated value, got %v, %v, %v", cv.RawValues, cv.Saturated, err)
+	}
+	c.VirtualCharacteristic = []a2l.VirtualCharacteristic{vc}
+	m.Characteristics[name] = c
+
+	//dependent characteristics may reference virtual ones
+	dcc, err := cd.CheckDependentCharacteristic("ASAM.C.DEPENDENT.REF_5.FLOAT64_IEEE")
+	if err != nil {
+		t.Errorf("could not check dependent characteristic referencing a virtual characteristic: %s", err)
+	} else if math.Abs(dcc.Computed[0]-(ubyte-3.45)*2) > 1e-9 {
+		t.Errorf("expected REF_5 to be %v, got %v", (ubyte-3.45)*2, dcc.Computed)
+	}
+
+	//cyclic references are detected
+	for name, ref := range map[string]string{"ASAM.C.VIRTUAL.REF_1.SWORD": "ASAM.C.VIRTUAL.REF_3.SWORD", "ASAM.C.VIRTUAL.REF_2.UWORD": "ASAM.C.VIRTUAL.REF_2.UWORD"} {
+		c := m.Characteristics[name]
+		c.VirtualCharacteristic = []a2l.VirtualCharacteristic{{Formula: "\"X1\"", FormulaSet: true, Characteristic: []string{ref}, CharacteristicSet: true}}
+		m.Characteristics[name] = c
+	}
+	for _, name := range []string{"ASAM.C.VIRTUAL.REF_1.SWORD", "ASAM.C.VIRTUAL.REF_2.UWORD", "ASAM.C.VIRTUAL.REF_3.SWORD"} {
+		if _, err = cd.GetCharacteristicValues(name); err == nil || !strings.Contains(err.Error(), "cyclic") {
+			t.Errorf("%s: expected cyclic reference to be detected, got %v", name, err)
+		}
+	}
+}